@@ -0,0 +1,275 @@
+package openaiorgs
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// DefaultStaleLoginAge is how long a user can go without logging in before an
+// access review flags them, when AccessReviewOptions.StaleAfter is not set.
+const DefaultStaleLoginAge = 90 * 24 * time.Hour
+
+// Access review finding kinds.
+const (
+	// AccessFindingStaleLogin flags a user with no successful login in the review
+	// window who was added to the organization before the window began.
+	AccessFindingStaleLogin = "stale_login"
+	// AccessFindingOrphanedKey flags an API key whose owner is no longer in the organization or project.
+	AccessFindingOrphanedKey = "orphaned_key"
+	// AccessFindingNoProjectOwner flags a project without any user holding the owner role.
+	AccessFindingNoProjectOwner = "no_project_owner"
+)
+
+// Principal types used in AccessEntry.PrincipalType.
+const (
+	PrincipalTypeUser           = "user"
+	PrincipalTypeServiceAccount = "service_account"
+	PrincipalTypeAPIKey         = "api_key"
+)
+
+// AccessReviewOptions configures BuildAccessReview.
+type AccessReviewOptions struct {
+	// StaleAfter is the login age after which users are flagged. Defaults to DefaultStaleLoginAge.
+	StaleAfter time.Duration
+	// IncludeArchived also reviews archived projects.
+	IncludeArchived bool
+	// Concurrency is the number of projects fetched in parallel.
+	Concurrency int
+	// Now overrides the review time, mainly for tests. Defaults to time.Now.
+	Now time.Time
+}
+
+// AccessEntry is one grant of access: an organization membership, a project
+// membership, a service account or an API key.
+type AccessEntry struct {
+	// ProjectID and ProjectName are empty for organization-level entries.
+	ProjectID     string     `json:"project_id,omitempty"`
+	ProjectName   string     `json:"project_name,omitempty"`
+	PrincipalType string     `json:"principal_type"`
+	PrincipalID   string     `json:"principal_id"`
+	Name          string     `json:"name,omitempty"`
+	Email         string     `json:"email,omitempty"`
+	Role          string     `json:"role,omitempty"`
+	Owner         string     `json:"owner,omitempty"`
+	LastLogin     *time.Time `json:"last_login,omitempty"`
+	Flags         []string   `json:"flags,omitempty"`
+}
+
+// AccessFinding is an issue a reviewer should act on.
+type AccessFinding struct {
+	Kind        string `json:"kind"`
+	ProjectID   string `json:"project_id,omitempty"`
+	PrincipalID string `json:"principal_id,omitempty"`
+	Message     string `json:"message"`
+}
+
+// AccessReview is an organization-wide snapshot of who has access to what.
+type AccessReview struct {
+	GeneratedAt time.Time       `json:"generated_at"`
+	StaleAfter  time.Duration   `json:"stale_after"`
+	Entries     []AccessEntry   `json:"entries"`
+	Findings    []AccessFinding `json:"findings"`
+}
+
+// BuildAccessReview collects organization users, every project's users, service
+// accounts and API keys, and successful logins from the audit log, then flags
+// stale users, orphaned keys and projects without an owner.
+//
+// Login history comes from "login.succeeded" audit events inside the StaleAfter
+// window, so audit logging must be enabled for the organization. Users added to
+// the organization within that window are not flagged as stale.
+func BuildAccessReview(c OpenAIOrgsClient, opts AccessReviewOptions) (*AccessReview, error) {
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	staleAfter := opts.StaleAfter
+	if staleAfter <= 0 {
+		staleAfter = DefaultStaleLoginAge
+	}
+
+	users, err := ListAll(func(after string) (*ListResponse[User], error) {
+		return c.ListUsers(100, after)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	lastLogins, err := collectLastLogins(c, now.Add(-staleAfter))
+	if err != nil {
+		return nil, err
+	}
+
+	inventory, err := CollectProjectInventory(c, InventoryOptions{
+		IncludeArchived: opts.IncludeArchived,
+		Concurrency:     opts.Concurrency,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	review := &AccessReview{
+		GeneratedAt: now,
+		StaleAfter:  staleAfter,
+	}
+	staleDays := int(staleAfter.Hours() / 24)
+
+	orgUsers := make(map[string]bool, len(users))
+	staleUsers := make(map[string]bool)
+	for _, user := range users {
+		orgUsers[user.ID] = true
+		entry := AccessEntry{
+			PrincipalType: PrincipalTypeUser,
+			PrincipalID:   user.ID,
+			Name:          user.Name,
+			Email:         user.Email,
+			Role:          user.Role,
+		}
+		if login, ok := lastLogins[user.ID]; ok {
+			entry.LastLogin = &login
+		} else if user.AddedAt.Time().Before(now.Add(-staleAfter)) {
+			// Users added inside the window haven't had a chance to log in yet.
+			staleUsers[user.ID] = true
+			entry.Flags = append(entry.Flags, AccessFindingStaleLogin)
+			review.Findings = append(review.Findings, AccessFinding{
+				Kind:        AccessFindingStaleLogin,
+				PrincipalID: user.ID,
+				Message:     fmt.Sprintf("%s has not logged in for %d days", user.Email, staleDays),
+			})
+		}
+		review.Entries = append(review.Entries, entry)
+	}
+
+	for _, project := range inventory {
+		review.addProject(project, orgUsers, staleUsers, lastLogins)
+	}
+
+	return review, nil
+}
+
+func (r *AccessReview) addProject(inv ProjectInventory, orgUsers, staleUsers map[string]bool, lastLogins map[string]time.Time) {
+	project := inv.Project
+	hasOwner := false
+	for _, user := range inv.Users {
+		if user.Role == string(RoleTypeOwner) {
+			hasOwner = true
+		}
+		entry := AccessEntry{
+			ProjectID:     project.ID,
+			ProjectName:   project.Name,
+			PrincipalType: PrincipalTypeUser,
+			PrincipalID:   user.ID,
+			Name:          user.Name,
+			Email:         user.Email,
+			Role:          user.Role,
+		}
+		if login, ok := lastLogins[user.ID]; ok {
+			entry.LastLogin = &login
+		}
+		if staleUsers[user.ID] {
+			entry.Flags = append(entry.Flags, AccessFindingStaleLogin)
+		}
+		r.Entries = append(r.Entries, entry)
+	}
+	if !hasOwner {
+		r.Findings = append(r.Findings, AccessFinding{
+			Kind:      AccessFindingNoProjectOwner,
+			ProjectID: project.ID,
+			Message:   fmt.Sprintf("project %s (%s) has no owner", project.Name, project.ID),
+		})
+	}
+
+	serviceAccounts := make(map[string]bool, len(inv.ServiceAccounts))
+	for _, sa := range inv.ServiceAccounts {
+		serviceAccounts[sa.ID] = true
+		r.Entries = append(r.Entries, AccessEntry{
+			ProjectID:     project.ID,
+			ProjectName:   project.Name,
+			PrincipalType: PrincipalTypeServiceAccount,
+			PrincipalID:   sa.ID,
+			Name:          sa.Name,
+			Role:          sa.Role,
+		})
+	}
+
+	for _, key := range inv.APIKeys {
+		entry := AccessEntry{
+			ProjectID:     project.ID,
+			ProjectName:   project.Name,
+			PrincipalType: PrincipalTypeAPIKey,
+			PrincipalID:   key.ID,
+			Name:          key.Name,
+		}
+
+		orphaned := false
+		switch {
+		case key.Owner.User != nil:
+			entry.Owner = key.Owner.User.Email
+			if entry.Owner == "" {
+				entry.Owner = key.Owner.User.ID
+			}
+			entry.Role = key.Owner.User.Role
+			orphaned = !orgUsers[key.Owner.User.ID]
+		case key.Owner.SA != nil:
+			entry.Owner = key.Owner.SA.Name
+			if entry.Owner == "" {
+				entry.Owner = key.Owner.SA.ID
+			}
+			entry.Role = key.Owner.SA.Role
+			orphaned = !serviceAccounts[key.Owner.SA.ID]
+		}
+		if orphaned {
+			entry.Flags = append(entry.Flags, AccessFindingOrphanedKey)
+			r.Findings = append(r.Findings, AccessFinding{
+				Kind:        AccessFindingOrphanedKey,
+				ProjectID:   project.ID,
+				PrincipalID: key.ID,
+				Message:     fmt.Sprintf("API key %s in project %s is owned by %s, who no longer has access", key.Name, project.Name, entry.Owner),
+			})
+		}
+		r.Entries = append(r.Entries, entry)
+	}
+}
+
+// collectLastLogins returns the most recent successful login per user ID since the given time.
+func collectLastLogins(c OpenAIOrgsClient, since time.Time) (map[string]time.Time, error) {
+	logs, err := ListAllAuditLogs(c, AuditLogListParams{
+		EventTypes:  []string{"login.succeeded"},
+		EffectiveAt: &EffectiveAt{Gte: since.Unix()},
+		Limit:       100,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list login events: %w", err)
+	}
+
+	lastLogins := make(map[string]time.Time)
+	for _, log := range logs {
+		if log.Actor.Session == nil {
+			continue
+		}
+		userID := log.Actor.Session.User.ID
+		at := log.EffectiveAt.Time()
+		if prev, ok := lastLogins[userID]; !ok || at.After(prev) {
+			lastLogins[userID] = at
+		}
+	}
+	return lastLogins, nil
+}
+
+// SortedFindings returns the findings grouped by kind, then by project and principal.
+func (r *AccessReview) SortedFindings() []AccessFinding {
+	findings := make([]AccessFinding, len(r.Findings))
+	copy(findings, r.Findings)
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.ProjectID != b.ProjectID {
+			return a.ProjectID < b.ProjectID
+		}
+		return a.PrincipalID < b.PrincipalID
+	})
+	return findings
+}
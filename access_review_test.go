@@ -0,0 +1,98 @@
+package openaiorgs
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuildAccessReview(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+
+	now := time.Unix(1700000000, 0)
+	recent := now.Add(-24 * time.Hour)
+
+	h.mockResponse("GET", UsersListEndpoint, 200, ListResponse[User]{
+		Object: "list",
+		Data: []User{
+			{ID: "user_active", Email: "active@example.com", Role: "owner"},
+			{ID: "user_idle", Email: "idle@example.com", Role: "reader"},
+			{ID: "user_new", Email: "new@example.com", Role: "reader", AddedAt: UnixSeconds(now.Add(-7 * 24 * time.Hour))},
+		},
+	})
+	h.mockResponse("GET", AuditLogsListEndpoint, 200, ListResponse[AuditLog]{
+		Object: "list",
+		Data: []AuditLog{{
+			ID:          "log_1",
+			Type:        "login.succeeded",
+			EffectiveAt: UnixSeconds(recent),
+			Actor:       Actor{Type: "session", Session: &Session{User: AuditUser{ID: "user_active"}}},
+		}},
+	})
+	h.mockResponse("GET", ProjectsListEndpoint, 200, ListResponse[Project]{
+		Object: "list",
+		Data:   []Project{{ID: "proj_owned", Name: "Owned"}, {ID: "proj_orphan", Name: "Orphan"}},
+	})
+	mockProjectInventory(h, "proj_owned",
+		[]ProjectUser{{ID: "user_active", Email: "active@example.com", Role: "owner"}},
+		[]ProjectServiceAccount{{ID: "svc_1", Name: "bot", Role: "member"}},
+		[]ProjectApiKey{
+			{ID: "key_user", Name: "user-key", Owner: Owner{Type: OwnerTypeUser, User: &User{ID: "user_active", Email: "active@example.com"}}},
+			{ID: "key_sa", Name: "sa-key", Owner: Owner{Type: OwnerTypeServiceAccount, SA: &ProjectServiceAccount{ID: "svc_1", Name: "bot"}}},
+		})
+	mockProjectInventory(h, "proj_orphan",
+		[]ProjectUser{{ID: "user_idle", Email: "idle@example.com", Role: "member"}},
+		nil,
+		[]ProjectApiKey{
+			{ID: "key_gone", Name: "gone-key", Owner: Owner{Type: OwnerTypeUser, User: &User{ID: "user_departed", Email: "gone@example.com"}}},
+			{ID: "key_gone_sa", Name: "gone-sa-key", Owner: Owner{Type: OwnerTypeServiceAccount, SA: &ProjectServiceAccount{ID: "svc_deleted"}}},
+		})
+
+	review, err := BuildAccessReview(h.client, AccessReviewOptions{StaleAfter: 30 * 24 * time.Hour, Now: now})
+	if err != nil {
+		t.Fatalf("BuildAccessReview() error = %v", err)
+	}
+
+	if len(review.Entries) != 10 {
+		t.Errorf("expected 10 entries, got %d", len(review.Entries))
+	}
+
+	findings := map[string][]string{}
+	for _, f := range review.SortedFindings() {
+		findings[f.Kind] = append(findings[f.Kind], f.PrincipalID+f.ProjectID)
+	}
+	if got := findings[AccessFindingStaleLogin]; len(got) != 1 || got[0] != "user_idle" {
+		t.Errorf("unexpected stale findings: %v", got)
+	}
+	if got := findings[AccessFindingOrphanedKey]; len(got) != 2 || got[0] != "key_goneproj_orphan" || got[1] != "key_gone_saproj_orphan" {
+		t.Errorf("unexpected orphaned key findings: %v", got)
+	}
+	if got := findings[AccessFindingNoProjectOwner]; len(got) != 1 || got[0] != "proj_orphan" {
+		t.Errorf("unexpected no-owner findings: %v", got)
+	}
+
+	for _, entry := range review.Entries {
+		if entry.PrincipalID == "user_active" && (entry.LastLogin == nil || !entry.LastLogin.Equal(recent)) {
+			t.Errorf("expected last login for active user, got %+v", entry)
+		}
+		if entry.PrincipalID == "user_idle" && (len(entry.Flags) != 1 || entry.Flags[0] != AccessFindingStaleLogin) {
+			t.Errorf("expected stale flag on idle user entry, got %+v", entry)
+		}
+		if entry.PrincipalID == "user_new" && len(entry.Flags) != 0 {
+			t.Errorf("expected no flags on recently added user, got %+v", entry)
+		}
+	}
+}
+
+func TestBuildAccessReview_AuditLogError(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+
+	h.mockResponse("GET", UsersListEndpoint, 200, ListResponse[User]{Object: "list"})
+	h.mockResponse("GET", AuditLogsListEndpoint, 403, map[string]string{"error": "audit logging disabled"})
+
+	_, err := BuildAccessReview(h.client, AccessReviewOptions{})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
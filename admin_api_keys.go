@@ -19,6 +19,8 @@ type AdminAPIKey struct {
 	Name string `json:"name"`
 	// RedactedValue is a partially hidden version of the API key for display purposes.
 	RedactedValue string `json:"redacted_value"`
+	// Value is the full API key. It is only returned by CreateAdminAPIKey.
+	Value string `json:"value,omitempty"`
 	// CreatedAt is the timestamp when this API key was created.
 	CreatedAt UnixSeconds `json:"created_at"`
 	// LastUsedAt is the timestamp when this API key was last used.
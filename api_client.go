@@ -12,8 +12,13 @@ import (
 // DefaultBaseURL is the default endpoint for the OpenAI Organizations API.
 const DefaultBaseURL = "https://api.openai.com/v1"
 
-// Compile-time check that *Client satisfies the OpenAIOrgsClient interface.
-var _ OpenAIOrgsClient = (*Client)(nil)
+// Compile-time checks that *Client satisfies the OpenAIOrgsClient interface and the
+// optional interfaces that extend it.
+var (
+	_ OpenAIOrgsClient           = (*Client)(nil)
+	_ ProjectInviteCreator       = (*Client)(nil)
+	_ CertificateOptionsUploader = (*Client)(nil)
+)
 
 // Client represents an OpenAI Organizations API client.
 // It handles authentication, request retries, and provides methods for interacting with the API.
@@ -0,0 +1,294 @@
+package openaiorgs
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Defaults for AuditAPIKeys when the corresponding APIKeyAuditOptions field is not set.
+const (
+	DefaultAPIKeyUnusedAge = 30 * 24 * time.Hour
+	DefaultAPIKeyMaxAge    = 365 * 24 * time.Hour
+)
+
+// API key audit finding kinds, from most to least urgent.
+const (
+	// APIKeyFindingOwnerLeft flags a key owned by a user who is no longer in the organization.
+	APIKeyFindingOwnerLeft = "owner_left"
+	// APIKeyFindingServiceAccountDeleted flags a key owned by a service account that no longer exists.
+	APIKeyFindingServiceAccountDeleted = "service_account_deleted"
+	// APIKeyFindingUnused flags a key with no completions usage in the unused window
+	// that was created before the window began.
+	APIKeyFindingUnused = "unused"
+	// APIKeyFindingTooOld flags a key created longer ago than the maximum age.
+	APIKeyFindingTooOld = "too_old"
+)
+
+// apiKeyFindingPriority ranks finding kinds; lower is more urgent.
+var apiKeyFindingPriority = map[string]int{
+	APIKeyFindingOwnerLeft:             1,
+	APIKeyFindingServiceAccountDeleted: 1,
+	APIKeyFindingUnused:                2,
+	APIKeyFindingTooOld:                3,
+}
+
+// APIKeyAuditOptions configures AuditAPIKeys.
+type APIKeyAuditOptions struct {
+	// UnusedAfter flags keys with no usage in this window. Defaults to DefaultAPIKeyUnusedAge.
+	UnusedAfter time.Duration
+	// MaxAge flags keys created longer ago than this. Defaults to DefaultAPIKeyMaxAge.
+	MaxAge time.Duration
+	// IncludeArchived also audits keys in archived projects.
+	IncludeArchived bool
+	// Concurrency is the number of projects fetched in parallel.
+	Concurrency int
+	// Now overrides the audit time, mainly for tests. Defaults to time.Now.
+	Now time.Time
+}
+
+// APIKeyAuditEntry describes one project API key and any problems found with it.
+type APIKeyAuditEntry struct {
+	ProjectID     string     `json:"project_id"`
+	ProjectName   string     `json:"project_name"`
+	KeyID         string     `json:"key_id"`
+	KeyName       string     `json:"key_name"`
+	RedactedValue string     `json:"redacted_value"`
+	OwnerType     string     `json:"owner_type,omitempty"`
+	OwnerID       string     `json:"owner_id,omitempty"`
+	OwnerName     string     `json:"owner_name,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	LastUsed      *time.Time `json:"last_used,omitempty"`
+	Requests      int        `json:"requests"`
+	Findings      []string   `json:"findings,omitempty"`
+	// Priority is the urgency of the most urgent finding, 1 being the highest.
+	// It is 0 for keys without findings.
+	Priority int `json:"priority,omitempty"`
+}
+
+// APIKeyAudit is the result of AuditAPIKeys.
+type APIKeyAudit struct {
+	GeneratedAt time.Time          `json:"generated_at"`
+	UnusedAfter time.Duration      `json:"unused_after"`
+	MaxAge      time.Duration      `json:"max_age"`
+	Keys        []APIKeyAuditEntry `json:"keys"`
+}
+
+// AuditAPIKeys lists every API key in every project together with its owner and
+// flags keys whose owner has left the organization, whose service account was
+// deleted, that have not been used within opts.UnusedAfter, or that are older than
+// opts.MaxAge.
+//
+// Usage is taken from the completions usage endpoint grouped by API key, so keys
+// used only for other endpoints (embeddings, images, ...) are reported as unused.
+func AuditAPIKeys(c OpenAIOrgsClient, opts APIKeyAuditOptions) (*APIKeyAudit, error) {
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	unusedAfter := opts.UnusedAfter
+	if unusedAfter <= 0 {
+		unusedAfter = DefaultAPIKeyUnusedAge
+	}
+	maxAge := opts.MaxAge
+	if maxAge <= 0 {
+		maxAge = DefaultAPIKeyMaxAge
+	}
+
+	users, err := ListAll(func(after string) (*ListResponse[User], error) {
+		return c.ListUsers(100, after)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	orgUsers := make(map[string]bool, len(users))
+	for _, user := range users {
+		orgUsers[user.ID] = true
+	}
+
+	usage, err := collectAPIKeyUsage(c, now.Add(-unusedAfter))
+	if err != nil {
+		return nil, err
+	}
+
+	inventory, err := CollectProjectInventory(c, InventoryOptions{
+		IncludeArchived: opts.IncludeArchived,
+		Concurrency:     opts.Concurrency,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	audit := &APIKeyAudit{
+		GeneratedAt: now,
+		UnusedAfter: unusedAfter,
+		MaxAge:      maxAge,
+	}
+	for _, project := range inventory {
+		serviceAccounts := make(map[string]bool, len(project.ServiceAccounts))
+		for _, sa := range project.ServiceAccounts {
+			serviceAccounts[sa.ID] = true
+		}
+
+		for _, key := range project.APIKeys {
+			entry := APIKeyAuditEntry{
+				ProjectID:     project.Project.ID,
+				ProjectName:   project.Project.Name,
+				KeyID:         key.ID,
+				KeyName:       key.Name,
+				RedactedValue: key.RedactedValue,
+				OwnerType:     string(key.Owner.Type),
+				OwnerID:       key.Owner.ID,
+				OwnerName:     key.Owner.Name,
+				CreatedAt:     key.CreatedAt.Time(),
+			}
+
+			switch {
+			case key.Owner.User != nil:
+				entry.OwnerType = string(OwnerTypeUser)
+				entry.OwnerID = key.Owner.User.ID
+				entry.OwnerName = key.Owner.User.Email
+				if !orgUsers[key.Owner.User.ID] {
+					entry.addFinding(APIKeyFindingOwnerLeft)
+				}
+			case key.Owner.SA != nil:
+				entry.OwnerType = string(OwnerTypeServiceAccount)
+				entry.OwnerID = key.Owner.SA.ID
+				entry.OwnerName = key.Owner.SA.Name
+				if !serviceAccounts[key.Owner.SA.ID] {
+					entry.addFinding(APIKeyFindingServiceAccountDeleted)
+				}
+			}
+
+			if used, ok := usage[key.ID]; ok {
+				lastUsed := used.lastUsed
+				entry.LastUsed = &lastUsed
+				entry.Requests = used.requests
+			} else if now.Sub(entry.CreatedAt) >= unusedAfter {
+				// Keys younger than the window haven't had a chance to be used yet.
+				entry.addFinding(APIKeyFindingUnused)
+			}
+			if !entry.CreatedAt.IsZero() && now.Sub(entry.CreatedAt) > maxAge {
+				entry.addFinding(APIKeyFindingTooOld)
+			}
+			audit.Keys = append(audit.Keys, entry)
+		}
+	}
+	return audit, nil
+}
+
+func (e *APIKeyAuditEntry) addFinding(kind string) {
+	e.Findings = append(e.Findings, kind)
+	if p := apiKeyFindingPriority[kind]; e.Priority == 0 || p < e.Priority {
+		e.Priority = p
+	}
+}
+
+// Remediations returns the keys that have findings, most urgent first. Keys with the
+// same priority are ordered oldest first.
+func (a *APIKeyAudit) Remediations() []APIKeyAuditEntry {
+	var flagged []APIKeyAuditEntry
+	for _, key := range a.Keys {
+		if key.Priority > 0 {
+			flagged = append(flagged, key)
+		}
+	}
+	sort.SliceStable(flagged, func(i, j int) bool {
+		if flagged[i].Priority != flagged[j].Priority {
+			return flagged[i].Priority < flagged[j].Priority
+		}
+		return flagged[i].CreatedAt.Before(flagged[j].CreatedAt)
+	})
+	return flagged
+}
+
+// DefaultAPIKeyRevokeFindings are the finding kinds revoked when no other kinds
+// are chosen. Keys that are only too old may still be in use, so they are left
+// for rotation rather than deleted.
+var DefaultAPIKeyRevokeFindings = []string{
+	APIKeyFindingOwnerLeft,
+	APIKeyFindingServiceAccountDeleted,
+	APIKeyFindingUnused,
+}
+
+// FilterAPIKeyFindings returns the entries with at least one finding of the given
+// kinds, preserving their order.
+func FilterAPIKeyFindings(entries []APIKeyAuditEntry, kinds []string) []APIKeyAuditEntry {
+	var matched []APIKeyAuditEntry
+	for _, entry := range entries {
+		for _, finding := range entry.Findings {
+			if slices.Contains(kinds, finding) {
+				matched = append(matched, entry)
+				break
+			}
+		}
+	}
+	return matched
+}
+
+type apiKeyUsage struct {
+	lastUsed time.Time
+	requests int
+}
+
+// collectAPIKeyUsage returns completions usage per API key ID since the given time,
+// using daily buckets.
+func collectAPIKeyUsage(c OpenAIOrgsClient, since time.Time) (map[string]apiKeyUsage, error) {
+	params := map[string]string{
+		"start_time":   strconv.FormatInt(since.Unix(), 10),
+		"bucket_width": "1d",
+		"group_by":     "api_key_id",
+		"limit":        "31",
+	}
+
+	usage := make(map[string]apiKeyUsage)
+	for {
+		resp, err := c.GetCompletionsUsage(params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get completions usage: %w", err)
+		}
+		for _, bucket := range resp.Data {
+			start := time.Unix(bucket.StartTime, 0)
+			for _, result := range bucket.Results {
+				if result.APIKeyID == "" || result.NumModelRequests == 0 {
+					continue
+				}
+				u := usage[result.APIKeyID]
+				u.requests += result.NumModelRequests
+				if start.After(u.lastUsed) {
+					u.lastUsed = start
+				}
+				usage[result.APIKeyID] = u
+			}
+		}
+		if !resp.HasMore || resp.NextPage == "" || resp.NextPage == params["page"] {
+			return usage, nil
+		}
+		params["page"] = resp.NextPage
+	}
+}
+
+// APIKeyRevocation is the outcome of revoking one key with RevokeAPIKeys.
+type APIKeyRevocation struct {
+	ProjectID string `json:"project_id"`
+	KeyID     string `json:"key_id"`
+	KeyName   string `json:"key_name"`
+	Revoked   bool   `json:"revoked"`
+	Error     string `json:"error,omitempty"`
+}
+
+// RevokeAPIKeys deletes each key, continuing past failures, which are recorded in the results.
+func RevokeAPIKeys(c OpenAIOrgsClient, keys []APIKeyAuditEntry) []APIKeyRevocation {
+	results := make([]APIKeyRevocation, len(keys))
+	for i, key := range keys {
+		results[i] = APIKeyRevocation{ProjectID: key.ProjectID, KeyID: key.KeyID, KeyName: key.KeyName}
+		if err := c.DeleteProjectApiKey(key.ProjectID, key.KeyID); err != nil {
+			results[i].Error = err.Error()
+		} else {
+			results[i].Revoked = true
+		}
+	}
+	return results
+}
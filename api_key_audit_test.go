@@ -0,0 +1,192 @@
+package openaiorgs
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+var apiKeyAuditNow = time.Unix(1700000000, 0)
+
+func mockAPIKeyAuditOrg(h *testHelper) {
+	day := 24 * time.Hour
+	at := func(d time.Duration) UnixSeconds { return UnixSeconds(apiKeyAuditNow.Add(-d)) }
+
+	h.mockResponse("GET", "/organization/users", 200, ListResponse[User]{
+		Object: "list",
+		Data:   []User{{ID: "user_1", Email: "alice@example.com"}},
+	})
+	h.mockResponse("GET", ProjectsListEndpoint, 200, ListResponse[Project]{
+		Object: "list",
+		Data:   []Project{{ID: "proj_1", Name: "One"}},
+	})
+	mockProjectInventory(h, "proj_1",
+		[]ProjectUser{{ID: "user_1", Role: "owner"}},
+		[]ProjectServiceAccount{{ID: "svc_1", Name: "bot"}},
+		[]ProjectApiKey{
+			{ID: "key_healthy", Name: "healthy", CreatedAt: at(10 * day), Owner: Owner{Type: OwnerTypeUser, User: &User{ID: "user_1", Email: "alice@example.com"}}},
+			{ID: "key_left", Name: "left", CreatedAt: at(20 * day), Owner: Owner{Type: OwnerTypeUser, User: &User{ID: "user_gone", Email: "bob@example.com"}}},
+			{ID: "key_svc_gone", Name: "svc gone", CreatedAt: at(50 * day), Owner: Owner{Type: OwnerTypeServiceAccount, SA: &ProjectServiceAccount{ID: "svc_gone", Name: "old bot"}}},
+			{ID: "key_idle", Name: "idle", CreatedAt: at(40 * day), Owner: Owner{Type: OwnerTypeServiceAccount, SA: &ProjectServiceAccount{ID: "svc_1", Name: "bot"}}},
+			{ID: "key_new", Name: "new", CreatedAt: at(5 * day), Owner: Owner{Type: OwnerTypeServiceAccount, SA: &ProjectServiceAccount{ID: "svc_1", Name: "bot"}}},
+			{ID: "key_ancient", Name: "ancient", CreatedAt: at(400 * day), Owner: Owner{Type: OwnerTypeUser, User: &User{ID: "user_1", Email: "alice@example.com"}}},
+		})
+
+	// The second page of usage is only returned when requested with page=p2.
+	httpmock.RegisterResponder("GET", testBaseURL+usageCompletionsEndpoint, func(req *http.Request) (*http.Response, error) {
+		if req.URL.Query().Get("group_by") != "api_key_id" {
+			return httpmock.NewStringResponse(400, "usage must be grouped by api_key_id"), nil
+		}
+		if req.URL.Query().Get("page") == "p2" {
+			return httpmock.NewJsonResponse(200, CompletionsUsageResponse{
+				Object: "page",
+				Data: []CompletionsUsageBucket{{
+					StartTime: apiKeyAuditNow.Add(-2 * day).Unix(),
+					Results: []CompletionsUsageResult{
+						{APIKeyID: "key_healthy", NumModelRequests: 3},
+						{APIKeyID: "key_ancient", NumModelRequests: 1},
+					},
+				}},
+			})
+		}
+		return httpmock.NewJsonResponse(200, CompletionsUsageResponse{
+			Object: "page",
+			Data: []CompletionsUsageBucket{{
+				StartTime: apiKeyAuditNow.Add(-5 * day).Unix(),
+				Results: []CompletionsUsageResult{
+					{APIKeyID: "key_healthy", NumModelRequests: 2},
+					{APIKeyID: "key_left", NumModelRequests: 7},
+					{APIKeyID: "key_idle", NumModelRequests: 0},
+				},
+			}},
+			HasMore:  true,
+			NextPage: "p2",
+		})
+	})
+}
+
+func TestAuditAPIKeys(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+	mockAPIKeyAuditOrg(h)
+
+	audit, err := AuditAPIKeys(h.client, APIKeyAuditOptions{Now: apiKeyAuditNow})
+	if err != nil {
+		t.Fatalf("AuditAPIKeys() error = %v", err)
+	}
+	if audit.UnusedAfter != DefaultAPIKeyUnusedAge || audit.MaxAge != DefaultAPIKeyMaxAge {
+		t.Errorf("expected default thresholds, got %v and %v", audit.UnusedAfter, audit.MaxAge)
+	}
+	if len(audit.Keys) != 6 {
+		t.Fatalf("expected 6 keys, got %d", len(audit.Keys))
+	}
+
+	byID := make(map[string]APIKeyAuditEntry)
+	for _, key := range audit.Keys {
+		byID[key.KeyID] = key
+	}
+
+	healthy := byID["key_healthy"]
+	if len(healthy.Findings) != 0 || healthy.Requests != 5 || healthy.LastUsed == nil || !healthy.LastUsed.Equal(apiKeyAuditNow.Add(-48*time.Hour)) {
+		t.Errorf("unexpected healthy key: %+v", healthy)
+	}
+	if healthy.OwnerType != "user" || healthy.OwnerName != "alice@example.com" {
+		t.Errorf("unexpected owner for healthy key: %+v", healthy)
+	}
+	// key_new has no usage, but it was created inside the unused window.
+	if fresh := byID["key_new"]; len(fresh.Findings) != 0 || fresh.Priority != 0 {
+		t.Errorf("expected no findings for a key younger than the unused window, got %+v", fresh)
+	}
+
+	wantFindings := map[string][]string{
+		"key_left":     {APIKeyFindingOwnerLeft},
+		"key_svc_gone": {APIKeyFindingServiceAccountDeleted, APIKeyFindingUnused},
+		"key_idle":     {APIKeyFindingUnused},
+		"key_ancient":  {APIKeyFindingTooOld},
+	}
+	for id, want := range wantFindings {
+		if got := byID[id].Findings; !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: findings = %v, want %v", id, got, want)
+		}
+	}
+
+	var order []string
+	for _, key := range audit.Remediations() {
+		order = append(order, key.KeyID)
+	}
+	wantOrder := []string{"key_svc_gone", "key_left", "key_idle", "key_ancient"}
+	if !reflect.DeepEqual(order, wantOrder) {
+		t.Errorf("Remediations() order = %v, want %v", order, wantOrder)
+	}
+}
+
+func TestFilterAPIKeyFindings(t *testing.T) {
+	entries := []APIKeyAuditEntry{
+		{KeyID: "key_left", Findings: []string{APIKeyFindingOwnerLeft, APIKeyFindingTooOld}},
+		{KeyID: "key_old", Findings: []string{APIKeyFindingTooOld}},
+		{KeyID: "key_idle", Findings: []string{APIKeyFindingUnused}},
+	}
+
+	var got []string
+	for _, entry := range FilterAPIKeyFindings(entries, DefaultAPIKeyRevokeFindings) {
+		got = append(got, entry.KeyID)
+	}
+	if want := []string{"key_left", "key_idle"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FilterAPIKeyFindings() = %v, want %v", got, want)
+	}
+}
+
+func TestAuditAPIKeys_Thresholds(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+	mockAPIKeyAuditOrg(h)
+
+	audit, err := AuditAPIKeys(h.client, APIKeyAuditOptions{
+		Now:    apiKeyAuditNow,
+		MaxAge: 15 * 24 * time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("AuditAPIKeys() error = %v", err)
+	}
+	for _, key := range audit.Keys {
+		if key.KeyID == "key_left" && !reflect.DeepEqual(key.Findings, []string{APIKeyFindingOwnerLeft, APIKeyFindingTooOld}) {
+			t.Errorf("expected key_left to also be too old, got %v", key.Findings)
+		}
+		if key.KeyID == "key_left" && key.Priority != 1 {
+			t.Errorf("expected priority 1 for key_left, got %d", key.Priority)
+		}
+	}
+}
+
+func TestAuditAPIKeys_UsageError(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+
+	h.mockResponse("GET", "/organization/users", 200, ListResponse[User]{Object: "list"})
+	h.mockResponse("GET", usageCompletionsEndpoint, 500, map[string]string{"error": "boom"})
+
+	if _, err := AuditAPIKeys(h.client, APIKeyAuditOptions{}); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestRevokeAPIKeys(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+
+	h.mockResponse("DELETE", "/organization/projects/proj_1/api_keys/key_1", 200, map[string]any{"deleted": true})
+	h.mockResponse("DELETE", "/organization/projects/proj_1/api_keys/key_2", 500, map[string]string{"error": "boom"})
+
+	results := RevokeAPIKeys(h.client, []APIKeyAuditEntry{
+		{ProjectID: "proj_1", KeyID: "key_1"},
+		{ProjectID: "proj_1", KeyID: "key_2"},
+	})
+	if len(results) != 2 || !results[0].Revoked || results[1].Revoked || results[1].Error == "" {
+		t.Errorf("unexpected results: %+v", results)
+	}
+	h.assertRequest("DELETE", "/organization/projects/proj_1/api_keys/key_1", 1)
+	h.assertRequest("DELETE", "/organization/projects/proj_1/api_keys/key_2", 1)
+}
@@ -0,0 +1,315 @@
+package openaiorgs
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// AuditExportVersion is the version of the signed export format written by AuditExportWriter.
+const AuditExportVersion = 1
+
+// auditExportGenesisHash is the previous hash of the first record in an export.
+var auditExportGenesisHash = hex.EncodeToString(make([]byte, sha256.Size))
+
+// AuditExportRecord is one line of a signed audit log export. Hash covers the previous
+// record's hash and the record bytes, so changing, removing or reordering any record
+// breaks every hash after it.
+type AuditExportRecord struct {
+	Seq      int             `json:"seq"`
+	PrevHash string          `json:"prev_hash"`
+	Hash     string          `json:"hash"`
+	Record   json.RawMessage `json:"record"`
+}
+
+// AuditExportManifest summarizes a signed export. It is written as the last line
+// of the export together with an ed25519 signature over its JSON encoding.
+type AuditExportManifest struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	Count     int       `json:"count"`
+	FirstID   string    `json:"first_id,omitempty"`
+	LastID    string    `json:"last_id,omitempty"`
+	FinalHash string    `json:"final_hash"`
+	PublicKey []byte    `json:"public_key"`
+}
+
+type auditExportTrailer struct {
+	Manifest  json.RawMessage `json:"manifest"`
+	Signature []byte          `json:"signature"`
+}
+
+// AuditExportWriter writes audit logs as a hash-chained JSON Lines export.
+// Records are serialized with AuditLog.MarshalJSON, which produces the same bytes
+// for the same event. Close must be called to append the signed manifest.
+type AuditExportWriter struct {
+	w        io.Writer
+	key      ed25519.PrivateKey
+	prevHash string
+	count    int
+	firstID  string
+	lastID   string
+	closed   bool
+}
+
+// NewAuditExportWriter returns a writer that signs the export with key.
+func NewAuditExportWriter(w io.Writer, key ed25519.PrivateKey) *AuditExportWriter {
+	return &AuditExportWriter{
+		w:        w,
+		key:      key,
+		prevHash: auditExportGenesisHash,
+	}
+}
+
+// Write appends log to the export, chaining its hash to the previous record.
+func (e *AuditExportWriter) Write(log *AuditLog) error {
+	if e.closed {
+		return errors.New("audit export already closed")
+	}
+	raw, err := json.Marshal(log)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit log %s: %w", log.ID, err)
+	}
+
+	e.count++
+	record := AuditExportRecord{
+		Seq:      e.count,
+		PrevHash: e.prevHash,
+		Hash:     chainHash(e.prevHash, raw),
+		Record:   raw,
+	}
+	if err := writeJSONLine(e.w, record); err != nil {
+		return err
+	}
+
+	e.prevHash = record.Hash
+	if e.firstID == "" {
+		e.firstID = log.ID
+	}
+	e.lastID = log.ID
+	return nil
+}
+
+// Close writes the signed manifest and returns it. No records can be written afterwards.
+func (e *AuditExportWriter) Close() (*AuditExportManifest, error) {
+	if e.closed {
+		return nil, errors.New("audit export already closed")
+	}
+	e.closed = true
+
+	manifest := &AuditExportManifest{
+		Version:   AuditExportVersion,
+		CreatedAt: time.Now().UTC(),
+		Count:     e.count,
+		FirstID:   e.firstID,
+		LastID:    e.lastID,
+		FinalHash: e.prevHash,
+		PublicKey: e.key.Public().(ed25519.PublicKey),
+	}
+	raw, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	trailer := auditExportTrailer{
+		Manifest:  raw,
+		Signature: ed25519.Sign(e.key, raw),
+	}
+	if err := writeJSONLine(e.w, trailer); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// ExportAuditLogs writes logs to w as a signed export and returns the manifest.
+func ExportAuditLogs(w io.Writer, key ed25519.PrivateKey, logs []AuditLog) (*AuditExportManifest, error) {
+	writer := NewAuditExportWriter(w, key)
+	for i := range logs {
+		if err := writer.Write(&logs[i]); err != nil {
+			return nil, err
+		}
+	}
+	return writer.Close()
+}
+
+// VerifyAuditExport checks an export written by AuditExportWriter. It recomputes the
+// hash chain over every record, checks that the manifest matches the records, and
+// verifies the manifest signature against pub. The public key embedded in the
+// manifest is informational only; trust comes from the key supplied by the caller.
+func VerifyAuditExport(r io.Reader, pub ed25519.PublicKey) (*AuditExportManifest, error) {
+	if len(pub) != ed25519.PublicKeySize {
+		return nil, errors.New("invalid ed25519 public key")
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	prevHash := auditExportGenesisHash
+	count := 0
+	firstID, lastID := "", ""
+	var trailer *auditExportTrailer
+	line := 0
+
+	for scanner.Scan() {
+		line++
+		raw := bytes.TrimSpace(scanner.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+		if trailer != nil {
+			return nil, fmt.Errorf("line %d: unexpected data after manifest", line)
+		}
+
+		var probe map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &probe); err != nil {
+			return nil, fmt.Errorf("line %d: invalid JSON: %w", line, err)
+		}
+		if _, ok := probe["manifest"]; ok {
+			trailer = &auditExportTrailer{}
+			if err := json.Unmarshal(raw, trailer); err != nil {
+				return nil, fmt.Errorf("line %d: invalid manifest: %w", line, err)
+			}
+			continue
+		}
+
+		var record AuditExportRecord
+		if err := json.Unmarshal(raw, &record); err != nil {
+			return nil, fmt.Errorf("line %d: invalid record: %w", line, err)
+		}
+		count++
+		if record.Seq != count {
+			return nil, fmt.Errorf("record %d: unexpected sequence number %d", count, record.Seq)
+		}
+		if record.PrevHash != prevHash {
+			return nil, fmt.Errorf("record %d: previous hash does not match the chain", count)
+		}
+		if want := chainHash(prevHash, record.Record); record.Hash != want {
+			return nil, fmt.Errorf("record %d: hash mismatch, record has been modified", count)
+		}
+		prevHash = record.Hash
+
+		var id struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(record.Record, &id); err != nil {
+			return nil, fmt.Errorf("record %d: invalid audit log: %w", count, err)
+		}
+		if firstID == "" {
+			firstID = id.ID
+		}
+		lastID = id.ID
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read export: %w", err)
+	}
+	if trailer == nil {
+		return nil, errors.New("export has no manifest, it may be truncated")
+	}
+
+	if !ed25519.Verify(pub, trailer.Manifest, trailer.Signature) {
+		return nil, errors.New("manifest signature is invalid")
+	}
+	var manifest AuditExportManifest
+	if err := json.Unmarshal(trailer.Manifest, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+	if manifest.Version != AuditExportVersion {
+		return nil, fmt.Errorf("unsupported export version %d", manifest.Version)
+	}
+	if manifest.Count != count {
+		return nil, fmt.Errorf("manifest lists %d records but export contains %d", manifest.Count, count)
+	}
+	if manifest.FinalHash != prevHash {
+		return nil, errors.New("manifest final hash does not match the record chain")
+	}
+	if manifest.FirstID != firstID || manifest.LastID != lastID {
+		return nil, errors.New("manifest record IDs do not match the export")
+	}
+	return &manifest, nil
+}
+
+// chainHash returns the hex SHA-256 of the previous hash followed by the compacted record.
+func chainHash(prevHash string, record []byte) string {
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, record); err != nil {
+		compact.Reset()
+		compact.Write(record)
+	}
+	h := sha256.New()
+	h.Write([]byte(prevHash))
+	h.Write([]byte{'\n'})
+	h.Write(compact.Bytes())
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func writeJSONLine(w io.Writer, v any) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode export line: %w", err)
+	}
+	raw = append(raw, '\n')
+	if _, err := w.Write(raw); err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+	return nil
+}
+
+// MarshalEd25519PrivateKeyPEM encodes key as a PKCS #8 "PRIVATE KEY" PEM block.
+func MarshalEd25519PrivateKeyPEM(key ed25519.PrivateKey) ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
+
+// MarshalEd25519PublicKeyPEM encodes key as a PKIX "PUBLIC KEY" PEM block.
+func MarshalEd25519PublicKeyPEM(key ed25519.PublicKey) ([]byte, error) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), nil
+}
+
+// ParseEd25519PrivateKeyPEM decodes a PKCS #8 PEM-encoded ed25519 private key.
+func ParseEd25519PrivateKeyPEM(data []byte) (ed25519.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	edKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("expected an ed25519 private key, got %T", key)
+	}
+	return edKey, nil
+}
+
+// ParseEd25519PublicKeyPEM decodes a PKIX PEM-encoded ed25519 public key.
+func ParseEd25519PublicKeyPEM(data []byte) (ed25519.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %w", err)
+	}
+	edKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("expected an ed25519 public key, got %T", key)
+	}
+	return edKey, nil
+}
@@ -0,0 +1,168 @@
+package openaiorgs
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"strings"
+	"testing"
+)
+
+func newExportKey(t *testing.T) (ed25519.PublicKey, ed25519.PrivateKey) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	return pub, priv
+}
+
+func TestExportAndVerifyAuditLogs(t *testing.T) {
+	pub, priv := newExportKey(t)
+	logs := parseAuditLogs(t, timelineFixture)
+
+	var buf bytes.Buffer
+	manifest, err := ExportAuditLogs(&buf, priv, logs)
+	if err != nil {
+		t.Fatalf("ExportAuditLogs() error = %v", err)
+	}
+	if manifest.Count != len(logs) || manifest.FirstID != "log_4" || manifest.LastID != "log_6" {
+		t.Errorf("unexpected manifest: %+v", manifest)
+	}
+	if lines := strings.Count(buf.String(), "\n"); lines != len(logs)+1 {
+		t.Errorf("expected %d lines, got %d", len(logs)+1, lines)
+	}
+
+	verified, err := VerifyAuditExport(bytes.NewReader(buf.Bytes()), pub)
+	if err != nil {
+		t.Fatalf("VerifyAuditExport() error = %v", err)
+	}
+	if verified.FinalHash != manifest.FinalHash || verified.Count != manifest.Count {
+		t.Errorf("verified manifest %+v does not match %+v", verified, manifest)
+	}
+}
+
+func TestVerifyAuditExport_Empty(t *testing.T) {
+	pub, priv := newExportKey(t)
+
+	var buf bytes.Buffer
+	if _, err := ExportAuditLogs(&buf, priv, nil); err != nil {
+		t.Fatalf("ExportAuditLogs() error = %v", err)
+	}
+	manifest, err := VerifyAuditExport(&buf, pub)
+	if err != nil {
+		t.Fatalf("VerifyAuditExport() error = %v", err)
+	}
+	if manifest.Count != 0 {
+		t.Errorf("expected empty export, got %d records", manifest.Count)
+	}
+}
+
+func TestVerifyAuditExport_Tampering(t *testing.T) {
+	pub, priv := newExportKey(t)
+	otherPub, _ := newExportKey(t)
+	logs := parseAuditLogs(t, timelineFixture)
+
+	var buf bytes.Buffer
+	if _, err := ExportAuditLogs(&buf, priv, logs); err != nil {
+		t.Fatalf("ExportAuditLogs() error = %v", err)
+	}
+	export := buf.String()
+	lines := strings.SplitAfter(export, "\n")
+
+	tests := []struct {
+		name    string
+		content string
+		key     ed25519.PublicKey
+		wantErr string
+	}{
+		{
+			name:    "modified record",
+			content: strings.Replace(export, `"title":"Beta"`, `"title":"Gamma"`, 1),
+			key:     pub,
+			wantErr: "hash mismatch",
+		},
+		{
+			name:    "removed record",
+			content: lines[0] + strings.Join(lines[2:], ""),
+			key:     pub,
+			wantErr: "sequence number",
+		},
+		{
+			name:    "swapped records",
+			content: lines[1] + lines[0] + strings.Join(lines[2:], ""),
+			key:     pub,
+			wantErr: "sequence number",
+		},
+		{
+			name:    "truncated",
+			content: strings.Join(lines[:len(lines)-2], ""),
+			key:     pub,
+			wantErr: "no manifest",
+		},
+		{
+			name:    "wrong key",
+			content: export,
+			key:     otherPub,
+			wantErr: "signature is invalid",
+		},
+		{
+			name:    "invalid key",
+			content: export,
+			key:     nil,
+			wantErr: "invalid ed25519 public key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := VerifyAuditExport(strings.NewReader(tt.content), tt.key)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestAuditExportWriter_Closed(t *testing.T) {
+	_, priv := newExportKey(t)
+	writer := NewAuditExportWriter(&bytes.Buffer{}, priv)
+	if _, err := writer.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if err := writer.Write(&AuditLog{ID: "log_1"}); err == nil {
+		t.Error("expected error writing to closed export")
+	}
+	if _, err := writer.Close(); err == nil {
+		t.Error("expected error closing twice")
+	}
+}
+
+func TestEd25519PEMRoundTrip(t *testing.T) {
+	pub, priv := newExportKey(t)
+
+	privPEM, err := MarshalEd25519PrivateKeyPEM(priv)
+	if err != nil {
+		t.Fatalf("MarshalEd25519PrivateKeyPEM() error = %v", err)
+	}
+	pubPEM, err := MarshalEd25519PublicKeyPEM(pub)
+	if err != nil {
+		t.Fatalf("MarshalEd25519PublicKeyPEM() error = %v", err)
+	}
+
+	parsedPriv, err := ParseEd25519PrivateKeyPEM(privPEM)
+	if err != nil || !parsedPriv.Equal(priv) {
+		t.Errorf("private key round trip failed: %v", err)
+	}
+	parsedPub, err := ParseEd25519PublicKeyPEM(pubPEM)
+	if err != nil || !parsedPub.Equal(pub) {
+		t.Errorf("public key round trip failed: %v", err)
+	}
+
+	if _, err := ParseEd25519PublicKeyPEM(privPEM); err == nil {
+		t.Error("expected error parsing private key as public key")
+	}
+	if _, err := ParseEd25519PrivateKeyPEM([]byte("not pem")); err == nil {
+		t.Error("expected error for non-PEM input")
+	}
+}
@@ -65,8 +65,15 @@ type IPAddressDetails struct {
 
 // APIKeyActor represents API key information in the actor field
 type APIKeyActor struct {
-	Type string    `json:"type"`
-	User AuditUser `json:"user"`
+	ID             string               `json:"id,omitempty"`
+	Type           string               `json:"type"`
+	User           AuditUser            `json:"user"`
+	ServiceAccount *AuditServiceAccount `json:"service_account,omitempty"`
+}
+
+// AuditServiceAccount represents service account information in audit logs
+type AuditServiceAccount struct {
+	ID string `json:"id"`
 }
 
 // AuditUser represents user information in audit logs
@@ -148,16 +155,18 @@ type ProjectArchived struct {
 	ID string `json:"id"`
 }
 
-// RateLimitUpdated represents the details for rate_limit.updated events
+// RateLimitUpdated represents the details for rate_limit.updated events.
+// Only the fields present in the event are set, so a limit changed to 0 can be
+// told apart from one that was not changed.
 type RateLimitUpdated struct {
 	ID               string `json:"id"`
 	ChangesRequested struct {
-		MaxRequestsPer1Minute       int `json:"max_requests_per_1_minute,omitempty"`
-		MaxTokensPer1Minute         int `json:"max_tokens_per_1_minute,omitempty"`
-		MaxImagesPer1Minute         int `json:"max_images_per_1_minute,omitempty"`
-		MaxAudioMegabytesPer1Minute int `json:"max_audio_megabytes_per_1_minute,omitempty"`
-		MaxRequestsPer1Day          int `json:"max_requests_per_1_day,omitempty"`
-		Batch1DayMaxInputTokens     int `json:"batch_1_day_max_input_tokens,omitempty"`
+		MaxRequestsPer1Minute       *int `json:"max_requests_per_1_minute,omitempty"`
+		MaxTokensPer1Minute         *int `json:"max_tokens_per_1_minute,omitempty"`
+		MaxImagesPer1Minute         *int `json:"max_images_per_1_minute,omitempty"`
+		MaxAudioMegabytesPer1Minute *int `json:"max_audio_megabytes_per_1_minute,omitempty"`
+		MaxRequestsPer1Day          *int `json:"max_requests_per_1_day,omitempty"`
+		Batch1DayMaxInputTokens     *int `json:"batch_1_day_max_input_tokens,omitempty"`
 	} `json:"changes_requested"`
 }
 
@@ -842,13 +842,13 @@ func TestAuditLogWithDynamicEventKey(t *testing.T) {
 			input:    `{"id": "log_123", "type": "rate_limit.updated", "effective_at": 1234567890, "actor": {"type": "session"}, "rate_limit.updated": {"id": "rl-123", "changes_requested": {"max_requests_per_1_minute": 100}}}`,
 			wantType: "rate_limit.updated",
 			wantDetails: &RateLimitUpdated{ID: "rl-123", ChangesRequested: struct {
-				MaxRequestsPer1Minute       int `json:"max_requests_per_1_minute,omitempty"`
-				MaxTokensPer1Minute         int `json:"max_tokens_per_1_minute,omitempty"`
-				MaxImagesPer1Minute         int `json:"max_images_per_1_minute,omitempty"`
-				MaxAudioMegabytesPer1Minute int `json:"max_audio_megabytes_per_1_minute,omitempty"`
-				MaxRequestsPer1Day          int `json:"max_requests_per_1_day,omitempty"`
-				Batch1DayMaxInputTokens     int `json:"batch_1_day_max_input_tokens,omitempty"`
-			}{MaxRequestsPer1Minute: 100}},
+				MaxRequestsPer1Minute       *int `json:"max_requests_per_1_minute,omitempty"`
+				MaxTokensPer1Minute         *int `json:"max_tokens_per_1_minute,omitempty"`
+				MaxImagesPer1Minute         *int `json:"max_images_per_1_minute,omitempty"`
+				MaxAudioMegabytesPer1Minute *int `json:"max_audio_megabytes_per_1_minute,omitempty"`
+				MaxRequestsPer1Day          *int `json:"max_requests_per_1_day,omitempty"`
+				Batch1DayMaxInputTokens     *int `json:"batch_1_day_max_input_tokens,omitempty"`
+			}{MaxRequestsPer1Minute: intPtr(100)}},
 		},
 		"rate_limit.deleted": {
 			input:       `{"id": "log_123", "type": "rate_limit.deleted", "effective_at": 1234567890, "actor": {"type": "session"}, "rate_limit.deleted": {"id": "rl-123"}}`,
@@ -1236,13 +1236,13 @@ func TestAuditLogMarshalJSON_SpecificEventTypes(t *testing.T) {
 				Details: &RateLimitUpdated{
 					ID: "rl_123",
 					ChangesRequested: struct {
-						MaxRequestsPer1Minute       int `json:"max_requests_per_1_minute,omitempty"`
-						MaxTokensPer1Minute         int `json:"max_tokens_per_1_minute,omitempty"`
-						MaxImagesPer1Minute         int `json:"max_images_per_1_minute,omitempty"`
-						MaxAudioMegabytesPer1Minute int `json:"max_audio_megabytes_per_1_minute,omitempty"`
-						MaxRequestsPer1Day          int `json:"max_requests_per_1_day,omitempty"`
-						Batch1DayMaxInputTokens     int `json:"batch_1_day_max_input_tokens,omitempty"`
-					}{MaxRequestsPer1Minute: 100},
+						MaxRequestsPer1Minute       *int `json:"max_requests_per_1_minute,omitempty"`
+						MaxTokensPer1Minute         *int `json:"max_tokens_per_1_minute,omitempty"`
+						MaxImagesPer1Minute         *int `json:"max_images_per_1_minute,omitempty"`
+						MaxAudioMegabytesPer1Minute *int `json:"max_audio_megabytes_per_1_minute,omitempty"`
+						MaxRequestsPer1Day          *int `json:"max_requests_per_1_day,omitempty"`
+						Batch1DayMaxInputTokens     *int `json:"batch_1_day_max_input_tokens,omitempty"`
+					}{MaxRequestsPer1Minute: intPtr(100)},
 				},
 			},
 			wantTypeKey: "rate_limit.updated",
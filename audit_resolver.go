@@ -0,0 +1,191 @@
+package openaiorgs
+
+import (
+	"strings"
+	"sync"
+)
+
+// ResolvedNames holds human-readable names for the IDs referenced by an audit log.
+// Fields are left empty when a name could not be looked up.
+type ResolvedNames struct {
+	// Actor is the email of the acting user, or the name of the acting API key.
+	Actor string `json:"actor,omitempty"`
+	// APIKey is the name of the API key that performed the action, if any.
+	APIKey string `json:"api_key,omitempty"`
+	// ServiceAccount is the name of the service account that owns the acting API key, if any.
+	ServiceAccount string `json:"service_account,omitempty"`
+	// Project is the name of the project the event occurred in.
+	Project string `json:"project,omitempty"`
+	// Resource is the name of the resource the event acted on.
+	Resource string `json:"resource,omitempty"`
+}
+
+// AuditLogResolver enriches audit logs with the names of the users, projects,
+// service accounts and API keys they reference. Lookups go through the regular
+// Retrieve* calls and are cached for the lifetime of the resolver, so resolving
+// many events that share the same IDs costs one request per ID.
+//
+// Failed lookups are cached too: a deleted resource is looked up once and then
+// reported without a name.
+type AuditLogResolver struct {
+	client OpenAIOrgsClient
+
+	mu    sync.Mutex
+	cache map[string]string
+}
+
+// NewAuditLogResolver creates a resolver that looks names up through client.
+func NewAuditLogResolver(client OpenAIOrgsClient) *AuditLogResolver {
+	return &AuditLogResolver{
+		client: client,
+		cache:  make(map[string]string),
+	}
+}
+
+// Resolve returns the names of the actor, project and target resource of log.
+func (r *AuditLogResolver) Resolve(log *AuditLog) ResolvedNames {
+	var names ResolvedNames
+	projectID := ""
+	if log.Project != nil {
+		projectID = log.Project.ID
+		names.Project = log.Project.Name
+		if names.Project == "" {
+			names.Project = r.projectName(projectID)
+		}
+	}
+
+	switch {
+	case log.Actor.Session != nil:
+		names.Actor = log.Actor.Session.User.Email
+		if names.Actor == "" {
+			names.Actor = r.userEmail(log.Actor.Session.User.ID)
+		}
+	case log.Actor.APIKey != nil:
+		key := log.Actor.APIKey
+		if key.ID != "" {
+			names.APIKey = r.apiKeyName(projectID, key.ID)
+		}
+		if key.ServiceAccount != nil && projectID != "" {
+			names.ServiceAccount = r.serviceAccountName(projectID, key.ServiceAccount.ID)
+		}
+		switch {
+		case key.User.Email != "":
+			names.Actor = key.User.Email
+		case key.User.ID != "":
+			names.Actor = r.userEmail(key.User.ID)
+		case names.ServiceAccount != "":
+			names.Actor = names.ServiceAccount
+		default:
+			names.Actor = names.APIKey
+		}
+	}
+
+	names.Resource = r.resourceName(log, projectID)
+	return names
+}
+
+func (r *AuditLogResolver) resourceName(log *AuditLog, projectID string) string {
+	id := log.ResourceID()
+	if id == "" {
+		return ""
+	}
+
+	kind, _, _ := strings.Cut(log.Type, ".")
+	switch kind {
+	case "project":
+		return r.projectName(id)
+	case "user":
+		return r.userEmail(id)
+	case "service_account":
+		if projectID == "" {
+			return ""
+		}
+		return r.serviceAccountName(projectID, id)
+	case "api_key":
+		return r.apiKeyName(projectID, id)
+	case "invite":
+		if sent, ok := log.Details.(*InviteSent); ok {
+			return sent.Data.Email
+		}
+		return r.lookup("invite:"+id, func() (string, error) {
+			invite, err := r.client.RetrieveInvite(id)
+			if err != nil {
+				return "", err
+			}
+			return invite.Email, nil
+		})
+	}
+	return ""
+}
+
+func (r *AuditLogResolver) projectName(id string) string {
+	if id == "" {
+		return ""
+	}
+	return r.lookup("project:"+id, func() (string, error) {
+		project, err := r.client.RetrieveProject(id)
+		if err != nil {
+			return "", err
+		}
+		return project.Name, nil
+	})
+}
+
+func (r *AuditLogResolver) userEmail(id string) string {
+	if id == "" {
+		return ""
+	}
+	return r.lookup("user:"+id, func() (string, error) {
+		user, err := r.client.RetrieveUser(id)
+		if err != nil {
+			return "", err
+		}
+		return user.Email, nil
+	})
+}
+
+func (r *AuditLogResolver) serviceAccountName(projectID, id string) string {
+	if id == "" {
+		return ""
+	}
+	return r.lookup("service_account:"+projectID+"/"+id, func() (string, error) {
+		sa, err := r.client.RetrieveProjectServiceAccount(projectID, id)
+		if err != nil {
+			return "", err
+		}
+		return sa.Name, nil
+	})
+}
+
+// apiKeyName looks the key up as an admin key first, then as a key of projectID.
+func (r *AuditLogResolver) apiKeyName(projectID, id string) string {
+	return r.lookup("api_key:"+projectID+"/"+id, func() (string, error) {
+		adminKey, err := r.client.RetrieveAdminAPIKey(id)
+		if err == nil {
+			return adminKey.Name, nil
+		}
+		if projectID == "" {
+			return "", err
+		}
+		projectKey, err := r.client.RetrieveProjectApiKey(projectID, id)
+		if err != nil {
+			return "", err
+		}
+		return projectKey.Name, nil
+	})
+}
+
+func (r *AuditLogResolver) lookup(key string, fetch func() (string, error)) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if name, ok := r.cache[key]; ok {
+		return name
+	}
+	name, err := fetch()
+	if err != nil {
+		name = ""
+	}
+	r.cache[key] = name
+	return name
+}
@@ -0,0 +1,72 @@
+package openaiorgs
+
+import (
+	"testing"
+)
+
+const resolverFixture = `[
+	{"id": "log_1", "type": "service_account.created", "effective_at": 1700000000,
+	 "project": {"id": "proj_abc"},
+	 "actor": {"type": "api_key", "api_key": {"id": "key_1", "type": "service_account", "service_account": {"id": "svc_actor"}}},
+	 "service_account.created": {"id": "svc_new", "data": {"role": "member"}}},
+	{"id": "log_2", "type": "user.updated", "effective_at": 1700000100,
+	 "actor": {"type": "session", "session": {"user": {"id": "user_1", "email": "admin@example.com"}}},
+	 "user.updated": {"id": "user_9", "changes_requested": {"role": "owner"}}},
+	{"id": "log_3", "type": "project.archived", "effective_at": 1700000200,
+	 "actor": {"type": "session", "session": {"user": {"id": "user_1", "email": "admin@example.com"}}},
+	 "project.archived": {"id": "proj_abc"}}
+]`
+
+func TestAuditLogResolver(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+
+	h.mockResponse("GET", ProjectsListEndpoint+"/proj_abc", 200, Project{ID: "proj_abc", Name: "Alpha"})
+	h.mockResponse("GET", AdminAPIKeysEndpoint+"/key_1", 404, map[string]string{"error": "not found"})
+	h.mockResponse("GET", "/organization/projects/proj_abc/api_keys/key_1", 200, ProjectApiKey{ID: "key_1", Name: "ci-key"})
+	h.mockResponse("GET", "/organization/projects/proj_abc/service_accounts/svc_actor", 200, ProjectServiceAccount{ID: "svc_actor", Name: "deployer"})
+	h.mockResponse("GET", "/organization/projects/proj_abc/service_accounts/svc_new", 200, ProjectServiceAccount{ID: "svc_new", Name: "new-bot"})
+	h.mockResponse("GET", UsersListEndpoint+"/user_9", 200, User{ID: "user_9", Email: "nine@example.com"})
+
+	logs := parseAuditLogs(t, resolverFixture)
+	resolver := NewAuditLogResolver(h.client)
+
+	names := resolver.Resolve(&logs[0])
+	want := ResolvedNames{Actor: "deployer", APIKey: "ci-key", ServiceAccount: "deployer", Project: "Alpha", Resource: "new-bot"}
+	if names != want {
+		t.Errorf("expected %+v, got %+v", want, names)
+	}
+
+	names = resolver.Resolve(&logs[1])
+	if names.Actor != "admin@example.com" || names.Resource != "nine@example.com" || names.Project != "" {
+		t.Errorf("unexpected names for user event: %+v", names)
+	}
+
+	names = resolver.Resolve(&logs[2])
+	if names.Resource != "Alpha" {
+		t.Errorf("expected project resource name Alpha, got %+v", names)
+	}
+
+	// Repeated lookups are served from the cache.
+	resolver.Resolve(&logs[0])
+	h.assertRequest("GET", ProjectsListEndpoint+"/proj_abc", 1)
+	h.assertRequest("GET", AdminAPIKeysEndpoint+"/key_1", 1)
+	h.assertRequest("GET", "/organization/projects/proj_abc/api_keys/key_1", 1)
+}
+
+func TestAuditLogResolver_LookupFailure(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+
+	h.mockResponse("GET", UsersListEndpoint+"/user_9", 404, map[string]string{"error": "not found"})
+
+	logs := parseAuditLogs(t, resolverFixture)
+	resolver := NewAuditLogResolver(h.client)
+
+	names := resolver.Resolve(&logs[1])
+	if names.Resource != "" {
+		t.Errorf("expected empty resource name, got %q", names.Resource)
+	}
+	resolver.Resolve(&logs[1])
+	h.assertRequest("GET", UsersListEndpoint+"/user_9", 1)
+}
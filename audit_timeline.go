@@ -0,0 +1,298 @@
+package openaiorgs
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TimelineEntry is a single, human-readable step in the change history of a resource.
+// Entries are produced by BuildTimeline from raw audit logs.
+type TimelineEntry struct {
+	// Time is when the event took effect.
+	Time time.Time `json:"time"`
+	// EventID is the ID of the audit log the entry was built from.
+	EventID string `json:"event_id"`
+	// Type is the audit event type (e.g., "project.updated").
+	Type string `json:"type"`
+	// Actor describes who performed the action.
+	Actor string `json:"actor"`
+	// ProjectID is the project the event occurred in, if any.
+	ProjectID string `json:"project_id,omitempty"`
+	// ResourceID is the ID of the resource the event acted on, if any.
+	ResourceID string `json:"resource_id,omitempty"`
+	// Description is a sentence describing the change.
+	Description string `json:"description"`
+}
+
+// ResourceID returns the identifier of the resource an event acted on, taken from
+// the event details. It returns an empty string for events without a target resource,
+// such as login and logout events.
+func (al *AuditLog) ResourceID() string {
+	switch d := al.Details.(type) {
+	case *APIKeyCreated:
+		return d.ID
+	case *APIKeyUpdated:
+		return d.ID
+	case *APIKeyDeleted:
+		return d.ID
+	case *InviteSent:
+		return d.ID
+	case *InviteAccepted:
+		return d.ID
+	case *InviteDeleted:
+		return d.ID
+	case *OrganizationUpdated:
+		return d.ID
+	case *ProjectCreated:
+		return d.ID
+	case *ProjectUpdated:
+		return d.ID
+	case *ProjectArchived:
+		return d.ID
+	case *RateLimitUpdated:
+		return d.ID
+	case *RateLimitDeleted:
+		return d.ID
+	case *ServiceAccountCreated:
+		return d.ID
+	case *ServiceAccountUpdated:
+		return d.ID
+	case *ServiceAccountDeleted:
+		return d.ID
+	case *UserAdded:
+		return d.ID
+	case *UserUpdated:
+		return d.ID
+	case *UserDeleted:
+		return d.ID
+	case map[string]any:
+		if id, ok := d["id"].(string); ok {
+			return id
+		}
+	}
+	return ""
+}
+
+// ActorLabel returns a short description of who performed the event:
+// the user's email for sessions, or the owning user's email for API keys.
+func (al *AuditLog) ActorLabel() string {
+	switch {
+	case al.Actor.Session != nil:
+		return al.Actor.Session.User.Email
+	case al.Actor.APIKey != nil:
+		if al.Actor.APIKey.User.Email != "" {
+			return fmt.Sprintf("%s (api key)", al.Actor.APIKey.User.Email)
+		}
+		return "api key"
+	default:
+		return "unknown"
+	}
+}
+
+// BuildTimeline converts audit logs into a chronological change history, oldest first.
+// It remembers earlier values it has seen (project titles, roles and rate limits) so that
+// later updates can be described as "from X to Y" rather than just the new value.
+func BuildTimeline(logs []AuditLog) []TimelineEntry {
+	sorted := make([]AuditLog, len(logs))
+	copy(sorted, logs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		ti, tj := sorted[i].EffectiveAt.Time(), sorted[j].EffectiveAt.Time()
+		if ti.Equal(tj) {
+			return sorted[i].ID < sorted[j].ID
+		}
+		return ti.Before(tj)
+	})
+
+	state := newTimelineState()
+	entries := make([]TimelineEntry, 0, len(sorted))
+	for i := range sorted {
+		log := &sorted[i]
+		entry := TimelineEntry{
+			Time:        log.EffectiveAt.Time(),
+			EventID:     log.ID,
+			Type:        log.Type,
+			Actor:       log.ActorLabel(),
+			ResourceID:  log.ResourceID(),
+			Description: state.describe(log),
+		}
+		if log.Project != nil {
+			entry.ProjectID = log.Project.ID
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// timelineState tracks the last known value of mutable attributes while a timeline is built.
+type timelineState struct {
+	projectTitles map[string]string
+	roles         map[string]string
+	rateLimits    map[string]map[string]int
+}
+
+func newTimelineState() *timelineState {
+	return &timelineState{
+		projectTitles: make(map[string]string),
+		roles:         make(map[string]string),
+		rateLimits:    make(map[string]map[string]int),
+	}
+}
+
+// roleKey scopes a member's role to the project the event occurred in, since the same
+// user can hold different roles in the organization and in each project.
+func roleKey(log *AuditLog, id string) string {
+	if log.Project != nil {
+		return log.Project.ID + "/" + id
+	}
+	return id
+}
+
+func projectScope(log *AuditLog) string {
+	if log.Project == nil {
+		return "the organization"
+	}
+	if log.Project.Name != "" {
+		return fmt.Sprintf("project %q", log.Project.Name)
+	}
+	return "project " + log.Project.ID
+}
+
+func (s *timelineState) describe(log *AuditLog) string {
+	switch d := log.Details.(type) {
+	case *ProjectCreated:
+		title := d.Data.Title
+		if title == "" {
+			title = d.Data.Name
+		}
+		s.projectTitles[d.ID] = title
+		return fmt.Sprintf("created project %s %q", d.ID, title)
+	case *ProjectUpdated:
+		newTitle := d.ChangesRequested.Title
+		if newTitle == "" {
+			return fmt.Sprintf("updated project %s", d.ID)
+		}
+		oldTitle, known := s.projectTitles[d.ID]
+		s.projectTitles[d.ID] = newTitle
+		if known {
+			return fmt.Sprintf("renamed project %s from %q to %q", d.ID, oldTitle, newTitle)
+		}
+		return fmt.Sprintf("renamed project %s to %q", d.ID, newTitle)
+	case *ProjectArchived:
+		return fmt.Sprintf("archived project %s", d.ID)
+	case *RateLimitUpdated:
+		return s.describeRateLimit(d)
+	case *RateLimitDeleted:
+		delete(s.rateLimits, d.ID)
+		return fmt.Sprintf("deleted rate limit %s", d.ID)
+	case *UserAdded:
+		s.roles[roleKey(log, d.ID)] = d.Data.Role
+		return fmt.Sprintf("added user %s to %s with role %s", d.ID, projectScope(log), d.Data.Role)
+	case *UserUpdated:
+		return s.describeRoleChange(log, "user", d.ID, d.ChangesRequested.Role)
+	case *UserDeleted:
+		delete(s.roles, roleKey(log, d.ID))
+		return fmt.Sprintf("removed user %s from %s", d.ID, projectScope(log))
+	case *ServiceAccountCreated:
+		s.roles[roleKey(log, d.ID)] = d.Data.Role
+		return fmt.Sprintf("created service account %s in %s with role %s", d.ID, projectScope(log), d.Data.Role)
+	case *ServiceAccountUpdated:
+		return s.describeRoleChange(log, "service account", d.ID, d.ChangesRequested.Role)
+	case *ServiceAccountDeleted:
+		delete(s.roles, roleKey(log, d.ID))
+		return fmt.Sprintf("deleted service account %s from %s", d.ID, projectScope(log))
+	case *APIKeyCreated:
+		if len(d.Data.Scopes) > 0 {
+			return fmt.Sprintf("created API key %s with scopes %s", d.ID, strings.Join(d.Data.Scopes, ", "))
+		}
+		return fmt.Sprintf("created API key %s", d.ID)
+	case *APIKeyUpdated:
+		return fmt.Sprintf("changed scopes of API key %s to %s", d.ID, strings.Join(d.ChangesRequested.Scopes, ", "))
+	case *APIKeyDeleted:
+		return fmt.Sprintf("deleted API key %s", d.ID)
+	case *InviteSent:
+		return fmt.Sprintf("invited %s (invite %s)", d.Data.Email, d.ID)
+	case *InviteAccepted:
+		return fmt.Sprintf("invite %s was accepted", d.ID)
+	case *InviteDeleted:
+		return fmt.Sprintf("deleted invite %s", d.ID)
+	case *OrganizationUpdated:
+		if d.ChangesRequested.Name != "" {
+			return fmt.Sprintf("renamed the organization to %q", d.ChangesRequested.Name)
+		}
+		return "updated the organization"
+	case *LoginSucceeded:
+		return "logged in"
+	case *LoginFailed:
+		return fmt.Sprintf("failed to log in (%s)", d.ErrorCode)
+	case *LogoutFailed:
+		return fmt.Sprintf("failed to log out (%s)", d.ErrorCode)
+	}
+
+	switch log.Type {
+	case "logout.succeeded":
+		return "logged out"
+	case "login.succeeded":
+		return "logged in"
+	}
+	if id := log.ResourceID(); id != "" {
+		return fmt.Sprintf("%s on %s", log.Type, id)
+	}
+	return log.Type
+}
+
+func (s *timelineState) describeRoleChange(log *AuditLog, kind, id, newRole string) string {
+	key := roleKey(log, id)
+	oldRole, known := s.roles[key]
+	s.roles[key] = newRole
+	if known && oldRole != "" {
+		return fmt.Sprintf("changed role of %s %s in %s from %s to %s", kind, id, projectScope(log), oldRole, newRole)
+	}
+	return fmt.Sprintf("changed role of %s %s in %s to %s", kind, id, projectScope(log), newRole)
+}
+
+func (s *timelineState) describeRateLimit(d *RateLimitUpdated) string {
+	known := s.rateLimits[d.ID]
+	if known == nil {
+		known = make(map[string]int)
+		s.rateLimits[d.ID] = known
+	}
+
+	var changes []string
+	for _, field := range rateLimitUpdatedFields(d) {
+		if field.value == nil {
+			continue
+		}
+		value := *field.value
+		if old, ok := known[field.name]; ok && old != value {
+			changes = append(changes, fmt.Sprintf("%s from %d to %d", field.name, old, value))
+		} else {
+			changes = append(changes, fmt.Sprintf("%s to %d", field.name, value))
+		}
+		known[field.name] = value
+	}
+	if len(changes) == 0 {
+		return fmt.Sprintf("updated rate limit %s", d.ID)
+	}
+	return fmt.Sprintf("changed rate limit %s: %s", d.ID, strings.Join(changes, ", "))
+}
+
+type namedLimit struct {
+	name string
+	// value is nil when the event did not change the limit.
+	value *int
+}
+
+// rateLimitUpdatedFields lists the requested rate limit changes in a stable order.
+func rateLimitUpdatedFields(d *RateLimitUpdated) []namedLimit {
+	c := d.ChangesRequested
+	return []namedLimit{
+		{"max_requests_per_1_minute", c.MaxRequestsPer1Minute},
+		{"max_tokens_per_1_minute", c.MaxTokensPer1Minute},
+		{"max_images_per_1_minute", c.MaxImagesPer1Minute},
+		{"max_audio_megabytes_per_1_minute", c.MaxAudioMegabytesPer1Minute},
+		{"max_requests_per_1_day", c.MaxRequestsPer1Day},
+		{"batch_1_day_max_input_tokens", c.Batch1DayMaxInputTokens},
+	}
+}
@@ -0,0 +1,165 @@
+package openaiorgs
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func intPtr(i int) *int { return &i }
+
+func parseAuditLogs(t *testing.T, raw string) []AuditLog {
+	t.Helper()
+	var logs []AuditLog
+	if err := json.Unmarshal([]byte(raw), &logs); err != nil {
+		t.Fatalf("failed to parse audit logs: %v", err)
+	}
+	return logs
+}
+
+const timelineFixture = `[
+	{"id": "log_4", "type": "rate_limit.updated", "effective_at": 1700000400,
+	 "project": {"id": "proj_abc", "name": "Alpha"},
+	 "actor": {"type": "session", "session": {"user": {"id": "user_1", "email": "admin@example.com"}}},
+	 "rate_limit.updated": {"id": "rl_1", "changes_requested": {"max_requests_per_1_minute": 500}}},
+	{"id": "log_1", "type": "project.created", "effective_at": 1700000000,
+	 "project": {"id": "proj_abc", "name": "Alpha"},
+	 "actor": {"type": "session", "session": {"user": {"id": "user_1", "email": "admin@example.com"}}},
+	 "project.created": {"id": "proj_abc", "data": {"name": "alpha", "title": "Alpha"}}},
+	{"id": "log_2", "type": "project.updated", "effective_at": 1700000100,
+	 "project": {"id": "proj_abc", "name": "Alpha"},
+	 "actor": {"type": "api_key", "api_key": {"type": "user", "user": {"id": "user_2", "email": "ops@example.com"}}},
+	 "project.updated": {"id": "proj_abc", "changes_requested": {"title": "Beta"}}},
+	{"id": "log_3", "type": "rate_limit.updated", "effective_at": 1700000200,
+	 "project": {"id": "proj_abc", "name": "Alpha"},
+	 "actor": {"type": "session", "session": {"user": {"id": "user_1", "email": "admin@example.com"}}},
+	 "rate_limit.updated": {"id": "rl_1", "changes_requested": {"max_requests_per_1_minute": 100}}},
+	{"id": "log_5", "type": "user.added", "effective_at": 1700000500,
+	 "project": {"id": "proj_abc", "name": "Alpha"},
+	 "actor": {"type": "session", "session": {"user": {"id": "user_1", "email": "admin@example.com"}}},
+	 "user.added": {"id": "user_9", "data": {"role": "member"}}},
+	{"id": "log_6", "type": "user.updated", "effective_at": 1700000600,
+	 "project": {"id": "proj_abc", "name": "Alpha"},
+	 "actor": {"type": "session", "session": {"user": {"id": "user_1", "email": "admin@example.com"}}},
+	 "user.updated": {"id": "user_9", "changes_requested": {"role": "owner"}}}
+]`
+
+func TestBuildTimeline(t *testing.T) {
+	timeline := BuildTimeline(parseAuditLogs(t, timelineFixture))
+
+	want := []struct {
+		eventID     string
+		actor       string
+		description string
+	}{
+		{"log_1", "admin@example.com", `created project proj_abc "Alpha"`},
+		{"log_2", "ops@example.com (api key)", `renamed project proj_abc from "Alpha" to "Beta"`},
+		{"log_3", "admin@example.com", "changed rate limit rl_1: max_requests_per_1_minute to 100"},
+		{"log_4", "admin@example.com", "changed rate limit rl_1: max_requests_per_1_minute from 100 to 500"},
+		{"log_5", "admin@example.com", `added user user_9 to project "Alpha" with role member`},
+		{"log_6", "admin@example.com", `changed role of user user_9 in project "Alpha" from member to owner`},
+	}
+
+	if len(timeline) != len(want) {
+		t.Fatalf("expected %d entries, got %d", len(want), len(timeline))
+	}
+	for i, w := range want {
+		got := timeline[i]
+		if got.EventID != w.eventID {
+			t.Errorf("entry %d: expected event %s, got %s", i, w.eventID, got.EventID)
+		}
+		if got.Actor != w.actor {
+			t.Errorf("entry %d: expected actor %q, got %q", i, w.actor, got.Actor)
+		}
+		if got.Description != w.description {
+			t.Errorf("entry %d: expected description %q, got %q", i, w.description, got.Description)
+		}
+		if got.ProjectID != "proj_abc" {
+			t.Errorf("entry %d: expected project proj_abc, got %q", i, got.ProjectID)
+		}
+	}
+}
+
+func TestBuildTimeline_UnknownValues(t *testing.T) {
+	logs := parseAuditLogs(t, `[
+		{"id": "log_1", "type": "project.updated", "effective_at": 1700000000,
+		 "actor": {"type": "session", "session": {"user": {"id": "user_1", "email": "a@example.com"}}},
+		 "project.updated": {"id": "proj_abc", "changes_requested": {"title": "New"}}},
+		{"id": "log_2", "type": "logout.succeeded", "effective_at": 1700000100,
+		 "actor": {"type": "session", "session": {"user": {"id": "user_1", "email": "a@example.com"}}}}
+	]`)
+
+	timeline := BuildTimeline(logs)
+	if len(timeline) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(timeline))
+	}
+	if timeline[0].Description != `renamed project proj_abc to "New"` {
+		t.Errorf("unexpected description: %q", timeline[0].Description)
+	}
+	if timeline[1].Description != "logged out" || timeline[1].ResourceID != "" {
+		t.Errorf("unexpected logout entry: %+v", timeline[1])
+	}
+}
+
+func TestBuildTimeline_ProjectUpdateWithoutTitle(t *testing.T) {
+	logs := parseAuditLogs(t, `[
+		{"id": "log_1", "type": "project.created", "effective_at": 1700000000,
+		 "actor": {"type": "session", "session": {"user": {"id": "user_1", "email": "a@example.com"}}},
+		 "project.created": {"id": "proj_abc", "data": {"name": "alpha", "title": "Alpha"}}},
+		{"id": "log_2", "type": "project.updated", "effective_at": 1700000100,
+		 "actor": {"type": "session", "session": {"user": {"id": "user_1", "email": "a@example.com"}}},
+		 "project.updated": {"id": "proj_abc", "changes_requested": {}}},
+		{"id": "log_3", "type": "project.updated", "effective_at": 1700000200,
+		 "actor": {"type": "session", "session": {"user": {"id": "user_1", "email": "a@example.com"}}},
+		 "project.updated": {"id": "proj_abc", "changes_requested": {"title": "Beta"}}}
+	]`)
+
+	timeline := BuildTimeline(logs)
+	if timeline[1].Description != "updated project proj_abc" {
+		t.Errorf("unexpected description: %q", timeline[1].Description)
+	}
+	if timeline[2].Description != `renamed project proj_abc from "Alpha" to "Beta"` {
+		t.Errorf("expected the title to survive an update without one, got %q", timeline[2].Description)
+	}
+}
+
+func TestBuildTimeline_RateLimitSetToZero(t *testing.T) {
+	logs := parseAuditLogs(t, `[
+		{"id": "log_1", "type": "rate_limit.updated", "effective_at": 1700000000,
+		 "actor": {"type": "session", "session": {"user": {"id": "user_1", "email": "a@example.com"}}},
+		 "rate_limit.updated": {"id": "rl_1", "changes_requested": {"max_requests_per_1_minute": 500}}},
+		{"id": "log_2", "type": "rate_limit.updated", "effective_at": 1700000100,
+		 "actor": {"type": "session", "session": {"user": {"id": "user_1", "email": "a@example.com"}}},
+		 "rate_limit.updated": {"id": "rl_1", "changes_requested": {"max_requests_per_1_minute": 0}}}
+	]`)
+
+	timeline := BuildTimeline(logs)
+	if want := "changed rate limit rl_1: max_requests_per_1_minute from 500 to 0"; timeline[1].Description != want {
+		t.Errorf("description = %q, want %q", timeline[1].Description, want)
+	}
+}
+
+func TestAuditLogResourceID(t *testing.T) {
+	logs := parseAuditLogs(t, timelineFixture)
+	for _, log := range logs {
+		if log.ResourceID() == "" {
+			t.Errorf("expected resource ID for %s", log.Type)
+		}
+	}
+
+	generic := AuditLog{Details: map[string]any{"id": "thing_1"}}
+	if generic.ResourceID() != "thing_1" {
+		t.Errorf("expected thing_1, got %q", generic.ResourceID())
+	}
+}
+
+func TestAuditLogActorLabel(t *testing.T) {
+	unknown := AuditLog{}
+	if unknown.ActorLabel() != "unknown" {
+		t.Errorf("expected unknown, got %q", unknown.ActorLabel())
+	}
+	key := AuditLog{Actor: Actor{APIKey: &APIKeyActor{}}}
+	if !strings.HasPrefix(key.ActorLabel(), "api key") {
+		t.Errorf("expected api key label, got %q", key.ActorLabel())
+	}
+}
@@ -0,0 +1,467 @@
+package openaiorgs
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultThrottlePercent is the share of each rate limit a project keeps while it is
+// over budget, when neither the policy nor the project sets one.
+const DefaultThrottlePercent = 10
+
+// Budget journal actions.
+const (
+	// BudgetActionThrottle records that a project's rate limits were lowered.
+	BudgetActionThrottle = "throttle"
+	// BudgetActionRestore records that the original limits were restored because a
+	// new budget period started.
+	BudgetActionRestore = "restore"
+	// BudgetActionRelease records that the original limits were restored on request.
+	BudgetActionRelease = "release"
+)
+
+// Budget statuses reported by EnforceBudgets.
+const (
+	BudgetStatusWithinBudget     = "within_budget"
+	BudgetStatusThrottled        = "throttled"
+	BudgetStatusAlreadyThrottled = "already_throttled"
+	BudgetStatusRestored         = "restored"
+	BudgetStatusFailed           = "failed"
+)
+
+// BudgetPolicy sets a monthly budget per project. Budgets are in the currency
+// reported by the costs endpoint (USD).
+type BudgetPolicy struct {
+	// ThrottlePercent is the share of each rate limit kept while a project is over
+	// budget. Zero, like omitting it, means DefaultThrottlePercent; a project cannot
+	// be throttled to no traffic at all.
+	ThrottlePercent float64 `yaml:"throttle_percent,omitempty" json:"throttle_percent,omitempty"`
+	// Projects maps project IDs to their budgets.
+	Projects map[string]ProjectBudget `yaml:"projects" json:"projects"`
+}
+
+// ProjectBudget is one project's entry in a BudgetPolicy.
+type ProjectBudget struct {
+	MonthlyBudget float64 `yaml:"monthly_budget" json:"monthly_budget"`
+	// ThrottlePercent overrides the policy's ThrottlePercent for this project. Zero
+	// means no override.
+	ThrottlePercent float64 `yaml:"throttle_percent,omitempty" json:"throttle_percent,omitempty"`
+}
+
+// LoadBudgetPolicy reads a budget policy from a YAML (or JSON) file, for example:
+//
+//	throttle_percent: 10
+//	projects:
+//	  proj_abc:
+//	    monthly_budget: 500
+func LoadBudgetPolicy(path string) (*BudgetPolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read budget policy: %w", err)
+	}
+
+	var policy BudgetPolicy
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&policy); err != nil {
+		return nil, fmt.Errorf("failed to parse budget policy %s: %w", path, err)
+	}
+
+	if len(policy.Projects) == 0 {
+		return nil, fmt.Errorf("budget policy %s has no projects", path)
+	}
+	if policy.ThrottlePercent < 0 || policy.ThrottlePercent > 100 {
+		return nil, fmt.Errorf("throttle_percent must be between 0 and 100, got %v", policy.ThrottlePercent)
+	}
+	for id, budget := range policy.Projects {
+		if budget.MonthlyBudget <= 0 {
+			return nil, fmt.Errorf("project %s: monthly_budget must be greater than zero", id)
+		}
+		if budget.ThrottlePercent < 0 || budget.ThrottlePercent > 100 {
+			return nil, fmt.Errorf("project %s: throttle_percent must be between 0 and 100, got %v", id, budget.ThrottlePercent)
+		}
+	}
+	return &policy, nil
+}
+
+func (p *BudgetPolicy) throttlePercent(projectID string) float64 {
+	if pct := p.Projects[projectID].ThrottlePercent; pct > 0 {
+		return pct
+	}
+	if p.ThrottlePercent > 0 {
+		return p.ThrottlePercent
+	}
+	return DefaultThrottlePercent
+}
+
+// BudgetPeriod returns the monthly budget period containing t, e.g. "2024-06".
+func BudgetPeriod(t time.Time) string {
+	return t.UTC().Format("2006-01")
+}
+
+// BudgetJournalEntry is one line in a budget journal.
+type BudgetJournalEntry struct {
+	Time      time.Time `json:"time"`
+	Action    string    `json:"action"`
+	ProjectID string    `json:"project_id"`
+	// Period is the budget period the throttle belongs to.
+	Period   string  `json:"period"`
+	Spend    float64 `json:"spend,omitempty"`
+	Budget   float64 `json:"budget,omitempty"`
+	Currency string  `json:"currency,omitempty"`
+	// Changes are the rate limit changes that were made. For a throttle, From holds
+	// the original value that is restored later.
+	Changes []RateLimitChange `json:"changes,omitempty"`
+	Error   string            `json:"error,omitempty"`
+}
+
+// BudgetJournal is an append-only JSON lines file that records every throttle and
+// restore. It is also the record of which projects are currently throttled and
+// what their original rate limits were.
+type BudgetJournal struct {
+	Path string
+}
+
+// Append adds entry to the journal, creating the file with 0600 permissions if needed.
+func (j *BudgetJournal) Append(entry BudgetJournalEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal budget journal entry: %w", err)
+	}
+	f, err := os.OpenFile(j.Path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open budget journal: %w", err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write budget journal: %w", err)
+	}
+	return f.Close()
+}
+
+// Entries reads every entry in the journal. A missing journal has no entries.
+func (j *BudgetJournal) Entries() ([]BudgetJournalEntry, error) {
+	f, err := os.Open(j.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open budget journal: %w", err)
+	}
+	defer f.Close()
+
+	var entries []BudgetJournalEntry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var entry BudgetJournalEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("invalid budget journal line %d: %w", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read budget journal: %w", err)
+	}
+	return entries, nil
+}
+
+// ActiveThrottles returns the latest throttle entry of every project that has not
+// been restored or released since.
+func (j *BudgetJournal) ActiveThrottles() (map[string]BudgetJournalEntry, error) {
+	entries, err := j.Entries()
+	if err != nil {
+		return nil, err
+	}
+	active := make(map[string]BudgetJournalEntry)
+	for _, entry := range entries {
+		switch entry.Action {
+		case BudgetActionThrottle:
+			if len(entry.Changes) > 0 {
+				active[entry.ProjectID] = entry
+			}
+		case BudgetActionRestore, BudgetActionRelease:
+			delete(active, entry.ProjectID)
+		}
+	}
+	return active, nil
+}
+
+// BudgetStatus is the outcome of enforcing or releasing one project's budget.
+type BudgetStatus struct {
+	ProjectID string            `json:"project_id"`
+	Budget    float64           `json:"budget,omitempty"`
+	Spend     float64           `json:"spend"`
+	Currency  string            `json:"currency,omitempty"`
+	Status    string            `json:"status"`
+	Changes   []RateLimitChange `json:"changes,omitempty"`
+	Error     string            `json:"error,omitempty"`
+}
+
+// BudgetEnforcementOptions configures EnforceBudgets.
+type BudgetEnforcementOptions struct {
+	// DryRun reports what would happen without changing rate limits or writing the journal.
+	DryRun bool
+	// Now overrides the current time, mainly for tests. Defaults to time.Now.
+	Now time.Time
+}
+
+// EnforceBudgets compares each policy project's month-to-date cost with its budget.
+// Projects over budget have every rate limit lowered to the policy's throttle
+// percentage, and the original values are recorded in the journal. Throttles from an
+// earlier budget period are restored first. A project that is already throttled in the
+// current period is left alone.
+func EnforceBudgets(c OpenAIOrgsClient, policy *BudgetPolicy, journal *BudgetJournal, opts BudgetEnforcementOptions) ([]BudgetStatus, error) {
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	period := BudgetPeriod(now)
+
+	active, err := journal.ActiveThrottles()
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []BudgetStatus
+	for _, projectID := range sortedKeys(active) {
+		throttle := active[projectID]
+		if throttle.Period == period {
+			continue
+		}
+		status := restoreThrottle(c, journal, throttle, BudgetActionRestore, now, opts.DryRun)
+		if status.Status == BudgetStatusRestored {
+			delete(active, projectID)
+		}
+		statuses = append(statuses, status)
+	}
+
+	spend, currency, err := monthToDateCosts(c, now)
+	if err != nil {
+		return statuses, err
+	}
+
+	for _, projectID := range sortedKeys(policy.Projects) {
+		budget := policy.Projects[projectID].MonthlyBudget
+		status := BudgetStatus{
+			ProjectID: projectID,
+			Budget:    budget,
+			Spend:     spend[projectID],
+			Currency:  currency,
+			Status:    BudgetStatusWithinBudget,
+		}
+		if _, throttled := active[projectID]; throttled {
+			status.Status = BudgetStatusAlreadyThrottled
+		} else if status.Spend > budget {
+			throttleProject(c, journal, policy.throttlePercent(projectID), &status, period, now, opts.DryRun)
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+func throttleProject(c OpenAIOrgsClient, journal *BudgetJournal, percent float64, status *BudgetStatus, period string, now time.Time, dryRun bool) {
+	plans, err := PlanRateLimitScale(c, []string{status.ProjectID}, RateLimitScaleOptions{Percent: percent})
+	if err == nil && len(plans[0].Errors) > 0 {
+		err = errors.New(plans[0].Errors[0])
+	}
+	if err != nil {
+		status.Status = BudgetStatusFailed
+		status.Error = err.Error()
+		return
+	}
+
+	status.Status = BudgetStatusThrottled
+	if dryRun {
+		status.Changes = plans[0].Changes
+		return
+	}
+
+	var errs []error
+	for _, change := range plans[0].Changes {
+		if err := ApplyRateLimitChange(c, change); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		status.Changes = append(status.Changes, change)
+	}
+	if err := errors.Join(errs...); err != nil {
+		status.Status = BudgetStatusFailed
+		status.Error = err.Error()
+	}
+
+	// Record the throttle even if some changes failed, so the ones that were made are
+	// restored later.
+	entry := BudgetJournalEntry{
+		Time:      now,
+		Action:    BudgetActionThrottle,
+		ProjectID: status.ProjectID,
+		Period:    period,
+		Spend:     status.Spend,
+		Budget:    status.Budget,
+		Currency:  status.Currency,
+		Changes:   status.Changes,
+		Error:     status.Error,
+	}
+	if err := journal.Append(entry); err != nil {
+		status.Status = BudgetStatusFailed
+		status.addError(err)
+	}
+}
+
+// ReleaseBudgetThrottles restores the original rate limits of the given throttled
+// projects, or of every throttled project if projectIDs is empty.
+func ReleaseBudgetThrottles(c OpenAIOrgsClient, journal *BudgetJournal, projectIDs []string, dryRun bool) ([]BudgetStatus, error) {
+	active, err := journal.ActiveThrottles()
+	if err != nil {
+		return nil, err
+	}
+	if len(projectIDs) == 0 {
+		projectIDs = sortedKeys(active)
+	}
+
+	now := time.Now()
+	statuses := make([]BudgetStatus, 0, len(projectIDs))
+	for _, projectID := range projectIDs {
+		throttle, ok := active[projectID]
+		if !ok {
+			statuses = append(statuses, BudgetStatus{
+				ProjectID: projectID,
+				Status:    BudgetStatusFailed,
+				Error:     "project is not throttled",
+			})
+			continue
+		}
+		statuses = append(statuses, restoreThrottle(c, journal, throttle, BudgetActionRelease, now, dryRun))
+	}
+	return statuses, nil
+}
+
+// restoreThrottle reverses the changes of a throttle entry and journals the result.
+// Changes that fail to apply are kept in a new throttle entry so they can be retried.
+func restoreThrottle(c OpenAIOrgsClient, journal *BudgetJournal, throttle BudgetJournalEntry, action string, now time.Time, dryRun bool) BudgetStatus {
+	status := BudgetStatus{
+		ProjectID: throttle.ProjectID,
+		Budget:    throttle.Budget,
+		Spend:     throttle.Spend,
+		Currency:  throttle.Currency,
+		Status:    BudgetStatusRestored,
+	}
+
+	var failed []RateLimitChange
+	var errs []error
+	for _, change := range throttle.Changes {
+		restore := change.Reverse()
+		if !dryRun {
+			if err := ApplyRateLimitChange(c, restore); err != nil {
+				errs = append(errs, err)
+				failed = append(failed, change)
+				continue
+			}
+		}
+		status.Changes = append(status.Changes, restore)
+	}
+	if dryRun {
+		return status
+	}
+
+	entry := BudgetJournalEntry{
+		Time:      now,
+		Action:    action,
+		ProjectID: throttle.ProjectID,
+		Period:    throttle.Period,
+		Changes:   status.Changes,
+	}
+	if err := errors.Join(errs...); err != nil {
+		status.Status = BudgetStatusFailed
+		status.Error = err.Error()
+		entry.Error = status.Error
+	}
+	journalErr := journal.Append(entry)
+	if journalErr == nil && len(failed) > 0 {
+		// Keep the changes that could not be restored active.
+		retry := throttle
+		retry.Time = now
+		retry.Changes = failed
+		journalErr = journal.Append(retry)
+	}
+	if journalErr != nil {
+		status.Status = BudgetStatusFailed
+		status.addError(journalErr)
+	}
+	return status
+}
+
+// addError appends err to s.Error on a new line, without a blank first line when
+// s.Error is empty.
+func (s *BudgetStatus) addError(err error) {
+	if s.Error == "" {
+		s.Error = err.Error()
+		return
+	}
+	s.Error += "\n" + err.Error()
+}
+
+// Reverse returns the change that undoes ch.
+func (ch RateLimitChange) Reverse() RateLimitChange {
+	reversed := ch
+	reversed.Fields = make([]RateLimitFieldChange, len(ch.Fields))
+	for i, field := range ch.Fields {
+		reversed.Fields[i] = RateLimitFieldChange{Field: field.Field, From: field.To, To: field.From}
+	}
+	return reversed
+}
+
+// monthToDateCosts returns the cost per project since the start of the month containing now.
+func monthToDateCosts(c OpenAIOrgsClient, now time.Time) (map[string]float64, string, error) {
+	utc := now.UTC()
+	start := time.Date(utc.Year(), utc.Month(), 1, 0, 0, 0, 0, time.UTC)
+	params := map[string]string{
+		"start_time":   strconv.FormatInt(start.Unix(), 10),
+		"bucket_width": "1d",
+		"group_by":     "project_id",
+		"limit":        "31",
+	}
+
+	spend := make(map[string]float64)
+	currency := ""
+	for {
+		resp, err := c.GetCostsUsage(params)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get costs: %w", err)
+		}
+		for _, bucket := range resp.Data {
+			for _, result := range bucket.Results {
+				spend[result.ProjectID] += result.Amount.Value
+				if currency == "" {
+					currency = result.Amount.Currency
+				}
+			}
+		}
+		if !resp.HasMore || resp.NextPage == "" || resp.NextPage == params["page"] {
+			return spend, currency, nil
+		}
+		params["page"] = resp.NextPage
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
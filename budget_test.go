@@ -0,0 +1,234 @@
+package openaiorgs
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func writeBudgetPolicy(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "budget-policy.yaml")
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func mockProjectCosts(h *testHelper, costs map[string]float64) {
+	var results []CostsUsageResult
+	for projectID, value := range costs {
+		results = append(results, CostsUsageResult{ProjectID: projectID, Amount: CostAmount{Value: value, Currency: "usd"}})
+	}
+	h.mockResponse("GET", usageCostsEndpoint, 200, CostsUsageResponse{
+		Object: "page",
+		Data:   []CostsUsageBucket{{Results: results}},
+	})
+}
+
+func TestLoadBudgetPolicy(t *testing.T) {
+	path := writeBudgetPolicy(t, `
+throttle_percent: 20
+projects:
+  proj_a:
+    monthly_budget: 100
+  proj_b:
+    monthly_budget: 50
+    throttle_percent: 5
+`)
+	policy, err := LoadBudgetPolicy(path)
+	if err != nil {
+		t.Fatalf("LoadBudgetPolicy() error = %v", err)
+	}
+	if policy.throttlePercent("proj_a") != 20 || policy.throttlePercent("proj_b") != 5 {
+		t.Errorf("unexpected throttle percents: %+v", policy)
+	}
+	if (&BudgetPolicy{}).throttlePercent("proj_a") != DefaultThrottlePercent {
+		t.Error("expected default throttle percent")
+	}
+	zero, err := LoadBudgetPolicy(writeBudgetPolicy(t, "throttle_percent: 0\nprojects:\n  proj_a:\n    monthly_budget: 10\n"))
+	if err != nil || zero.throttlePercent("proj_a") != DefaultThrottlePercent {
+		t.Errorf("expected throttle_percent 0 to use the default, got %v", err)
+	}
+
+	for name, contents := range map[string]string{
+		"no projects":    "throttle_percent: 10\n",
+		"zero budget":    "projects:\n  proj_a:\n    monthly_budget: 0\n",
+		"unknown field":  "projects:\n  proj_a:\n    budget: 10\n",
+		"bad percentage": "throttle_percent: 150\nprojects:\n  proj_a:\n    monthly_budget: 10\n",
+	} {
+		if _, err := LoadBudgetPolicy(writeBudgetPolicy(t, contents)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestEnforceBudgets(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+	journal := &BudgetJournal{Path: filepath.Join(t.TempDir(), "journal.jsonl")}
+	policy := &BudgetPolicy{Projects: map[string]ProjectBudget{
+		"proj_over":  {MonthlyBudget: 100},
+		"proj_under": {MonthlyBudget: 100},
+	}}
+
+	httpmock.RegisterResponder("GET", testBaseURL+usageCostsEndpoint, func(req *http.Request) (*http.Response, error) {
+		q := req.URL.Query()
+		if q.Get("start_time") != "1717200000" || q.Get("group_by") != "project_id" {
+			return httpmock.NewStringResponse(400, "unexpected query "+req.URL.RawQuery), nil
+		}
+		return httpmock.NewJsonResponse(200, CostsUsageResponse{Object: "page", Data: []CostsUsageBucket{
+			{Results: []CostsUsageResult{{ProjectID: "proj_over", Amount: CostAmount{Value: 80, Currency: "usd"}}}},
+			{Results: []CostsUsageResult{
+				{ProjectID: "proj_over", Amount: CostAmount{Value: 40.5, Currency: "usd"}},
+				{ProjectID: "proj_under", Amount: CostAmount{Value: 10, Currency: "usd"}},
+			}},
+		}})
+	})
+	mockProjectRateLimits(h, "proj_over",
+		ProjectRateLimit{ID: "rl_4o", Model: "gpt-4o", MaxRequestsPer1Minute: 500, MaxTokensPer1Minute: 30000})
+	h.mockResponse("POST", "/organization/projects/proj_over/rate_limits/rl_4o", 200, ProjectRateLimit{ID: "rl_4o"})
+
+	statuses, err := EnforceBudgets(h.client, policy, journal, BudgetEnforcementOptions{Now: now, DryRun: true})
+	if err != nil {
+		t.Fatalf("EnforceBudgets() dry run error = %v", err)
+	}
+	if len(statuses) != 2 || statuses[0].Status != BudgetStatusThrottled || len(statuses[0].Changes) != 1 {
+		t.Fatalf("unexpected dry run statuses: %+v", statuses)
+	}
+	h.assertRequest("POST", "/organization/projects/proj_over/rate_limits/rl_4o", 0)
+	if _, err := os.Stat(journal.Path); !os.IsNotExist(err) {
+		t.Errorf("dry run should not write the journal, stat error = %v", err)
+	}
+
+	statuses, err = EnforceBudgets(h.client, policy, journal, BudgetEnforcementOptions{Now: now})
+	if err != nil {
+		t.Fatalf("EnforceBudgets() error = %v", err)
+	}
+	over, under := statuses[0], statuses[1]
+	if over.ProjectID != "proj_over" || over.Status != BudgetStatusThrottled || over.Spend != 120.5 || over.Currency != "usd" {
+		t.Errorf("unexpected status for proj_over: %+v", over)
+	}
+	fields := over.Changes[0].Fields
+	if len(fields) != 2 || fields[0].From != 500 || fields[0].To != 50 || fields[1].From != 30000 || fields[1].To != 3000 {
+		t.Errorf("unexpected throttle changes: %+v", fields)
+	}
+	if under.Status != BudgetStatusWithinBudget || under.Spend != 10 {
+		t.Errorf("unexpected status for proj_under: %+v", under)
+	}
+	h.assertRequest("POST", "/organization/projects/proj_over/rate_limits/rl_4o", 1)
+
+	// A second run in the same month leaves the throttle alone.
+	statuses, err = EnforceBudgets(h.client, policy, journal, BudgetEnforcementOptions{Now: now.Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("EnforceBudgets() second run error = %v", err)
+	}
+	if statuses[0].Status != BudgetStatusAlreadyThrottled {
+		t.Errorf("expected already throttled, got %+v", statuses[0])
+	}
+	h.assertRequest("POST", "/organization/projects/proj_over/rate_limits/rl_4o", 1)
+
+	// The next month restores the original limits before checking spend again.
+	mockProjectCosts(h, map[string]float64{"proj_over": 1})
+	statuses, err = EnforceBudgets(h.client, policy, journal, BudgetEnforcementOptions{Now: now.AddDate(0, 1, 0)})
+	if err != nil {
+		t.Fatalf("EnforceBudgets() next month error = %v", err)
+	}
+	if len(statuses) != 3 || statuses[0].Status != BudgetStatusRestored || statuses[1].Status != BudgetStatusWithinBudget {
+		t.Fatalf("unexpected next month statuses: %+v", statuses)
+	}
+	restored := statuses[0].Changes[0].Fields
+	if restored[0].To != 500 || restored[1].To != 30000 {
+		t.Errorf("expected original limits to be restored, got %+v", restored)
+	}
+	h.assertRequest("POST", "/organization/projects/proj_over/rate_limits/rl_4o", 2)
+
+	entries, err := journal.Entries()
+	if err != nil {
+		t.Fatalf("Entries() error = %v", err)
+	}
+	if len(entries) != 2 || entries[0].Action != BudgetActionThrottle || entries[0].Period != "2024-06" || entries[1].Action != BudgetActionRestore {
+		t.Errorf("unexpected journal entries: %+v", entries)
+	}
+	if active, _ := journal.ActiveThrottles(); len(active) != 0 {
+		t.Errorf("expected no active throttles, got %+v", active)
+	}
+}
+
+func TestEnforceBudgets_JournalFailure(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+
+	journal := &BudgetJournal{Path: filepath.Join(t.TempDir(), "missing", "journal.jsonl")}
+	policy := &BudgetPolicy{Projects: map[string]ProjectBudget{"proj_over": {MonthlyBudget: 100}}}
+	mockProjectCosts(h, map[string]float64{"proj_over": 150})
+	mockProjectRateLimits(h, "proj_over", ProjectRateLimit{ID: "rl_4o", Model: "gpt-4o", MaxRequestsPer1Minute: 500})
+	h.mockResponse("POST", "/organization/projects/proj_over/rate_limits/rl_4o", 200, ProjectRateLimit{ID: "rl_4o"})
+
+	statuses, err := EnforceBudgets(h.client, policy, journal, BudgetEnforcementOptions{})
+	if err != nil {
+		t.Fatalf("EnforceBudgets() error = %v", err)
+	}
+	status := statuses[0]
+	if status.Status != BudgetStatusFailed || !strings.HasPrefix(status.Error, "failed to open budget journal") {
+		t.Errorf("expected only the journal error, got %+v", status)
+	}
+}
+
+func TestReleaseBudgetThrottles(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+
+	journal := &BudgetJournal{Path: filepath.Join(t.TempDir(), "journal.jsonl")}
+	throttle := func(projectID, rateLimitID string) BudgetJournalEntry {
+		return BudgetJournalEntry{
+			Action:    BudgetActionThrottle,
+			ProjectID: projectID,
+			Period:    "2024-06",
+			Changes: []RateLimitChange{{
+				ProjectID:   projectID,
+				RateLimitID: rateLimitID,
+				Model:       "gpt-4o",
+				Fields:      []RateLimitFieldChange{{Field: "max_requests_per_1_minute", From: 500, To: 50}},
+			}},
+		}
+	}
+	for _, entry := range []BudgetJournalEntry{throttle("proj_a", "rl_a"), throttle("proj_b", "rl_b")} {
+		if err := journal.Append(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+	h.mockResponse("POST", "/organization/projects/proj_a/rate_limits/rl_a", 200, ProjectRateLimit{ID: "rl_a"})
+	h.mockResponse("POST", "/organization/projects/proj_b/rate_limits/rl_b", 500, map[string]string{"error": "boom"})
+
+	statuses, err := ReleaseBudgetThrottles(h.client, journal, []string{"proj_a", "proj_c"}, false)
+	if err != nil {
+		t.Fatalf("ReleaseBudgetThrottles() error = %v", err)
+	}
+	if statuses[0].Status != BudgetStatusRestored || statuses[1].Status != BudgetStatusFailed || statuses[1].Error != "project is not throttled" {
+		t.Errorf("unexpected statuses: %+v", statuses)
+	}
+
+	// A failed restore keeps the throttle active so it can be retried.
+	statuses, err = ReleaseBudgetThrottles(h.client, journal, nil, false)
+	if err != nil {
+		t.Fatalf("ReleaseBudgetThrottles() error = %v", err)
+	}
+	if len(statuses) != 1 || statuses[0].ProjectID != "proj_b" || statuses[0].Status != BudgetStatusFailed || !strings.Contains(statuses[0].Error, "rl_b") {
+		t.Errorf("unexpected statuses: %+v", statuses)
+	}
+	active, err := journal.ActiveThrottles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := active["proj_b"]; !ok || len(active) != 1 {
+		t.Errorf("expected proj_b to stay throttled, got %+v", active)
+	}
+}
@@ -0,0 +1,214 @@
+package openaiorgs
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"time"
+)
+
+// DefaultCertificateValidity is the validity period of generated certificates when
+// CertificateRequest.Validity is not set.
+const DefaultCertificateValidity = 365 * 24 * time.Hour
+
+// certificateClockSkew backdates generated certificates so they are valid immediately
+// on machines whose clocks run slightly behind.
+const certificateClockSkew = 5 * time.Minute
+
+// CertificateRequest describes a certificate to generate.
+type CertificateRequest struct {
+	CommonName     string
+	Organization   string
+	DNSNames       []string
+	IPAddresses    []string
+	EmailAddresses []string
+	URIs           []string
+	// Validity defaults to DefaultCertificateValidity.
+	Validity time.Duration
+}
+
+// GeneratedCertificate is a PEM-encoded certificate and its private key.
+type GeneratedCertificate struct {
+	CertificatePEM string
+	PrivateKeyPEM  string
+	Info           CertificateInfo
+}
+
+// GenerateCA creates a self-signed CA certificate with a new ECDSA P-256 key. It is
+// intended for testing mutual TLS in development organizations.
+func GenerateCA(req CertificateRequest) (*GeneratedCertificate, error) {
+	template, err := req.template()
+	if err != nil {
+		return nil, err
+	}
+	template.IsCA = true
+	template.MaxPathLenZero = true
+	template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+	return signCertificate(template, template, key, key)
+}
+
+// GenerateClientCertificate creates a client authentication certificate with a new
+// ECDSA P-256 key, signed by the CA in caCertPEM and caKeyPEM.
+func GenerateClientCertificate(req CertificateRequest, caCertPEM, caKeyPEM string) (*GeneratedCertificate, error) {
+	caCerts, err := ParseCertificatePEM(caCertPEM)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CA certificate: %w", err)
+	}
+	ca := caCerts[0]
+	if !ca.IsCA {
+		return nil, fmt.Errorf("certificate %s is not a CA certificate", ca.Subject)
+	}
+	caKey, err := ParsePrivateKeyPEM(caKeyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CA key: %w", err)
+	}
+
+	template, err := req.template()
+	if err != nil {
+		return nil, err
+	}
+	template.KeyUsage = x509.KeyUsageDigitalSignature
+	template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	if template.NotAfter.After(ca.NotAfter) {
+		template.NotAfter = ca.NotAfter
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+	return signCertificate(template, ca, key, caKey)
+}
+
+func (req CertificateRequest) template() (*x509.Certificate, error) {
+	if req.CommonName == "" {
+		return nil, errors.New("a common name is required")
+	}
+	validity := req.Validity
+	if validity <= 0 {
+		validity = DefaultCertificateValidity
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate serial number: %w", err)
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: req.CommonName},
+		DNSNames:              req.DNSNames,
+		EmailAddresses:        req.EmailAddresses,
+		NotBefore:             now.Add(-certificateClockSkew),
+		NotAfter:              now.Add(validity),
+		BasicConstraintsValid: true,
+	}
+	if req.Organization != "" {
+		template.Subject.Organization = []string{req.Organization}
+	}
+	for _, value := range req.IPAddresses {
+		ip := net.ParseIP(value)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IP address %q", value)
+		}
+		template.IPAddresses = append(template.IPAddresses, ip)
+	}
+	for _, value := range req.URIs {
+		uri, err := url.Parse(value)
+		if err != nil || uri.Scheme == "" {
+			return nil, fmt.Errorf("invalid URI %q", value)
+		}
+		template.URIs = append(template.URIs, uri)
+	}
+	return template, nil
+}
+
+func signCertificate(template, parent *x509.Certificate, key *ecdsa.PrivateKey, signer crypto.Signer) (*GeneratedCertificate, error) {
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, signer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create certificate: %w", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse generated certificate: %w", err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode private key: %w", err)
+	}
+	return &GeneratedCertificate{
+		CertificatePEM: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		PrivateKeyPEM:  string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})),
+		Info:           NewCertificateInfo(cert),
+	}, nil
+}
+
+// ParsePrivateKeyPEM decodes a PKCS #8, SEC 1 (EC) or PKCS #1 (RSA) private key.
+func ParsePrivateKeyPEM(content string) (crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(content))
+	if block == nil {
+		return nil, errors.New("content is not PEM-encoded")
+	}
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse private key: %w", err)
+		}
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("unsupported private key type %T", key)
+		}
+		return signer, nil
+	case "EC PRIVATE KEY":
+		key, err := x509.ParseECPrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse private key: %w", err)
+		}
+		return key, nil
+	case "RSA PRIVATE KEY":
+		key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse private key: %w", err)
+		}
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unexpected PEM block %q, expected a private key", block.Type)
+	}
+}
+
+// WriteFiles writes the certificate to certPath (mode 0644) and the private key to
+// keyPath (mode 0600). Existing files are only replaced when overwrite is set.
+func (g *GeneratedCertificate) WriteFiles(certPath, keyPath string, overwrite bool) error {
+	if !overwrite {
+		for _, path := range []string{certPath, keyPath} {
+			if _, err := os.Stat(path); err == nil {
+				return fmt.Errorf("%s already exists", path)
+			} else if !errors.Is(err, os.ErrNotExist) {
+				return fmt.Errorf("failed to check %s: %w", path, err)
+			}
+		}
+	}
+	if err := writeSecretFile(keyPath, []byte(g.PrivateKeyPEM)); err != nil {
+		return err
+	}
+	if err := os.WriteFile(certPath, []byte(g.CertificatePEM), 0o644); err != nil {
+		return fmt.Errorf("failed to write certificate: %w", err)
+	}
+	return nil
+}
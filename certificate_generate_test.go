@@ -0,0 +1,160 @@
+package openaiorgs
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerateCertificates(t *testing.T) {
+	ca, err := GenerateCA(CertificateRequest{CommonName: "Test CA", Organization: "Example", Validity: 48 * time.Hour})
+	if err != nil {
+		t.Fatalf("GenerateCA() error = %v", err)
+	}
+	if !ca.Info.IsCA || ca.Info.Subject != "CN=Test CA,O=Example" {
+		t.Errorf("unexpected CA: %+v", ca.Info)
+	}
+	if _, err := ValidateCertificatePEM(ca.CertificatePEM, CertificateValidationOptions{}); err != nil {
+		t.Errorf("generated CA should pass upload validation: %v", err)
+	}
+
+	client, err := GenerateClientCertificate(CertificateRequest{
+		CommonName:  "client",
+		DNSNames:    []string{"client.example.com"},
+		IPAddresses: []string{"10.0.0.1"},
+		URIs:        []string{"spiffe://example/client"},
+		Validity:    365 * 24 * time.Hour,
+	}, ca.CertificatePEM, ca.PrivateKeyPEM)
+	if err != nil {
+		t.Fatalf("GenerateClientCertificate() error = %v", err)
+	}
+	if client.Info.IsCA || client.Info.Issuer != ca.Info.Subject {
+		t.Errorf("unexpected client certificate: %+v", client.Info)
+	}
+	if got := strings.Join(client.Info.SANs(), ","); got != "DNS:client.example.com,IP:10.0.0.1,URI:spiffe://example/client" {
+		t.Errorf("SANs = %s", got)
+	}
+	if client.Info.NotAfter.After(ca.Info.NotAfter) {
+		t.Error("client certificate should not outlive its CA")
+	}
+	if _, err := ParsePrivateKeyPEM(client.PrivateKeyPEM); err != nil {
+		t.Errorf("ParsePrivateKeyPEM() error = %v", err)
+	}
+
+	if _, err := GenerateClientCertificate(CertificateRequest{CommonName: "x"}, client.CertificatePEM, client.PrivateKeyPEM); err == nil {
+		t.Error("expected error when signing with a non-CA certificate")
+	}
+	if _, err := GenerateCA(CertificateRequest{}); err == nil {
+		t.Error("expected error without a common name")
+	}
+	if _, err := GenerateCA(CertificateRequest{CommonName: "x", IPAddresses: []string{"nope"}}); err == nil {
+		t.Error("expected error for invalid IP address")
+	}
+}
+
+func TestGeneratedCertificateWriteFiles(t *testing.T) {
+	ca, err := GenerateCA(CertificateRequest{CommonName: "Test CA"})
+	if err != nil {
+		t.Fatalf("GenerateCA() error = %v", err)
+	}
+	dir := t.TempDir()
+	certPath, keyPath := filepath.Join(dir, "ca.pem"), filepath.Join(dir, "ca-key.pem")
+
+	if err := ca.WriteFiles(certPath, keyPath, false); err != nil {
+		t.Fatalf("WriteFiles() error = %v", err)
+	}
+	info, err := os.Stat(keyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("key mode = %v, want 0600", info.Mode().Perm())
+	}
+	if err := ca.WriteFiles(certPath, keyPath, false); err == nil {
+		t.Error("expected error when files already exist")
+	}
+	if err := ca.WriteFiles(certPath, keyPath, true); err != nil {
+		t.Errorf("WriteFiles() with overwrite error = %v", err)
+	}
+}
+
+func TestVerifyClientCertificate(t *testing.T) {
+	ca, err := GenerateCA(CertificateRequest{CommonName: "Test CA"})
+	if err != nil {
+		t.Fatalf("GenerateCA() error = %v", err)
+	}
+	other, err := GenerateCA(CertificateRequest{CommonName: "Other CA"})
+	if err != nil {
+		t.Fatalf("GenerateCA() error = %v", err)
+	}
+	client, err := GenerateClientCertificate(CertificateRequest{CommonName: "client"}, ca.CertificatePEM, ca.PrivateKeyPEM)
+	if err != nil {
+		t.Fatalf("GenerateClientCertificate() error = %v", err)
+	}
+
+	mockOrg := func(h *testHelper, caActive bool) {
+		expires := time.Now().AddDate(1, 0, 0)
+		h.mockResponse("GET", OrganizationCertificatesEndpoint, 200, ListResponse[Certificate]{Object: "list", Data: []Certificate{
+			mockCertificate("cert_other", "other", true, expires),
+			mockCertificate("cert_ca", "test-ca", caActive, expires),
+		}})
+		for id, content := range map[string]string{"cert_other": other.CertificatePEM, "cert_ca": ca.CertificatePEM} {
+			cert := mockCertificate(id, id, true, expires)
+			cert.CertificateDetails.Content = &content
+			h.mockResponse("GET", OrganizationCertificatesEndpoint+"/"+id, 200, cert)
+		}
+	}
+
+	t.Run("trusted", func(t *testing.T) {
+		h := newTestHelper(t)
+		defer h.cleanup()
+		mockOrg(h, true)
+
+		result, err := VerifyClientCertificate(h.client, client.CertificatePEM, time.Time{})
+		if err != nil {
+			t.Fatalf("VerifyClientCertificate() error = %v", err)
+		}
+		if !result.Trusted || result.CertificateID != "cert_ca" || result.Checked != 2 {
+			t.Errorf("unexpected result: %+v", result)
+		}
+	})
+
+	t.Run("inactive CA", func(t *testing.T) {
+		h := newTestHelper(t)
+		defer h.cleanup()
+		mockOrg(h, false)
+
+		result, err := VerifyClientCertificate(h.client, client.CertificatePEM, time.Time{})
+		if err != nil {
+			t.Fatalf("VerifyClientCertificate() error = %v", err)
+		}
+		if result.Trusted || result.Checked != 1 || result.Reason == "" {
+			t.Errorf("unexpected result: %+v", result)
+		}
+		h.assertRequest("GET", OrganizationCertificatesEndpoint+"/cert_ca", 0)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		h := newTestHelper(t)
+		defer h.cleanup()
+		mockOrg(h, true)
+
+		result, err := VerifyClientCertificate(h.client, client.CertificatePEM, time.Now().AddDate(2, 0, 0))
+		if err != nil {
+			t.Fatalf("VerifyClientCertificate() error = %v", err)
+		}
+		if result.Trusted {
+			t.Errorf("expected expired certificate to be untrusted: %+v", result)
+		}
+	})
+
+	t.Run("not a certificate", func(t *testing.T) {
+		h := newTestHelper(t)
+		defer h.cleanup()
+		if _, err := VerifyClientCertificate(h.client, client.PrivateKeyPEM, time.Time{}); err == nil {
+			t.Error("expected error for private key input")
+		}
+	})
+}
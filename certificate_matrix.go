@@ -0,0 +1,131 @@
+package openaiorgs
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+)
+
+// DefaultCertificateExpiryWarning is how close to expiry an active certificate must be
+// for BuildCertificateMatrix to flag it, when CertificateMatrixOptions.ExpiringWithin is
+// not set.
+const DefaultCertificateExpiryWarning = 30 * 24 * time.Hour
+
+// Certificate matrix project flags.
+const (
+	// CertificateFlagNoActive flags a project with no active certificate.
+	CertificateFlagNoActive = "no_active_certificate"
+	// CertificateFlagExpiring flags a project with an active certificate that expires
+	// within the warning window (or has already expired).
+	CertificateFlagExpiring = "expiring_certificate"
+)
+
+// CertificateMatrixOptions configures BuildCertificateMatrix.
+type CertificateMatrixOptions struct {
+	// ExpiringWithin is the warning window for active certificates. Defaults to
+	// DefaultCertificateExpiryWarning.
+	ExpiringWithin time.Duration
+	// IncludeArchived also includes archived projects.
+	IncludeArchived bool
+	// Concurrency is the number of projects fetched in parallel.
+	Concurrency int
+	// Now overrides the current time, mainly for tests. Defaults to time.Now.
+	Now time.Time
+}
+
+// CertificateMatrixProject is one row of a CertificateMatrix.
+type CertificateMatrixProject struct {
+	ProjectID   string `json:"project_id"`
+	ProjectName string `json:"project_name"`
+	// Active lists the IDs of the certificates active in the project.
+	Active []string `json:"active"`
+	// Expiring lists the active certificates that expire within the warning window.
+	Expiring []string `json:"expiring,omitempty"`
+	Flags    []string `json:"flags,omitempty"`
+}
+
+// IsActive reports whether the certificate is active in the project.
+func (p CertificateMatrixProject) IsActive(certificateID string) bool {
+	return slices.Contains(p.Active, certificateID)
+}
+
+// CertificateMatrix shows which certificates are active in which projects.
+type CertificateMatrix struct {
+	GeneratedAt time.Time `json:"generated_at"`
+	// Certificates are the columns: every organization certificate, by name.
+	Certificates []Certificate `json:"certificates"`
+	// Expiring lists the IDs of certificates that expire within the warning window.
+	Expiring []string                   `json:"expiring,omitempty"`
+	Projects []CertificateMatrixProject `json:"projects"`
+}
+
+// BuildCertificateMatrix lists every organization certificate and, for each project
+// (fetched concurrently), which of them are active there. Projects with no active
+// certificate, or with an active certificate that expires within opts.ExpiringWithin,
+// are flagged.
+func BuildCertificateMatrix(c OpenAIOrgsClient, opts CertificateMatrixOptions) (*CertificateMatrix, error) {
+	now := lifecycleNow(opts.Now)
+	within := opts.ExpiringWithin
+	if within <= 0 {
+		within = DefaultCertificateExpiryWarning
+	}
+
+	certificates, err := listAllOrganizationCertificates(c)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(certificates, func(i, j int) bool { return certificates[i].Name < certificates[j].Name })
+
+	projects, err := ListAll(func(after string) (*ListResponse[Project], error) {
+		return c.ListProjects(100, after, opts.IncludeArchived)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+
+	matrix := &CertificateMatrix{
+		GeneratedAt:  now,
+		Certificates: certificates,
+		Projects:     make([]CertificateMatrixProject, len(projects)),
+	}
+	expiring := make(map[string]bool)
+	for _, cert := range certificates {
+		if !time.Time(cert.CertificateDetails.ExpiresAt).After(now.Add(within)) {
+			expiring[cert.ID] = true
+			matrix.Expiring = append(matrix.Expiring, cert.ID)
+		}
+	}
+
+	err = forEachConcurrently(len(projects), opts.Concurrency, func(i int) error {
+		project := projects[i]
+		row := CertificateMatrixProject{ProjectID: project.ID, ProjectName: project.Name, Active: []string{}}
+		projectCertificates, err := ListAll(func(after string) (*ListResponse[Certificate], error) {
+			return c.ListProjectCertificates(project.ID, 100, after, "")
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list certificates for project %s: %w", project.ID, err)
+		}
+		for _, cert := range projectCertificates {
+			if cert.Active == nil || !*cert.Active {
+				continue
+			}
+			row.Active = append(row.Active, cert.ID)
+			if expiring[cert.ID] {
+				row.Expiring = append(row.Expiring, cert.ID)
+			}
+		}
+		if len(row.Active) == 0 {
+			row.Flags = append(row.Flags, CertificateFlagNoActive)
+		}
+		if len(row.Expiring) > 0 {
+			row.Flags = append(row.Flags, CertificateFlagExpiring)
+		}
+		matrix.Projects[i] = row
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return matrix, nil
+}
@@ -0,0 +1,47 @@
+package openaiorgs
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBuildCertificateMatrix(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	current := mockCertificate("cert_current", "b-current", true, now.AddDate(1, 0, 0))
+	old := mockCertificate("cert_old", "a-old", true, now.AddDate(0, 0, 10))
+	h.mockResponse("GET", OrganizationCertificatesEndpoint, 200, ListResponse[Certificate]{Object: "list", Data: []Certificate{current, old}})
+	h.mockResponse("GET", "/organization/projects", 200, ListResponse[Project]{Object: "list", Data: []Project{
+		{ID: "proj_a", Name: "A"}, {ID: "proj_b", Name: "B"}, {ID: "proj_c", Name: "C"},
+	}})
+	h.mockResponse("GET", "/organization/projects/proj_a/certificates", 200, ListResponse[Certificate]{Object: "list", Data: []Certificate{current}})
+	h.mockResponse("GET", "/organization/projects/proj_b/certificates", 200, ListResponse[Certificate]{Object: "list", Data: []Certificate{current, old}})
+	inactive := mockCertificate("cert_current", "b-current", false, now.AddDate(1, 0, 0))
+	h.mockResponse("GET", "/organization/projects/proj_c/certificates", 200, ListResponse[Certificate]{Object: "list", Data: []Certificate{inactive}})
+
+	matrix, err := BuildCertificateMatrix(h.client, CertificateMatrixOptions{Now: now, Concurrency: 2})
+	if err != nil {
+		t.Fatalf("BuildCertificateMatrix() error = %v", err)
+	}
+	if len(matrix.Certificates) != 2 || matrix.Certificates[0].ID != "cert_old" {
+		t.Errorf("expected certificates sorted by name, got %+v", matrix.Certificates)
+	}
+	if !reflect.DeepEqual(matrix.Expiring, []string{"cert_old"}) {
+		t.Errorf("Expiring = %v", matrix.Expiring)
+	}
+
+	want := []CertificateMatrixProject{
+		{ProjectID: "proj_a", ProjectName: "A", Active: []string{"cert_current"}},
+		{ProjectID: "proj_b", ProjectName: "B", Active: []string{"cert_current", "cert_old"}, Expiring: []string{"cert_old"}, Flags: []string{CertificateFlagExpiring}},
+		{ProjectID: "proj_c", ProjectName: "C", Active: []string{}, Flags: []string{CertificateFlagNoActive}},
+	}
+	if !reflect.DeepEqual(matrix.Projects, want) {
+		t.Errorf("Projects = %+v, want %+v", matrix.Projects, want)
+	}
+	if !matrix.Projects[1].IsActive("cert_old") || matrix.Projects[0].IsActive("cert_old") {
+		t.Error("unexpected IsActive result")
+	}
+}
@@ -0,0 +1,168 @@
+package openaiorgs
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CertificateInfo summarizes a parsed X.509 certificate.
+type CertificateInfo struct {
+	Subject        string    `json:"subject"`
+	Issuer         string    `json:"issuer"`
+	SerialNumber   string    `json:"serial_number"`
+	DNSNames       []string  `json:"dns_names,omitempty"`
+	IPAddresses    []string  `json:"ip_addresses,omitempty"`
+	EmailAddresses []string  `json:"email_addresses,omitempty"`
+	URIs           []string  `json:"uris,omitempty"`
+	IsCA           bool      `json:"is_ca"`
+	NotBefore      time.Time `json:"not_before"`
+	NotAfter       time.Time `json:"not_after"`
+	// SHA256Fingerprint is the SHA-256 digest of the DER encoding, as colon-separated hex.
+	SHA256Fingerprint string `json:"sha256_fingerprint"`
+}
+
+// SANs returns the subject alternative names with their type prefix (DNS:, IP:, email:, URI:).
+func (info CertificateInfo) SANs() []string {
+	var sans []string
+	for _, name := range info.DNSNames {
+		sans = append(sans, "DNS:"+name)
+	}
+	for _, ip := range info.IPAddresses {
+		sans = append(sans, "IP:"+ip)
+	}
+	for _, email := range info.EmailAddresses {
+		sans = append(sans, "email:"+email)
+	}
+	for _, uri := range info.URIs {
+		sans = append(sans, "URI:"+uri)
+	}
+	return sans
+}
+
+// ValidAt reports whether t is within the certificate's validity period.
+func (info CertificateInfo) ValidAt(t time.Time) bool {
+	return !t.Before(info.NotBefore) && !t.After(info.NotAfter)
+}
+
+// NewCertificateInfo summarizes cert.
+func NewCertificateInfo(cert *x509.Certificate) CertificateInfo {
+	sum := sha256.Sum256(cert.Raw)
+	fingerprint := strings.ToUpper(hex.EncodeToString(sum[:]))
+	pairs := make([]string, 0, len(sum))
+	for i := 0; i < len(fingerprint); i += 2 {
+		pairs = append(pairs, fingerprint[i:i+2])
+	}
+
+	info := CertificateInfo{
+		Subject:           cert.Subject.String(),
+		Issuer:            cert.Issuer.String(),
+		SerialNumber:      cert.SerialNumber.String(),
+		DNSNames:          cert.DNSNames,
+		EmailAddresses:    cert.EmailAddresses,
+		IsCA:              cert.IsCA,
+		NotBefore:         cert.NotBefore,
+		NotAfter:          cert.NotAfter,
+		SHA256Fingerprint: strings.Join(pairs, ":"),
+	}
+	for _, ip := range cert.IPAddresses {
+		info.IPAddresses = append(info.IPAddresses, ip.String())
+	}
+	for _, uri := range cert.URIs {
+		info.URIs = append(info.URIs, uri.String())
+	}
+	return info
+}
+
+// ParseCertificatePEM decodes every CERTIFICATE block in content. It fails on input
+// that is not PEM, on private keys and other non-certificate blocks, and on
+// certificates that crypto/x509 cannot parse.
+func ParseCertificatePEM(content string) ([]*x509.Certificate, error) {
+	rest := []byte(content)
+	var certs []*x509.Certificate
+	for {
+		block, remaining := pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		rest = remaining
+
+		if strings.Contains(block.Type, "PRIVATE KEY") {
+			return nil, fmt.Errorf("PEM contains a private key (%s); upload only the certificate and keep the key secret", block.Type)
+		}
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("unexpected PEM block %q, expected CERTIFICATE", block.Type)
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate %d: %w", len(certs)+1, err)
+		}
+		certs = append(certs, cert)
+	}
+
+	if len(bytes.TrimSpace(rest)) > 0 {
+		if len(certs) == 0 {
+			return nil, errors.New("content is not PEM-encoded (expected -----BEGIN CERTIFICATE-----)")
+		}
+		return nil, errors.New("content has unparseable data after the last PEM block")
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("content contains no certificates")
+	}
+	return certs, nil
+}
+
+// InspectCertificatePEM parses content and summarizes each certificate, without
+// checking validity dates or CA constraints.
+func InspectCertificatePEM(content string) ([]CertificateInfo, error) {
+	certs, err := ParseCertificatePEM(content)
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]CertificateInfo, len(certs))
+	for i, cert := range certs {
+		infos[i] = NewCertificateInfo(cert)
+	}
+	return infos, nil
+}
+
+// CertificateValidationOptions configures ValidateCertificatePEM.
+type CertificateValidationOptions struct {
+	// AllowLeaf accepts certificates that are not CA certificates. Certificates
+	// uploaded for mutual TLS are normally the CA that issued the client certificates.
+	AllowLeaf bool
+	// Now overrides the time used for the validity check, mainly for tests. Defaults to time.Now.
+	Now time.Time
+}
+
+// ValidateCertificatePEM parses content like InspectCertificatePEM and also rejects
+// certificates that are expired, not yet valid, or (unless opts.AllowLeaf is set) not
+// a CA.
+func ValidateCertificatePEM(content string, opts CertificateValidationOptions) ([]CertificateInfo, error) {
+	infos, err := InspectCertificatePEM(content)
+	if err != nil {
+		return nil, err
+	}
+
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	for _, info := range infos {
+		switch {
+		case now.After(info.NotAfter):
+			return infos, fmt.Errorf("certificate %s expired on %s", info.Subject, info.NotAfter.Format(time.RFC3339))
+		case now.Before(info.NotBefore):
+			return infos, fmt.Errorf("certificate %s is not valid until %s", info.Subject, info.NotBefore.Format(time.RFC3339))
+		case !info.IsCA && !opts.AllowLeaf:
+			return infos, fmt.Errorf("certificate %s is not a CA certificate", info.Subject)
+		}
+	}
+	return infos, nil
+}
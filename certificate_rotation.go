@@ -0,0 +1,325 @@
+package openaiorgs
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Certificate rotation step actions, in the order RotateCertificate performs them.
+const (
+	CertificateActionUpload            = "upload"
+	CertificateActionActivateOrg       = "activate_org"
+	CertificateActionActivateProject   = "activate_project"
+	CertificateActionDeactivateProject = "deactivate_project"
+	CertificateActionDeactivateOrg     = "deactivate_org"
+	CertificateActionDelete            = "delete"
+)
+
+// Certificate rotation step statuses.
+const (
+	CertificateStepPlanned = "planned"
+	CertificateStepDone    = "done"
+	CertificateStepFailed  = "failed"
+)
+
+// Certificate rotation statuses.
+const (
+	// CertificateRotationCompleted means the new certificate replaced the old one everywhere.
+	CertificateRotationCompleted = "completed"
+	// CertificateRotationRolledBack means a step failed and every completed step was undone.
+	CertificateRotationRolledBack = "rolled_back"
+	// CertificateRotationFailed means a step failed and the rollback failed too; the
+	// steps show what is left to fix by hand.
+	CertificateRotationFailed = "failed"
+	// CertificateRotationPlanned is the status of a dry run.
+	CertificateRotationPlanned = "planned"
+)
+
+// ExpiringCertificates returns the organization certificates that expire within the
+// given duration of now, soonest first. Certificates that have already expired are
+// included, since they are just as much in need of rotation.
+func ExpiringCertificates(c OpenAIOrgsClient, within time.Duration, now time.Time) ([]Certificate, error) {
+	now = lifecycleNow(now)
+	certificates, err := listAllOrganizationCertificates(c)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := now.Add(within)
+	var expiring []Certificate
+	for _, cert := range certificates {
+		if !time.Time(cert.CertificateDetails.ExpiresAt).After(deadline) {
+			expiring = append(expiring, cert)
+		}
+	}
+	sort.SliceStable(expiring, func(i, j int) bool {
+		return time.Time(expiring[i].CertificateDetails.ExpiresAt).Before(time.Time(expiring[j].CertificateDetails.ExpiresAt))
+	})
+	return expiring, nil
+}
+
+// CertificateRotationStep records one step of a certificate rotation. Rollback is set
+// on steps that undo an earlier step.
+type CertificateRotationStep struct {
+	Action        string `json:"action"`
+	ProjectID     string `json:"project_id,omitempty"`
+	CertificateID string `json:"certificate_id,omitempty"`
+	Rollback      bool   `json:"rollback,omitempty"`
+	Status        string `json:"status"`
+	Error         string `json:"error,omitempty"`
+}
+
+// CertificateRotation is the outcome of RotateCertificate.
+type CertificateRotation struct {
+	OldCertificateID string `json:"old_certificate_id"`
+	NewCertificateID string `json:"new_certificate_id,omitempty"`
+	Name             string `json:"name"`
+	// Projects are the projects in which the old certificate was active.
+	Projects []string                  `json:"projects"`
+	Steps    []CertificateRotationStep `json:"steps"`
+	Status   string                    `json:"status"`
+	Error    string                    `json:"error,omitempty"`
+}
+
+// CertificateRotationOptions configures RotateCertificate.
+type CertificateRotationOptions struct {
+	// Name is the name of the new certificate. Defaults to the old certificate's name
+	// followed by today's date, since certificate names must be unique.
+	Name string
+	// DryRun validates the new certificate and lists the steps without changing anything.
+	DryRun bool
+	// AllowLeaf accepts a replacement that is not a CA certificate, as
+	// CertificateValidationOptions.AllowLeaf does. Uploading it requires the client to
+	// implement CertificateOptionsUploader.
+	AllowLeaf bool
+	// Concurrency bounds how many projects are checked at once. Defaults to
+	// DefaultInventoryConcurrency.
+	Concurrency int
+}
+
+// RotateCertificate replaces the organization certificate oldID with the PEM-encoded
+// certificate in content. It uploads the new certificate, activates it at the
+// organization level and in every project where the old certificate is active, then
+// deactivates the old certificate in those projects and (if it was active there) at
+// the organization level. The old certificate is not deleted.
+//
+// If any step fails, the completed steps are undone in reverse order and the uploaded
+// certificate is deleted. The returned rotation describes every step, including the
+// rollback, and is returned even when err is non-nil unless nothing was changed.
+func RotateCertificate(c OpenAIOrgsClient, oldID, content string, opts CertificateRotationOptions) (*CertificateRotation, error) {
+	validation := CertificateValidationOptions{AllowLeaf: opts.AllowLeaf}
+	if _, err := ValidateCertificatePEM(content, validation); err != nil {
+		return nil, fmt.Errorf("invalid certificate: %w", err)
+	}
+	upload := c.UploadCertificate
+	if opts.AllowLeaf {
+		uploader, ok := c.(CertificateOptionsUploader)
+		if !ok {
+			return nil, fmt.Errorf("client does not support uploading leaf certificates")
+		}
+		upload = func(content, name string) (*Certificate, error) {
+			return uploader.UploadCertificateWithOptions(content, name, validation)
+		}
+	}
+
+	certificates, err := listAllOrganizationCertificates(c)
+	if err != nil {
+		return nil, err
+	}
+	var old *Certificate
+	for i := range certificates {
+		if certificates[i].ID == oldID {
+			old = &certificates[i]
+			break
+		}
+	}
+	if old == nil {
+		return nil, fmt.Errorf("no organization certificate %s", oldID)
+	}
+	oldActive := old.Active != nil && *old.Active
+
+	projects, err := projectsWithActiveCertificate(c, oldID, opts.Concurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	rotation := &CertificateRotation{
+		OldCertificateID: oldID,
+		Name:             opts.Name,
+		Projects:         projects,
+	}
+	if rotation.Name == "" {
+		rotation.Name = fmt.Sprintf("%s %s", old.Name, time.Now().UTC().Format("2006-01-02"))
+	}
+
+	if opts.DryRun {
+		rotation.Status = CertificateRotationPlanned
+		rotation.Steps = append(rotation.Steps,
+			CertificateRotationStep{Action: CertificateActionUpload, Status: CertificateStepPlanned},
+			CertificateRotationStep{Action: CertificateActionActivateOrg, Status: CertificateStepPlanned})
+		for _, projectID := range projects {
+			rotation.Steps = append(rotation.Steps, CertificateRotationStep{Action: CertificateActionActivateProject, ProjectID: projectID, Status: CertificateStepPlanned})
+		}
+		for _, projectID := range projects {
+			rotation.Steps = append(rotation.Steps, CertificateRotationStep{Action: CertificateActionDeactivateProject, ProjectID: projectID, CertificateID: oldID, Status: CertificateStepPlanned})
+		}
+		if oldActive {
+			rotation.Steps = append(rotation.Steps, CertificateRotationStep{Action: CertificateActionDeactivateOrg, CertificateID: oldID, Status: CertificateStepPlanned})
+		}
+		return rotation, nil
+	}
+
+	uploaded, err := upload(content, rotation.Name)
+	if err != nil {
+		rotation.Steps = append(rotation.Steps, CertificateRotationStep{Action: CertificateActionUpload, Status: CertificateStepFailed, Error: err.Error()})
+		rotation.Status = CertificateRotationFailed
+		rotation.Error = fmt.Sprintf("failed to upload new certificate: %v", err)
+		return rotation, fmt.Errorf("failed to upload new certificate: %w", err)
+	}
+	newID := uploaded.ID
+	rotation.NewCertificateID = newID
+	rotation.Steps = append(rotation.Steps, CertificateRotationStep{Action: CertificateActionUpload, CertificateID: newID, Status: CertificateStepDone})
+
+	// Each forward step has an undo that is run, newest first, if a later step fails.
+	type step struct {
+		CertificateRotationStep
+		do, undo   func() error
+		undoAction string
+	}
+	steps := []step{{
+		CertificateRotationStep: CertificateRotationStep{Action: CertificateActionActivateOrg, CertificateID: newID},
+		do:                      func() error { return activationResult(c.ActivateOrganizationCertificates([]string{newID})) },
+		undo:                    func() error { return activationResult(c.DeactivateOrganizationCertificates([]string{newID})) },
+		undoAction:              CertificateActionDeactivateOrg,
+	}}
+	for _, projectID := range projects {
+		steps = append(steps, step{
+			CertificateRotationStep: CertificateRotationStep{Action: CertificateActionActivateProject, ProjectID: projectID, CertificateID: newID},
+			do:                      func() error { return activationResult(c.ActivateProjectCertificates(projectID, []string{newID})) },
+			undo:                    func() error { return activationResult(c.DeactivateProjectCertificates(projectID, []string{newID})) },
+			undoAction:              CertificateActionDeactivateProject,
+		})
+	}
+	for _, projectID := range projects {
+		steps = append(steps, step{
+			CertificateRotationStep: CertificateRotationStep{Action: CertificateActionDeactivateProject, ProjectID: projectID, CertificateID: oldID},
+			do:                      func() error { return activationResult(c.DeactivateProjectCertificates(projectID, []string{oldID})) },
+			undo:                    func() error { return activationResult(c.ActivateProjectCertificates(projectID, []string{oldID})) },
+			undoAction:              CertificateActionActivateProject,
+		})
+	}
+	if oldActive {
+		steps = append(steps, step{
+			CertificateRotationStep: CertificateRotationStep{Action: CertificateActionDeactivateOrg, CertificateID: oldID},
+			do:                      func() error { return activationResult(c.DeactivateOrganizationCertificates([]string{oldID})) },
+			undo:                    func() error { return activationResult(c.ActivateOrganizationCertificates([]string{oldID})) },
+			undoAction:              CertificateActionActivateOrg,
+		})
+	}
+
+	for i, s := range steps {
+		if err := s.do(); err != nil {
+			s.Status = CertificateStepFailed
+			s.Error = err.Error()
+			rotation.Steps = append(rotation.Steps, s.CertificateRotationStep)
+			stepErr := fmt.Errorf("%s failed: %w", describeCertificateStep(s.CertificateRotationStep), err)
+
+			rolledBack := true
+			record := func(action, projectID, certificateID string, err error) {
+				rs := CertificateRotationStep{Action: action, ProjectID: projectID, CertificateID: certificateID, Rollback: true, Status: CertificateStepDone}
+				if err != nil {
+					rs.Status = CertificateStepFailed
+					rs.Error = err.Error()
+					rolledBack = false
+				}
+				rotation.Steps = append(rotation.Steps, rs)
+			}
+			for j := i - 1; j >= 0; j-- {
+				record(steps[j].undoAction, steps[j].ProjectID, steps[j].CertificateID, steps[j].undo())
+			}
+			_, delErr := c.DeleteCertificate(newID)
+			record(CertificateActionDelete, "", newID, delErr)
+
+			rotation.Status = CertificateRotationRolledBack
+			if !rolledBack {
+				rotation.Status = CertificateRotationFailed
+				stepErr = fmt.Errorf("%w; rollback was incomplete", stepErr)
+			}
+			rotation.Error = stepErr.Error()
+			return rotation, stepErr
+		}
+		s.Status = CertificateStepDone
+		rotation.Steps = append(rotation.Steps, s.CertificateRotationStep)
+	}
+
+	rotation.Status = CertificateRotationCompleted
+	return rotation, nil
+}
+
+func describeCertificateStep(s CertificateRotationStep) string {
+	if s.ProjectID != "" {
+		return fmt.Sprintf("%s %s in project %s", s.Action, s.CertificateID, s.ProjectID)
+	}
+	return fmt.Sprintf("%s %s", s.Action, s.CertificateID)
+}
+
+// activationResult turns a bulk (de)activation response into an error.
+func activationResult(resp *CertificateActivationResponse, err error) error {
+	if err != nil {
+		return err
+	}
+	if resp == nil || !resp.Success {
+		return fmt.Errorf("request was not successful")
+	}
+	return nil
+}
+
+// projectsWithActiveCertificate returns the active projects in which the certificate
+// is active, sorted by ID.
+func projectsWithActiveCertificate(c OpenAIOrgsClient, certificateID string, concurrency int) ([]string, error) {
+	projects, err := ListAll(func(after string) (*ListResponse[Project], error) {
+		return c.ListProjects(100, after, false)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+
+	active := make([]bool, len(projects))
+	err = forEachConcurrently(len(projects), concurrency, func(i int) error {
+		certificates, err := ListAll(func(after string) (*ListResponse[Certificate], error) {
+			return c.ListProjectCertificates(projects[i].ID, 100, after, "")
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list certificates for project %s: %w", projects[i].ID, err)
+		}
+		for _, cert := range certificates {
+			if cert.ID == certificateID && cert.Active != nil && *cert.Active {
+				active[i] = true
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for i, project := range projects {
+		if active[i] {
+			ids = append(ids, project.ID)
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+func listAllOrganizationCertificates(c OpenAIOrgsClient) ([]Certificate, error) {
+	certificates, err := ListAll(func(after string) (*ListResponse[Certificate], error) {
+		return c.ListOrganizationCertificates(100, after, "")
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list certificates: %w", err)
+	}
+	return certificates, nil
+}
@@ -0,0 +1,175 @@
+package openaiorgs
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func boolPtr(b bool) *bool { return &b }
+
+func mockCertificate(id, name string, active bool, expiresAt time.Time) Certificate {
+	return Certificate{
+		ID:                 id,
+		Name:               name,
+		Active:             boolPtr(active),
+		CertificateDetails: CertificateDetails{ExpiresAt: UnixSeconds(expiresAt)},
+	}
+}
+
+func TestExpiringCertificates(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	h.mockResponse("GET", OrganizationCertificatesEndpoint, 200, ListResponse[Certificate]{Object: "list", Data: []Certificate{
+		mockCertificate("cert_later", "later", true, now.AddDate(0, 3, 0)),
+		mockCertificate("cert_soon", "soon", true, now.AddDate(0, 0, 20)),
+		mockCertificate("cert_expired", "expired", false, now.AddDate(0, 0, -1)),
+	}})
+
+	certificates, err := ExpiringCertificates(h.client, 30*24*time.Hour, now)
+	if err != nil {
+		t.Fatalf("ExpiringCertificates() error = %v", err)
+	}
+	if len(certificates) != 2 || certificates[0].ID != "cert_expired" || certificates[1].ID != "cert_soon" {
+		t.Errorf("unexpected certificates: %+v", certificates)
+	}
+}
+
+// mockCertificateRotation sets up an organization with the old certificate active at the
+// organization level and in proj_a but not proj_b.
+func mockCertificateRotation(h *testHelper) {
+	expires := time.Now().AddDate(0, 0, 5)
+	h.mockResponse("GET", OrganizationCertificatesEndpoint, 200, ListResponse[Certificate]{Object: "list", Data: []Certificate{
+		mockCertificate("cert_old", "corp-ca", true, expires),
+	}})
+	h.mockResponse("GET", "/organization/projects", 200, ListResponse[Project]{Object: "list", Data: []Project{{ID: "proj_a"}, {ID: "proj_b"}}})
+	h.mockResponse("GET", "/organization/projects/proj_a/certificates", 200, ListResponse[Certificate]{Object: "list", Data: []Certificate{
+		mockCertificate("cert_old", "corp-ca", true, expires),
+	}})
+	h.mockResponse("GET", "/organization/projects/proj_b/certificates", 200, ListResponse[Certificate]{Object: "list", Data: []Certificate{
+		mockCertificate("cert_old", "corp-ca", false, expires),
+	}})
+	h.mockResponse("POST", OrganizationCertificatesEndpoint, 200, Certificate{ID: "cert_new", Name: "corp-ca 2024"})
+	success := CertificateActivationResponse{Success: true}
+	h.mockResponse("POST", OrganizationCertificateActivateEndpoint, 200, success)
+	h.mockResponse("POST", OrganizationCertificateDeactivateEndpoint, 200, success)
+	h.mockResponse("POST", fmt.Sprintf(ProjectCertificateActivateEndpoint, "proj_a"), 200, success)
+	h.mockResponse("POST", fmt.Sprintf(ProjectCertificateDeactivateEndpoint, "proj_a"), 200, success)
+	h.mockResponse("DELETE", OrganizationCertificatesEndpoint+"/cert_new", 200, CertificateDeletedResponse{ID: "cert_new", Deleted: true})
+}
+
+func TestRotateCertificate(t *testing.T) {
+	now := time.Now()
+	content := testCertificatePEM(t, true, now.Add(-time.Hour), now.AddDate(1, 0, 0))
+
+	t.Run("dry run", func(t *testing.T) {
+		h := newTestHelper(t)
+		defer h.cleanup()
+		mockCertificateRotation(h)
+
+		rotation, err := RotateCertificate(h.client, "cert_old", content, CertificateRotationOptions{DryRun: true, Name: "corp-ca 2024"})
+		if err != nil {
+			t.Fatalf("RotateCertificate() error = %v", err)
+		}
+		if rotation.Status != CertificateRotationPlanned || len(rotation.Steps) != 5 || strings.Join(rotation.Projects, ",") != "proj_a" {
+			t.Errorf("unexpected plan: %+v", rotation)
+		}
+		h.assertRequest("POST", OrganizationCertificatesEndpoint, 0)
+	})
+
+	t.Run("completed", func(t *testing.T) {
+		h := newTestHelper(t)
+		defer h.cleanup()
+		mockCertificateRotation(h)
+
+		rotation, err := RotateCertificate(h.client, "cert_old", content, CertificateRotationOptions{})
+		if err != nil {
+			t.Fatalf("RotateCertificate() error = %v", err)
+		}
+		if rotation.Status != CertificateRotationCompleted || rotation.NewCertificateID != "cert_new" || !strings.HasPrefix(rotation.Name, "corp-ca ") {
+			t.Errorf("unexpected rotation: %+v", rotation)
+		}
+		var actions []string
+		for _, step := range rotation.Steps {
+			actions = append(actions, step.Action+":"+step.CertificateID)
+		}
+		want := "upload:cert_new activate_org:cert_new activate_project:cert_new deactivate_project:cert_old deactivate_org:cert_old"
+		if got := strings.Join(actions, " "); got != want {
+			t.Errorf("steps = %s, want %s", got, want)
+		}
+		h.assertRequest("POST", fmt.Sprintf(ProjectCertificateActivateEndpoint, "proj_b"), 0)
+		h.assertRequest("DELETE", OrganizationCertificatesEndpoint+"/cert_new", 0)
+	})
+
+	t.Run("rolled back", func(t *testing.T) {
+		h := newTestHelper(t)
+		defer h.cleanup()
+		mockCertificateRotation(h)
+		// Deactivating the old certificate fails; deactivating the new one (rollback) works.
+		httpmock.RegisterResponder("POST", testBaseURL+fmt.Sprintf(ProjectCertificateDeactivateEndpoint, "proj_a"), func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			if strings.Contains(string(body), "cert_old") {
+				return httpmock.NewJsonResponse(500, map[string]string{"error": "boom"})
+			}
+			return httpmock.NewJsonResponse(200, CertificateActivationResponse{Success: true})
+		})
+
+		rotation, err := RotateCertificate(h.client, "cert_old", content, CertificateRotationOptions{})
+		if err == nil || !strings.Contains(err.Error(), "deactivate_project cert_old in project proj_a failed") {
+			t.Fatalf("expected step error, got %v", err)
+		}
+		if rotation.Status != CertificateRotationRolledBack {
+			t.Errorf("unexpected status: %+v", rotation)
+		}
+		var rollback []string
+		for _, step := range rotation.Steps {
+			if step.Rollback {
+				rollback = append(rollback, step.Action+":"+step.CertificateID+":"+step.Status)
+			}
+		}
+		want := "deactivate_project:cert_new:done deactivate_org:cert_new:done delete:cert_new:done"
+		if got := strings.Join(rollback, " "); got != want {
+			t.Errorf("rollback = %s, want %s", got, want)
+		}
+		h.assertRequest("DELETE", OrganizationCertificatesEndpoint+"/cert_new", 1)
+		h.assertRequest("POST", OrganizationCertificateDeactivateEndpoint, 1)
+	})
+
+	t.Run("leaf certificate", func(t *testing.T) {
+		h := newTestHelper(t)
+		defer h.cleanup()
+		mockCertificateRotation(h)
+		leaf := testCertificatePEM(t, false, now.Add(-time.Hour), now.AddDate(1, 0, 0))
+
+		if _, err := RotateCertificate(h.client, "cert_old", leaf, CertificateRotationOptions{}); err == nil || !strings.Contains(err.Error(), "invalid certificate") {
+			t.Fatalf("expected leaf certificate to be rejected, got %v", err)
+		}
+		h.assertRequest("POST", OrganizationCertificatesEndpoint, 0)
+
+		rotation, err := RotateCertificate(h.client, "cert_old", leaf, CertificateRotationOptions{AllowLeaf: true})
+		if err != nil {
+			t.Fatalf("RotateCertificate() error = %v", err)
+		}
+		if rotation.Status != CertificateRotationCompleted || rotation.NewCertificateID != "cert_new" {
+			t.Errorf("unexpected rotation: %+v", rotation)
+		}
+		h.assertRequest("POST", OrganizationCertificatesEndpoint, 1)
+	})
+
+	t.Run("unknown certificate", func(t *testing.T) {
+		h := newTestHelper(t)
+		defer h.cleanup()
+		mockCertificateRotation(h)
+
+		if _, err := RotateCertificate(h.client, "cert_missing", content, CertificateRotationOptions{}); err == nil {
+			t.Error("expected error for unknown certificate")
+		}
+	})
+}
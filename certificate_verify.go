@@ -0,0 +1,96 @@
+package openaiorgs
+
+import (
+	"crypto/x509"
+	"fmt"
+	"time"
+)
+
+// ClientCertificateVerification is the result of VerifyClientCertificate.
+type ClientCertificateVerification struct {
+	Client CertificateInfo `json:"client"`
+	// Trusted is true when the client certificate chains to an active organization certificate.
+	Trusted bool `json:"trusted"`
+	// CertificateID and CertificateName identify the organization certificate the
+	// client chains to.
+	CertificateID   string `json:"certificate_id,omitempty"`
+	CertificateName string `json:"certificate_name,omitempty"`
+	// Checked is the number of active organization certificates that were tried.
+	Checked int `json:"checked"`
+	// Reason explains why the certificate is not trusted.
+	Reason string `json:"reason,omitempty"`
+}
+
+// VerifyClientCertificate checks that the PEM-encoded client certificate in content
+// chains to one of the organization's uploaded, active certificates and is valid for
+// client authentication at now (defaults to time.Now). Any further certificates in
+// content are used as intermediates.
+//
+// The content of each active certificate is fetched with GetCertificate. An
+// untrusted client certificate is not an error; Trusted and Reason describe it.
+func VerifyClientCertificate(c OpenAIOrgsClient, content string, now time.Time) (*ClientCertificateVerification, error) {
+	now = lifecycleNow(now)
+	certs, err := ParseCertificatePEM(content)
+	if err != nil {
+		return nil, fmt.Errorf("invalid client certificate: %w", err)
+	}
+	result := &ClientCertificateVerification{Client: NewCertificateInfo(certs[0])}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	certificates, err := listAllOrganizationCertificates(c)
+	if err != nil {
+		return nil, err
+	}
+
+	var lastErr error
+	for _, summary := range certificates {
+		if summary.Active == nil || !*summary.Active {
+			continue
+		}
+		result.Checked++
+
+		cert, err := c.GetCertificate(summary.ID, true)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get certificate %s: %w", summary.ID, err)
+		}
+		if cert.CertificateDetails.Content == nil {
+			continue
+		}
+		roots, err := ParseCertificatePEM(*cert.CertificateDetails.Content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode certificate %s: %w", summary.ID, err)
+		}
+		pool := x509.NewCertPool()
+		for _, root := range roots {
+			pool.AddCert(root)
+		}
+
+		_, err = certs[0].Verify(x509.VerifyOptions{
+			Roots:         pool,
+			Intermediates: intermediates,
+			CurrentTime:   now,
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		})
+		if err == nil {
+			result.Trusted = true
+			result.CertificateID = summary.ID
+			result.CertificateName = summary.Name
+			return result, nil
+		}
+		lastErr = err
+	}
+
+	switch {
+	case result.Checked == 0:
+		result.Reason = "the organization has no active certificates"
+	case lastErr != nil:
+		result.Reason = lastErr.Error()
+	default:
+		result.Reason = "no active certificate content could be retrieved"
+	}
+	return result, nil
+}
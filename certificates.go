@@ -35,6 +35,11 @@ func (c *Client) ListOrganizationCertificates(limit int, after string, order str
 // The certificate content must be provided in PEM format.
 // New certificates are created in an inactive state and must be explicitly activated.
 //
+// The content is validated locally with ValidateCertificatePEM before it is sent, so
+// malformed input, private keys, certificates outside their validity period and
+// non-CA certificates are rejected without an API call. Use UploadCertificateWithOptions
+// to upload a leaf certificate.
+//
 // Parameters:
 //   - content: The PEM-encoded certificate content
 //   - name: The human-readable name for the certificate (must be unique)
@@ -42,6 +47,14 @@ func (c *Client) ListOrganizationCertificates(limit int, after string, order str
 // Returns the created Certificate object or an error if upload fails.
 // Common errors include invalid certificate format or duplicate names.
 func (c *Client) UploadCertificate(content string, name string) (*Certificate, error) {
+	return c.UploadCertificateWithOptions(content, name, CertificateValidationOptions{})
+}
+
+// UploadCertificateWithOptions is UploadCertificate with control over the local validation.
+func (c *Client) UploadCertificateWithOptions(content string, name string, opts CertificateValidationOptions) (*Certificate, error) {
+	if _, err := ValidateCertificatePEM(content, opts); err != nil {
+		return nil, fmt.Errorf("invalid certificate: %w", err)
+	}
 	body := map[string]string{
 		"content": content,
 		"name":    name,
@@ -79,7 +79,8 @@ func TestUploadCertificate(t *testing.T) {
 	h.mockResponse("POST", OrganizationCertificatesEndpoint, 200, mockCertificate)
 
 	// Make the API call
-	certificate, err := h.client.UploadCertificate("-----BEGIN CERTIFICATE-----\ntest\n-----END CERTIFICATE-----", "Test Certificate")
+	content := testCertificatePEM(t, true, now.Add(-time.Hour), now.Add(365*24*time.Hour))
+	certificate, err := h.client.UploadCertificate(content, "Test Certificate")
 	// Assert results
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
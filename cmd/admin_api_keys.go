@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	openaiorgs "github.com/klauern/openai-orgs"
 	"github.com/urfave/cli/v3"
 )
 
@@ -37,14 +38,14 @@ func createAdminAPIKeyCommand() *cli.Command {
 	return &cli.Command{
 		Name:  "create",
 		Usage: "Create a new organization API key",
-		Flags: []cli.Flag{
+		Flags: append([]cli.Flag{
 			nameFlag,
 			&cli.StringSliceFlag{
 				Name:     "scopes",
 				Usage:    "API key scopes (comma-separated)",
 				Required: true,
 			},
-		},
+		}, secretSinkFlags()...),
 		Action: createAdminAPIKey,
 	}
 }
@@ -104,6 +105,11 @@ func listAdminAPIKeys(ctx context.Context, cmd *cli.Command) error {
 }
 
 func createAdminAPIKey(ctx context.Context, cmd *cli.Command) error {
+	sink, err := secretSinkFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
 	client := newClient(ctx, cmd)
 
 	name := cmd.String("name")
@@ -124,6 +130,16 @@ func createAdminAPIKey(ctx context.Context, cmd *cli.Command) error {
 	)
 	fmt.Printf("Scopes: %s\n", strings.Join(apiKey.Scopes, ", "))
 
+	if sink != nil {
+		if apiKey.Value == "" {
+			return fmt.Errorf("API key %s was created without a value", apiKey.ID)
+		}
+		return storeSecret(sink, openaiorgs.Secret{
+			Name:  apiKey.Name,
+			ID:    apiKey.ID,
+			Value: apiKey.Value,
+		})
+	}
 	return nil
 }
 
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -151,6 +153,41 @@ func TestCreateAdminAPIKeyCommand(t *testing.T) {
 	}
 }
 
+func TestCreateAdminAPIKeyCommand_SecretSink(t *testing.T) {
+	h := newCmdTestHelper(t)
+	defer h.cleanup()
+
+	apiKey := createMockAdminAPIKey("key_123", "CI Key", "sk-admin...wxyz", []string{"api.read"})
+	apiKey.Value = "sk-admin-0123456789wxyz"
+	h.mockResponse("POST", "/organization/admin_api_keys", 200, apiKey)
+
+	envFile := filepath.Join(t.TempDir(), ".env")
+	var err error
+	output := captureOutput(func() {
+		err = h.runCmd(AdminAPIKeysCommand(), []string{
+			"admin-api-keys", "create", "--name", "CI Key", "--scopes", "api.read",
+			"--secret-sink", "dotenv", "--secret-path", envFile, "--secret-key", "OPENAI_ADMIN_KEY",
+		})
+	})
+	if err != nil {
+		t.Fatalf("runCmd() error = %v", err)
+	}
+
+	if want := "stored in " + envFile; !strings.Contains(output, want) {
+		t.Errorf("Expected output to contain %q, got: %s", want, output)
+	}
+	if strings.Contains(output, "sk-admin-0123456789wxyz") {
+		t.Errorf("API key must not be printed, got: %s", output)
+	}
+	data, err := os.ReadFile(envFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "OPENAI_ADMIN_KEY=\"sk-admin-0123456789wxyz\"\n" {
+		t.Errorf("unexpected dotenv contents %q", data)
+	}
+}
+
 func TestRetrieveAdminAPIKeyCommand(t *testing.T) {
 	tests := []struct {
 		name         string
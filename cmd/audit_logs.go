@@ -2,7 +2,11 @@ package cmd
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -31,9 +35,13 @@ func AuditLogsCommand() *cli.Command {
 			&cli.StringFlag{
 				Name:    "output",
 				Aliases: []string{"o"},
-				Usage:   "Output format (pretty, json, jsonl)",
+				Usage:   "Output format (pretty, json, jsonl, csv)",
 				Value:   "pretty",
 			},
+			&cli.BoolFlag{
+				Name:  "resolve",
+				Usage: "Look up names for the users, projects, service accounts and API keys referenced by each event",
+			},
 			&cli.BoolFlag{
 				Name:    "verbose",
 				Aliases: []string{"v"},
@@ -45,6 +53,44 @@ func AuditLogsCommand() *cli.Command {
 			},
 		},
 		Action: listAuditLogs,
+		Commands: []*cli.Command{
+			auditLogTimelineCommand(),
+			auditLogExportCommand(),
+			auditLogVerifyCommand(),
+			auditLogKeygenCommand(),
+		},
+	}
+}
+
+func auditLogTimelineCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "timeline",
+		Usage: "Show the change history of a resource or actor",
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:  "resource-id",
+				Usage: "ID of the resource to reconstruct (can be repeated)",
+			},
+			&cli.StringSliceFlag{
+				Name:  "actor-email",
+				Usage: "Only include events performed by this email (can be repeated)",
+			},
+			&cli.StringFlag{
+				Name:  "start-date",
+				Usage: "Start date for the query (RFC3339 format)",
+			},
+			&cli.StringFlag{
+				Name:  "end-date",
+				Usage: "End date for the query (RFC3339 format)",
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "Output format (pretty, json)",
+				Value:   "pretty",
+			},
+		},
+		Action: auditLogTimeline,
 	}
 }
 
@@ -62,28 +108,13 @@ func listAuditLogs(ctx context.Context, cmd *cli.Command) error {
 	outputFormat := cmd.String("output")
 	verbose := cmd.Bool("verbose")
 	paginate := cmd.Bool("paginate")
+	resolve := cmd.Bool("resolve")
 
-	if startDate != "" {
-		t, err := time.Parse(time.RFC3339, startDate)
-		if err != nil {
-			return fmt.Errorf("invalid start-date format: %w", err)
-		}
-		if params.EffectiveAt == nil {
-			params.EffectiveAt = &openaiorgs.EffectiveAt{}
-		}
-		params.EffectiveAt.Gte = t.Unix()
-	}
-
-	if endDate != "" {
-		t, err := time.Parse(time.RFC3339, endDate)
-		if err != nil {
-			return fmt.Errorf("invalid end-date format: %w", err)
-		}
-		if params.EffectiveAt == nil {
-			params.EffectiveAt = &openaiorgs.EffectiveAt{}
-		}
-		params.EffectiveAt.Lte = t.Unix()
+	effectiveAt, err := parseEffectiveAt(startDate, endDate)
+	if err != nil {
+		return err
 	}
+	params.EffectiveAt = effectiveAt
 
 	var allLogs []openaiorgs.AuditLog
 	for {
@@ -99,7 +130,7 @@ func listAuditLogs(ctx context.Context, cmd *cli.Command) error {
 			}
 			params.After = logs.LastID
 		} else {
-			return outputResponse(logs, outputFormat, verbose)
+			return outputResponse(logs, outputFormat, verbose, resolveAuditLogs(client, resolve, logs.Data))
 		}
 	}
 
@@ -108,32 +139,391 @@ func listAuditLogs(ctx context.Context, cmd *cli.Command) error {
 			Object: "list",
 			Data:   allLogs,
 		}
-		return outputResponse(response, outputFormat, verbose)
+		return outputResponse(response, outputFormat, verbose, resolveAuditLogs(client, resolve, allLogs))
+	}
+
+	return nil
+}
+
+// resolveAuditLogs looks up names for every log when enabled, keyed by log ID.
+// It returns nil when resolution is disabled.
+func resolveAuditLogs(client openaiorgs.OpenAIOrgsClient, enabled bool, logs []openaiorgs.AuditLog) map[string]openaiorgs.ResolvedNames {
+	if !enabled {
+		return nil
+	}
+	resolver := openaiorgs.NewAuditLogResolver(client)
+	resolved := make(map[string]openaiorgs.ResolvedNames, len(logs))
+	for i := range logs {
+		resolved[logs[i].ID] = resolver.Resolve(&logs[i])
+	}
+	return resolved
+}
+
+func auditLogExportCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "export",
+		Usage: "Export audit logs as a hash-chained file with a signed manifest",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "file",
+				Aliases:  []string{"f"},
+				Usage:    "Path of the export file to write",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "private-key",
+				Usage:    "Path to the PEM-encoded ed25519 signing key",
+				Required: true,
+			},
+			&cli.StringSliceFlag{
+				Name:  "resource-id",
+				Usage: "Only export events for this resource (can be repeated)",
+			},
+			&cli.StringSliceFlag{
+				Name:  "actor-email",
+				Usage: "Only export events performed by this email (can be repeated)",
+			},
+			&cli.StringFlag{
+				Name:  "start-date",
+				Usage: "Start date for the query (RFC3339 format)",
+			},
+			&cli.StringFlag{
+				Name:  "end-date",
+				Usage: "End date for the query (RFC3339 format)",
+			},
+		},
+		Action: exportAuditLogs,
+	}
+}
+
+func auditLogVerifyCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "verify",
+		Usage: "Verify the hash chain and signature of an audit log export",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "file",
+				Aliases:  []string{"f"},
+				Usage:    "Path of the export file to verify",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "public-key",
+				Usage:    "Path to the PEM-encoded ed25519 public key",
+				Required: true,
+			},
+		},
+		Action: verifyAuditLogExport,
+	}
+}
+
+func auditLogKeygenCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "keygen",
+		Usage: "Generate an ed25519 key pair for signing audit log exports",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "private-key",
+				Usage:    "Path to write the PEM-encoded private key",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "public-key",
+				Usage:    "Path to write the PEM-encoded public key",
+				Required: true,
+			},
+			&cli.BoolFlag{
+				Name:  "force",
+				Usage: "Overwrite existing key files",
+			},
+		},
+		Action: generateAuditSigningKey,
+	}
+}
+
+// parseEffectiveAt converts the RFC3339 start-date and end-date flags into an
+// effective_at filter. It returns nil when neither date is set.
+func parseEffectiveAt(startDate, endDate string) (*openaiorgs.EffectiveAt, error) {
+	if startDate == "" && endDate == "" {
+		return nil, nil
+	}
+
+	effectiveAt := &openaiorgs.EffectiveAt{}
+	if startDate != "" {
+		t, err := time.Parse(time.RFC3339, startDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start-date format: %w", err)
+		}
+		effectiveAt.Gte = t.Unix()
+	}
+	if endDate != "" {
+		t, err := time.Parse(time.RFC3339, endDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid end-date format: %w", err)
+		}
+		effectiveAt.Lte = t.Unix()
+	}
+	return effectiveAt, nil
+}
+
+func auditLogTimeline(ctx context.Context, cmd *cli.Command) error {
+	resourceIDs := cmd.StringSlice("resource-id")
+	actorEmails := cmd.StringSlice("actor-email")
+	if len(resourceIDs) == 0 && len(actorEmails) == 0 {
+		return fmt.Errorf("at least one of --resource-id or --actor-email is required")
+	}
+
+	outputFormat := cmd.String("output")
+	if outputFormat != "pretty" && outputFormat != "json" {
+		return fmt.Errorf("unknown output format: %s", outputFormat)
+	}
+
+	effectiveAt, err := parseEffectiveAt(cmd.String("start-date"), cmd.String("end-date"))
+	if err != nil {
+		return err
+	}
+
+	client := newClient(ctx, cmd)
+	logs, err := openaiorgs.ListAllAuditLogs(client, openaiorgs.AuditLogListParams{
+		EffectiveAt: effectiveAt,
+		ResourceIDs: resourceIDs,
+		ActorEmails: actorEmails,
+		Limit:       100,
+	})
+	if err != nil {
+		return wrapError("list audit logs", err)
+	}
+
+	timeline := openaiorgs.BuildTimeline(logs)
+
+	if outputFormat == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(timeline)
+	}
+
+	if len(timeline) == 0 {
+		fmt.Println("No matching audit log events found.")
+		return nil
+	}
+
+	data := TableData{
+		Headers: []string{"Time", "Actor", "Event", "Change"},
+		Rows:    make([][]string, len(timeline)),
+	}
+	for i, entry := range timeline {
+		data.Rows[i] = []string{
+			entry.Time.UTC().Format(time.RFC3339),
+			entry.Actor,
+			entry.Type,
+			entry.Description,
+		}
+	}
+	printTableData(data)
+	return nil
+}
+
+func exportAuditLogs(ctx context.Context, cmd *cli.Command) error {
+	keyPEM, err := os.ReadFile(cmd.String("private-key"))
+	if err != nil {
+		return fmt.Errorf("failed to read private key: %w", err)
+	}
+	key, err := openaiorgs.ParseEd25519PrivateKeyPEM(keyPEM)
+	if err != nil {
+		return err
+	}
+
+	effectiveAt, err := parseEffectiveAt(cmd.String("start-date"), cmd.String("end-date"))
+	if err != nil {
+		return err
+	}
+
+	client := newClient(ctx, cmd)
+	logs, err := openaiorgs.ListAllAuditLogs(client, openaiorgs.AuditLogListParams{
+		EffectiveAt: effectiveAt,
+		ResourceIDs: cmd.StringSlice("resource-id"),
+		ActorEmails: cmd.StringSlice("actor-email"),
+		Limit:       100,
+	})
+	if err != nil {
+		return wrapError("list audit logs", err)
+	}
+
+	path := cmd.String("file")
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create export file: %w", err)
+	}
+	defer file.Close()
+
+	manifest, err := openaiorgs.ExportAuditLogs(file, key, logs)
+	if err != nil {
+		return wrapError("export audit logs", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write export file: %w", err)
+	}
+
+	fmt.Printf("Exported %d audit logs to %s\n", manifest.Count, path)
+	fmt.Printf("Final hash: %s\n", manifest.FinalHash)
+	return nil
+}
+
+func verifyAuditLogExport(ctx context.Context, cmd *cli.Command) error {
+	keyPEM, err := os.ReadFile(cmd.String("public-key"))
+	if err != nil {
+		return fmt.Errorf("failed to read public key: %w", err)
+	}
+	key, err := openaiorgs.ParseEd25519PublicKeyPEM(keyPEM)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(cmd.String("file"))
+	if err != nil {
+		return fmt.Errorf("failed to open export file: %w", err)
+	}
+	defer file.Close()
+
+	manifest, err := openaiorgs.VerifyAuditExport(file, key)
+	if err != nil {
+		return fmt.Errorf("verification failed: %w", err)
+	}
+
+	fmt.Printf("Export verified: %d records, signed %s\n", manifest.Count, manifest.CreatedAt.Format(time.RFC3339))
+	if manifest.Count > 0 {
+		fmt.Printf("Records:    %s .. %s\n", manifest.FirstID, manifest.LastID)
+	}
+	fmt.Printf("Final hash: %s\n", manifest.FinalHash)
+	return nil
+}
+
+func generateAuditSigningKey(ctx context.Context, cmd *cli.Command) error {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return fmt.Errorf("failed to generate key: %w", err)
+	}
+	privPEM, err := openaiorgs.MarshalEd25519PrivateKeyPEM(priv)
+	if err != nil {
+		return fmt.Errorf("failed to encode private key: %w", err)
+	}
+	pubPEM, err := openaiorgs.MarshalEd25519PublicKeyPEM(pub)
+	if err != nil {
+		return fmt.Errorf("failed to encode public key: %w", err)
+	}
+
+	privPath, pubPath := cmd.String("private-key"), cmd.String("public-key")
+	force := cmd.Bool("force")
+	if !force {
+		// Check both paths up front so an existing public key doesn't leave
+		// a freshly written, unpaired private key behind.
+		for _, path := range []string{privPath, pubPath} {
+			if _, err := os.Stat(path); err == nil {
+				return fmt.Errorf("%s already exists (use --force to overwrite)", path)
+			} else if !errors.Is(err, os.ErrNotExist) {
+				return fmt.Errorf("failed to check %s: %w", path, err)
+			}
+		}
+	}
+	if err := writeKeyFile(privPath, privPEM, 0o600, force); err != nil {
+		return fmt.Errorf("failed to write private key: %w", err)
+	}
+	if err := writeKeyFile(pubPath, pubPEM, 0o644, force); err != nil {
+		return fmt.Errorf("failed to write public key: %w", err)
 	}
 
+	fmt.Printf("Wrote private key to %s and public key to %s\n", privPath, pubPath)
 	return nil
 }
 
-func outputResponse(response *openaiorgs.ListResponse[openaiorgs.AuditLog], outputFormat string, verbose bool) error {
+// writeKeyFile writes data to path, refusing to replace an existing file
+// unless overwrite is set.
+func writeKeyFile(path string, data []byte, perm os.FileMode, overwrite bool) error {
+	flag := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if overwrite {
+		flag = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+	f, err := os.OpenFile(path, flag, perm)
+	if err != nil {
+		return err
+	}
+	// OpenFile only applies perm to new files; tighten replaced ones too.
+	if err := f.Chmod(perm); err != nil {
+		f.Close()
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func outputResponse(response *openaiorgs.ListResponse[openaiorgs.AuditLog], outputFormat string, verbose bool, resolved map[string]openaiorgs.ResolvedNames) error {
 	switch outputFormat {
 	case "json":
-		return outputJSON(response, verbose)
+		return outputJSON(response, verbose, resolved)
 	case "jsonl":
-		return outputJSONL(response, verbose)
+		return outputJSONL(response, verbose, resolved)
+	case "csv":
+		return outputCSV(response, resolved)
 	case "pretty":
-		return outputPretty(response, verbose)
+		return outputPretty(response, verbose, resolved)
 	default:
 		return fmt.Errorf("unknown output format: %s", outputFormat)
 	}
 }
 
-func outputJSON(response *openaiorgs.ListResponse[openaiorgs.AuditLog], verbose bool) error {
+func outputJSON(response *openaiorgs.ListResponse[openaiorgs.AuditLog], verbose bool, resolved map[string]openaiorgs.ResolvedNames) error {
 	encoder := json.NewEncoder(os.Stdout)
 	encoder.SetIndent("", "  ")
-	return encoder.Encode(response)
+	if resolved == nil {
+		return encoder.Encode(response)
+	}
+
+	// Re-encode the list with each entry carrying its resolved names.
+	raw, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return err
+	}
+	data := make([]json.RawMessage, len(response.Data))
+	for i := range response.Data {
+		if data[i], err = annotateAuditLog(&response.Data[i], resolved); err != nil {
+			return err
+		}
+	}
+	if fields["data"], err = json.Marshal(data); err != nil {
+		return err
+	}
+	return encoder.Encode(fields)
+}
+
+// annotateAuditLog encodes log with its resolved names added under a "resolved" key.
+func annotateAuditLog(log *openaiorgs.AuditLog, resolved map[string]openaiorgs.ResolvedNames) (json.RawMessage, error) {
+	raw, err := json.Marshal(log)
+	if err != nil {
+		return nil, err
+	}
+	names, ok := resolved[log.ID]
+	if !ok {
+		return raw, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	if fields["resolved"], err = json.Marshal(names); err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
 }
 
-func outputJSONL(response *openaiorgs.ListResponse[openaiorgs.AuditLog], verbose bool) error {
+func outputJSONL(response *openaiorgs.ListResponse[openaiorgs.AuditLog], verbose bool, resolved map[string]openaiorgs.ResolvedNames) error {
 	encoder := json.NewEncoder(os.Stdout)
 	// First line: metadata if verbose
 	if verbose {
@@ -154,15 +544,61 @@ func outputJSONL(response *openaiorgs.ListResponse[openaiorgs.AuditLog], verbose
 	}
 
 	// Output each log entry on its own line
-	for _, log := range response.Data {
-		if err := encoder.Encode(log); err != nil {
+	for i := range response.Data {
+		if resolved == nil {
+			if err := encoder.Encode(response.Data[i]); err != nil {
+				return err
+			}
+			continue
+		}
+		line, err := annotateAuditLog(&response.Data[i], resolved)
+		if err != nil {
+			return err
+		}
+		if err := encoder.Encode(line); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func outputPretty(response *openaiorgs.ListResponse[openaiorgs.AuditLog], verbose bool) error {
+func outputCSV(response *openaiorgs.ListResponse[openaiorgs.AuditLog], resolved map[string]openaiorgs.ResolvedNames) error {
+	w := csv.NewWriter(os.Stdout)
+	header := []string{"id", "type", "effective_at", "actor", "project_id", "resource_id"}
+	if resolved != nil {
+		header = append(header, "actor_name", "api_key_name", "service_account_name", "project_name", "resource_name")
+	}
+	if err := w.Write(header); err != nil {
+		return err
+	}
+
+	for i := range response.Data {
+		log := &response.Data[i]
+		projectID := ""
+		if log.Project != nil {
+			projectID = log.Project.ID
+		}
+		row := []string{
+			log.ID,
+			log.Type,
+			log.EffectiveAt.Time().UTC().Format(time.RFC3339),
+			log.ActorLabel(),
+			projectID,
+			log.ResourceID(),
+		}
+		if resolved != nil {
+			names := resolved[log.ID]
+			row = append(row, names.Actor, names.APIKey, names.ServiceAccount, names.Project, names.Resource)
+		}
+		if err := w.Write(row); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func outputPretty(response *openaiorgs.ListResponse[openaiorgs.AuditLog], verbose bool, resolved map[string]openaiorgs.ResolvedNames) error {
 	if verbose {
 		fmt.Printf("=== Audit Log Summary ===\n")
 		fmt.Printf("Total logs: %d\nHas more: %v\nFirst ID: %s\nLast ID: %s\n\n",
@@ -174,6 +610,9 @@ func outputPretty(response *openaiorgs.ListResponse[openaiorgs.AuditLog], verbos
 		fmt.Printf("ID:        %s\n", log.ID)
 		fmt.Printf("Type:      %s\n", log.Type)
 		fmt.Printf("Effective: %s\n", log.EffectiveAt.Time().Format(time.RFC3339))
+		if names, ok := resolved[log.ID]; ok {
+			printResolvedNames(names)
+		}
 
 		if verbose {
 			fmt.Printf("\nActor Details:\n")
@@ -235,23 +674,23 @@ func outputPretty(response *openaiorgs.ListResponse[openaiorgs.AuditLog], verbos
 			case *openaiorgs.RateLimitUpdated:
 				fmt.Printf("  Rate limit updated with ID: %s\n", details.ID)
 				changes := details.ChangesRequested
-				if changes.MaxRequestsPer1Minute > 0 {
-					fmt.Printf("  Max requests per minute: %d\n", changes.MaxRequestsPer1Minute)
+				if changes.MaxRequestsPer1Minute != nil {
+					fmt.Printf("  Max requests per minute: %d\n", *changes.MaxRequestsPer1Minute)
 				}
-				if changes.MaxTokensPer1Minute > 0 {
-					fmt.Printf("  Max tokens per minute: %d\n", changes.MaxTokensPer1Minute)
+				if changes.MaxTokensPer1Minute != nil {
+					fmt.Printf("  Max tokens per minute: %d\n", *changes.MaxTokensPer1Minute)
 				}
-				if changes.MaxImagesPer1Minute > 0 {
-					fmt.Printf("  Max images per minute: %d\n", changes.MaxImagesPer1Minute)
+				if changes.MaxImagesPer1Minute != nil {
+					fmt.Printf("  Max images per minute: %d\n", *changes.MaxImagesPer1Minute)
 				}
-				if changes.MaxAudioMegabytesPer1Minute > 0 {
-					fmt.Printf("  Max audio MB per minute: %d\n", changes.MaxAudioMegabytesPer1Minute)
+				if changes.MaxAudioMegabytesPer1Minute != nil {
+					fmt.Printf("  Max audio MB per minute: %d\n", *changes.MaxAudioMegabytesPer1Minute)
 				}
-				if changes.MaxRequestsPer1Day > 0 {
-					fmt.Printf("  Max requests per day: %d\n", changes.MaxRequestsPer1Day)
+				if changes.MaxRequestsPer1Day != nil {
+					fmt.Printf("  Max requests per day: %d\n", *changes.MaxRequestsPer1Day)
 				}
-				if changes.Batch1DayMaxInputTokens > 0 {
-					fmt.Printf("  Batch max input tokens per day: %d\n", changes.Batch1DayMaxInputTokens)
+				if changes.Batch1DayMaxInputTokens != nil {
+					fmt.Printf("  Batch max input tokens per day: %d\n", *changes.Batch1DayMaxInputTokens)
 				}
 			case *openaiorgs.RateLimitDeleted:
 				fmt.Printf("  Rate limit deleted with ID: %s\n", details.ID)
@@ -280,3 +719,21 @@ func outputPretty(response *openaiorgs.ListResponse[openaiorgs.AuditLog], verbos
 
 	return nil
 }
+
+func printResolvedNames(names openaiorgs.ResolvedNames) {
+	if names.Actor != "" {
+		fmt.Printf("Actor:     %s\n", names.Actor)
+	}
+	if names.APIKey != "" {
+		fmt.Printf("API Key:   %s\n", names.APIKey)
+	}
+	if names.ServiceAccount != "" {
+		fmt.Printf("Service Account: %s\n", names.ServiceAccount)
+	}
+	if names.Project != "" {
+		fmt.Printf("Project:   %s\n", names.Project)
+	}
+	if names.Resource != "" {
+		fmt.Printf("Resource:  %s\n", names.Resource)
+	}
+}
@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"net/http"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -47,7 +49,7 @@ func TestOutputJSON(t *testing.T) {
 	response := createTestResponse(log1)
 
 	output := captureOutput(func() {
-		err := outputJSON(response, false)
+		err := outputJSON(response, false, nil)
 		if err != nil {
 			t.Errorf("outputJSON() error = %v", err)
 		}
@@ -74,7 +76,7 @@ func TestOutputJSONL(t *testing.T) {
 
 	t.Run("non-verbose", func(t *testing.T) {
 		output := captureOutput(func() {
-			err := outputJSONL(response, false)
+			err := outputJSONL(response, false, nil)
 			if err != nil {
 				t.Errorf("outputJSONL() error = %v", err)
 			}
@@ -94,7 +96,7 @@ func TestOutputJSONL(t *testing.T) {
 
 	t.Run("verbose", func(t *testing.T) {
 		output := captureOutput(func() {
-			err := outputJSONL(response, true)
+			err := outputJSONL(response, true, nil)
 			if err != nil {
 				t.Errorf("outputJSONL() error = %v", err)
 			}
@@ -124,7 +126,7 @@ func TestOutputPretty(t *testing.T) {
 	response := createTestResponse(log1)
 
 	output := captureOutput(func() {
-		err := outputPretty(response, false)
+		err := outputPretty(response, false, nil)
 		if err != nil {
 			t.Errorf("outputPretty() error = %v", err)
 		}
@@ -149,7 +151,7 @@ func TestOutputPrettyVerbose(t *testing.T) {
 	response := createTestResponse(log1)
 
 	output := captureOutput(func() {
-		err := outputPretty(response, true)
+		err := outputPretty(response, true, nil)
 		if err != nil {
 			t.Errorf("outputPretty() error = %v", err)
 		}
@@ -193,7 +195,7 @@ func TestOutputPrettyWithNilSession(t *testing.T) {
 	response := createTestResponse(log1)
 
 	output := captureOutput(func() {
-		err := outputPretty(response, true)
+		err := outputPretty(response, true, nil)
 		if err != nil {
 			t.Errorf("outputPretty() error = %v", err)
 		}
@@ -212,7 +214,7 @@ func TestOutputPrettyWithNilDetails(t *testing.T) {
 	response := createTestResponse(log1)
 
 	output := captureOutput(func() {
-		err := outputPretty(response, false)
+		err := outputPretty(response, false, nil)
 		if err != nil {
 			t.Errorf("outputPretty() error = %v", err)
 		}
@@ -289,7 +291,7 @@ func TestOutputPrettyDetailTypes(t *testing.T) {
 			response := createTestResponse(log)
 
 			output := captureOutput(func() {
-				err := outputPretty(response, false)
+				err := outputPretty(response, false, nil)
 				if err != nil {
 					t.Errorf("outputPretty() error = %v", err)
 				}
@@ -306,7 +308,7 @@ func TestOutputPrettyDetailTypes(t *testing.T) {
 
 func TestOutputResponse_UnknownFormat(t *testing.T) {
 	response := createTestResponse()
-	err := outputResponse(response, "xml", false)
+	err := outputResponse(response, "xml", false, nil)
 	if err == nil {
 		t.Error("Expected error for unknown format, got nil")
 	}
@@ -344,7 +346,7 @@ func TestOutputResponse_Routing(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			output := captureOutput(func() {
-				err := outputResponse(response, tt.format, false)
+				err := outputResponse(response, tt.format, false, nil)
 				if err != nil {
 					t.Errorf("outputResponse(%q) error = %v", tt.format, err)
 				}
@@ -361,7 +363,7 @@ func TestEmptyAuditLogs(t *testing.T) {
 
 	t.Run("json empty", func(t *testing.T) {
 		output := captureOutput(func() {
-			err := outputJSON(response, false)
+			err := outputJSON(response, false, nil)
 			if err != nil {
 				t.Errorf("outputJSON() error = %v", err)
 			}
@@ -373,7 +375,7 @@ func TestEmptyAuditLogs(t *testing.T) {
 
 	t.Run("jsonl empty", func(t *testing.T) {
 		output := captureOutput(func() {
-			err := outputJSONL(response, false)
+			err := outputJSONL(response, false, nil)
 			if err != nil {
 				t.Errorf("outputJSONL() error = %v", err)
 			}
@@ -385,7 +387,7 @@ func TestEmptyAuditLogs(t *testing.T) {
 
 	t.Run("jsonl empty verbose", func(t *testing.T) {
 		output := captureOutput(func() {
-			err := outputJSONL(response, true)
+			err := outputJSONL(response, true, nil)
 			if err != nil {
 				t.Errorf("outputJSONL() error = %v", err)
 			}
@@ -397,7 +399,7 @@ func TestEmptyAuditLogs(t *testing.T) {
 
 	t.Run("pretty empty", func(t *testing.T) {
 		output := captureOutput(func() {
-			err := outputPretty(response, false)
+			err := outputPretty(response, false, nil)
 			if err != nil {
 				t.Errorf("outputPretty() error = %v", err)
 			}
@@ -496,3 +498,276 @@ func TestListAuditLogsCommand(t *testing.T) {
 		}
 	})
 }
+
+func TestAuditLogTimelineCommand(t *testing.T) {
+	created := createTestAuditLog("log_1", "user.added", nil)
+	created.EffectiveAt = openaiorgs.UnixSeconds(time.Unix(1700000000, 0))
+	updated := createTestAuditLog("log_2", "logout.succeeded", nil)
+	updated.EffectiveAt = openaiorgs.UnixSeconds(time.Unix(1700000100, 0))
+
+	tests := []struct {
+		name         string
+		args         []string
+		wantErr      string
+		wantCalls    int
+		wantContains []string
+	}{
+		{
+			name:         "pretty by resource",
+			args:         []string{"audit-logs", "timeline", "--resource-id", "proj_abc"},
+			wantCalls:    1,
+			wantContains: []string{"Time", "Actor", "test@example.com", "logged out", "2023-11-14T22:13:20Z"},
+		},
+		{
+			name:         "json by actor",
+			args:         []string{"audit-logs", "timeline", "--actor-email", "test@example.com", "--output", "json"},
+			wantCalls:    1,
+			wantContains: []string{`"event_id": "log_2"`, `"description": "logged out"`},
+		},
+		{
+			name:    "missing filter",
+			args:    []string{"audit-logs", "timeline"},
+			wantErr: "--resource-id or --actor-email",
+		},
+		{
+			name:    "bad date",
+			args:    []string{"audit-logs", "timeline", "--resource-id", "proj_abc", "--start-date", "yesterday"},
+			wantErr: "invalid start-date format",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newCmdTestHelper(t)
+			defer h.cleanup()
+
+			h.mockResponse("GET", "/organization/audit_logs", 200, createTestResponse(updated, created))
+
+			var err error
+			output := captureOutput(func() {
+				err = h.runCmd(AuditLogsCommand(), tt.args)
+			})
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("runCmd() error = %v", err)
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(output, want) {
+					t.Errorf("expected output to contain %q, got: %s", want, output)
+				}
+			}
+			h.assertRequest("GET", "/organization/audit_logs", tt.wantCalls)
+		})
+	}
+}
+
+func TestAuditLogTimelineCommand_Filters(t *testing.T) {
+	h := newCmdTestHelper(t)
+	defer h.cleanup()
+
+	var query string
+	httpmock.RegisterResponder("GET", testBaseURL+"/organization/audit_logs",
+		func(req *http.Request) (*http.Response, error) {
+			query = req.URL.RawQuery
+			return httpmock.NewJsonResponse(200, createTestResponse())
+		})
+
+	output := captureOutput(func() {
+		err := h.runCmd(AuditLogsCommand(), []string{"audit-logs", "timeline", "--resource-id", "proj_abc", "--actor-email", "a@example.com"})
+		if err != nil {
+			t.Errorf("runCmd() error = %v", err)
+		}
+	})
+
+	if !strings.Contains(output, "No matching audit log events found.") {
+		t.Errorf("expected empty message, got: %s", output)
+	}
+	if !strings.Contains(query, "proj_abc") || !strings.Contains(query, "a%40example.com") {
+		t.Errorf("expected resource and actor filters in query, got: %s", query)
+	}
+}
+
+func TestListAuditLogsCommand_Resolve(t *testing.T) {
+	log1 := createTestAuditLog("log_1", "project.archived", &openaiorgs.ProjectArchived{ID: "proj_abc"})
+	log1.Project = &openaiorgs.AuditProject{ID: "proj_abc"}
+
+	tests := []struct {
+		name         string
+		args         []string
+		wantContains []string
+	}{
+		{
+			name:         "pretty",
+			args:         []string{"audit-logs", "--resolve"},
+			wantContains: []string{"Actor:     test@example.com", "Project:   Alpha", "Resource:  Alpha"},
+		},
+		{
+			name:         "json",
+			args:         []string{"audit-logs", "--resolve", "--output", "json"},
+			wantContains: []string{`"resolved"`, `"project": "Alpha"`},
+		},
+		{
+			name:         "jsonl",
+			args:         []string{"audit-logs", "--resolve", "--output", "jsonl"},
+			wantContains: []string{`"resolved":{`, `"resource":"Alpha"`},
+		},
+		{
+			name: "csv",
+			args: []string{"audit-logs", "--resolve", "--output", "csv"},
+			wantContains: []string{
+				"id,type,effective_at,actor,project_id,resource_id,actor_name,api_key_name,service_account_name,project_name,resource_name",
+				"log_1,project.archived,",
+				",test@example.com,,,Alpha,Alpha",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newCmdTestHelper(t)
+			defer h.cleanup()
+
+			h.mockResponse("GET", "/organization/audit_logs", 200, createTestResponse(log1))
+			h.mockResponse("GET", "/organization/projects/proj_abc", 200, openaiorgs.Project{ID: "proj_abc", Name: "Alpha"})
+
+			var err error
+			output := captureOutput(func() {
+				err = h.runCmd(AuditLogsCommand(), tt.args)
+			})
+			if err != nil {
+				t.Fatalf("runCmd() error = %v", err)
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(output, want) {
+					t.Errorf("expected output to contain %q, got: %s", want, output)
+				}
+			}
+			h.assertRequest("GET", "/organization/projects/proj_abc", 1)
+		})
+	}
+}
+
+func TestOutputCSV_WithoutResolve(t *testing.T) {
+	response := createTestResponse(createTestAuditLog("log_1", "api_key.deleted", &openaiorgs.APIKeyDeleted{ID: "key_1"}))
+
+	output := captureOutput(func() {
+		if err := outputCSV(response, nil); err != nil {
+			t.Errorf("outputCSV() error = %v", err)
+		}
+	})
+
+	if !strings.HasPrefix(output, "id,type,effective_at,actor,project_id,resource_id\n") {
+		t.Errorf("unexpected CSV header: %s", output)
+	}
+	if strings.Contains(output, "actor_name") {
+		t.Errorf("expected no resolved columns, got: %s", output)
+	}
+	if !strings.Contains(output, "test@example.com,,key_1") {
+		t.Errorf("expected actor and resource in CSV row, got: %s", output)
+	}
+}
+
+func TestAuditLogExportAndVerifyCommands(t *testing.T) {
+	dir := t.TempDir()
+	privPath := filepath.Join(dir, "signing.pem")
+	pubPath := filepath.Join(dir, "signing.pub")
+	exportPath := filepath.Join(dir, "export.jsonl")
+
+	h := newCmdTestHelper(t)
+	defer h.cleanup()
+
+	output := captureOutput(func() {
+		err := h.runCmd(AuditLogsCommand(), []string{"audit-logs", "keygen", "--private-key", privPath, "--public-key", pubPath})
+		if err != nil {
+			t.Fatalf("keygen error = %v", err)
+		}
+	})
+	if !strings.Contains(output, "Wrote private key") {
+		t.Errorf("unexpected keygen output: %s", output)
+	}
+	if info, err := os.Stat(privPath); err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("expected private key with 0600 permissions, got %v (%v)", info, err)
+	}
+
+	original, err := os.ReadFile(privPath)
+	if err != nil {
+		t.Fatalf("failed to read private key: %v", err)
+	}
+	err = h.runCmd(AuditLogsCommand(), []string{"audit-logs", "keygen", "--private-key", privPath, "--public-key", pubPath})
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("expected keygen to refuse to overwrite, got %v", err)
+	}
+	if current, _ := os.ReadFile(privPath); string(current) != string(original) {
+		t.Error("expected existing private key to be left untouched")
+	}
+	captureOutput(func() {
+		err = h.runCmd(AuditLogsCommand(), []string{"audit-logs", "keygen", "--private-key", privPath, "--public-key", pubPath, "--force"})
+	})
+	if err != nil {
+		t.Fatalf("keygen --force error = %v", err)
+	}
+	if current, _ := os.ReadFile(privPath); string(current) == string(original) {
+		t.Error("expected --force to replace the private key")
+	}
+
+	log1 := createTestAuditLog("log_1", "project.archived", &openaiorgs.ProjectArchived{ID: "proj_abc"})
+	log2 := createTestAuditLog("log_2", "api_key.deleted", &openaiorgs.APIKeyDeleted{ID: "key_1"})
+	h.mockResponse("GET", "/organization/audit_logs", 200, createTestResponse(log1, log2))
+
+	output = captureOutput(func() {
+		err := h.runCmd(AuditLogsCommand(), []string{"audit-logs", "export", "--file", exportPath, "--private-key", privPath, "--resource-id", "proj_abc"})
+		if err != nil {
+			t.Fatalf("export error = %v", err)
+		}
+	})
+	if !strings.Contains(output, "Exported 2 audit logs") {
+		t.Errorf("unexpected export output: %s", output)
+	}
+	h.assertRequest("GET", "/organization/audit_logs", 1)
+
+	output = captureOutput(func() {
+		err := h.runCmd(AuditLogsCommand(), []string{"audit-logs", "verify", "--file", exportPath, "--public-key", pubPath})
+		if err != nil {
+			t.Fatalf("verify error = %v", err)
+		}
+	})
+	if !strings.Contains(output, "Export verified: 2 records") || !strings.Contains(output, "log_1 .. log_2") {
+		t.Errorf("unexpected verify output: %s", output)
+	}
+
+	content, err := os.ReadFile(exportPath)
+	if err != nil {
+		t.Fatalf("failed to read export: %v", err)
+	}
+	tampered := strings.Replace(string(content), "key_1", "key_2", 1)
+	if err := os.WriteFile(exportPath, []byte(tampered), 0o600); err != nil {
+		t.Fatalf("failed to write export: %v", err)
+	}
+
+	err = h.runCmd(AuditLogsCommand(), []string{"audit-logs", "verify", "--file", exportPath, "--public-key", pubPath})
+	if err == nil || !strings.Contains(err.Error(), "verification failed") {
+		t.Errorf("expected verification failure, got %v", err)
+	}
+}
+
+func TestAuditLogExportCommand_BadKey(t *testing.T) {
+	keyPath := filepath.Join(t.TempDir(), "bad.pem")
+	if err := os.WriteFile(keyPath, []byte("not a key"), 0o600); err != nil {
+		t.Fatalf("failed to write key: %v", err)
+	}
+
+	h := newCmdTestHelper(t)
+	defer h.cleanup()
+
+	err := h.runCmd(AuditLogsCommand(), []string{"audit-logs", "export", "--file", filepath.Join(t.TempDir(), "out.jsonl"), "--private-key", keyPath})
+	if err == nil || !strings.Contains(err.Error(), "no PEM data found") {
+		t.Errorf("expected PEM error, got %v", err)
+	}
+	h.assertRequest("GET", "/organization/audit_logs", 0)
+}
@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	openaiorgs "github.com/klauern/openai-orgs"
+	"github.com/urfave/cli/v3"
+)
+
+func BudgetCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "budget",
+		Usage: "Enforce monthly project budgets by throttling rate limits",
+		Commands: []*cli.Command{
+			enforceBudgetCommand(),
+			releaseBudgetCommand(),
+		},
+	}
+}
+
+func enforceBudgetCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "enforce",
+		Usage: "Throttle the rate limits of projects over their monthly budget and restore throttles from earlier months",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "policy",
+				Usage: "YAML file with the monthly budget of each project",
+				Value: "budget-policy.yaml",
+			},
+			budgetJournalFlag(),
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Show what would be throttled or restored without changing anything",
+			},
+		},
+		Action: enforceBudget,
+	}
+}
+
+func releaseBudgetCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "release",
+		Usage: "Restore the original rate limits of throttled projects",
+		Flags: []cli.Flag{
+			budgetJournalFlag(),
+			&cli.StringSliceFlag{
+				Name:  "project-id",
+				Usage: "Project to release (repeatable; default: every throttled project)",
+			},
+			&cli.BoolFlag{
+				Name:    "yes",
+				Aliases: []string{"y"},
+				Usage:   "Restore without asking",
+			},
+		},
+		Action: releaseBudget,
+	}
+}
+
+func budgetJournalFlag() *cli.StringFlag {
+	return &cli.StringFlag{
+		Name:  "journal",
+		Usage: "Journal of throttles and restores (JSON lines)",
+		Value: "budget-journal.jsonl",
+	}
+}
+
+func enforceBudget(ctx context.Context, cmd *cli.Command) error {
+	policy, err := openaiorgs.LoadBudgetPolicy(cmd.String("policy"))
+	if err != nil {
+		return err
+	}
+
+	client := newClient(ctx, cmd)
+	journal := &openaiorgs.BudgetJournal{Path: cmd.String("journal")}
+	statuses, err := openaiorgs.EnforceBudgets(client, policy, journal, openaiorgs.BudgetEnforcementOptions{
+		DryRun: cmd.Bool("dry-run"),
+		Now:    time.Now(),
+	})
+	if err != nil {
+		if len(statuses) > 0 {
+			_ = printBudgetStatuses(cmd, statuses)
+		}
+		return wrapError("enforce budgets", err)
+	}
+	return printBudgetStatuses(cmd, statuses)
+}
+
+func releaseBudget(ctx context.Context, cmd *cli.Command) error {
+	journal := &openaiorgs.BudgetJournal{Path: cmd.String("journal")}
+	active, err := journal.ActiveThrottles()
+	if err != nil {
+		return err
+	}
+
+	projectIDs := cmd.StringSlice("project-id")
+	if len(projectIDs) == 0 && len(active) == 0 {
+		fmt.Println("No projects are throttled.")
+		return nil
+	}
+	if !cmd.Bool("yes") {
+		target := "every throttled project"
+		if len(projectIDs) > 0 {
+			target = strings.Join(projectIDs, ", ")
+		}
+		if !confirm(fmt.Sprintf("Restore the original rate limits of %s?", target)) {
+			fmt.Println("Aborted.")
+			return nil
+		}
+	}
+
+	client := newClient(ctx, cmd)
+	statuses, err := openaiorgs.ReleaseBudgetThrottles(client, journal, projectIDs, false)
+	if err != nil {
+		return wrapError("release budget throttles", err)
+	}
+	return printBudgetStatuses(cmd, statuses)
+}
+
+// printBudgetStatuses prints the outcome of enforce or release and returns an error if
+// any project failed.
+func printBudgetStatuses(cmd *cli.Command, statuses []openaiorgs.BudgetStatus) error {
+	if cmd.String("output") == OutputFormatJSON {
+		data, err := json.MarshalIndent(statuses, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal budget statuses: %w", err)
+		}
+		fmt.Println(string(data))
+	} else {
+		data := TableData{Headers: []string{"Project", "Budget", "Spend", "Status", "Changes"}}
+		for _, status := range statuses {
+			changes := make([]string, 0, len(status.Changes))
+			for _, change := range status.Changes {
+				for _, field := range change.Fields {
+					changes = append(changes, fmt.Sprintf("%s %s: %d -> %d", change.Model, field.Field, field.From, field.To))
+				}
+			}
+			if status.Error != "" {
+				changes = append(changes, "error: "+status.Error)
+			}
+			data.Rows = append(data.Rows, []string{
+				status.ProjectID,
+				fmt.Sprintf("%.2f", status.Budget),
+				fmt.Sprintf("%.2f", status.Spend),
+				status.Status,
+				strings.Join(changes, "; "),
+			})
+		}
+		printTableData(data)
+	}
+
+	failed := 0
+	for _, status := range statuses {
+		if status.Status == openaiorgs.BudgetStatusFailed {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d project(s) had errors", failed, len(statuses))
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	openaiorgs "github.com/klauern/openai-orgs"
+)
+
+func TestEnforceBudgetCommand(t *testing.T) {
+	h := newCmdTestHelper(t)
+	defer h.cleanup()
+
+	dir := t.TempDir()
+	policy := filepath.Join(dir, "budget-policy.yaml")
+	if err := os.WriteFile(policy, []byte("projects:\n  proj_a:\n    monthly_budget: 100\n  proj_b:\n    monthly_budget: 100\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	journal := filepath.Join(dir, "journal.jsonl")
+
+	h.mockResponse("GET", "/organization/costs", 200, openaiorgs.CostsUsageResponse{
+		Object: "page",
+		Data: []openaiorgs.CostsUsageBucket{{Results: []openaiorgs.CostsUsageResult{
+			{ProjectID: "proj_a", Amount: openaiorgs.CostAmount{Value: 150, Currency: "usd"}},
+			{ProjectID: "proj_b", Amount: openaiorgs.CostAmount{Value: 20, Currency: "usd"}},
+		}}},
+	})
+	h.mockResponse("GET", "/organization/projects/proj_a/rate_limits", 200, openaiorgs.ListResponse[openaiorgs.ProjectRateLimit]{
+		Object: "list", Data: []openaiorgs.ProjectRateLimit{createMockProjectRateLimit("rl_a", "gpt-4o")},
+	})
+	h.mockResponse("POST", "/organization/projects/proj_a/rate_limits/rl_a", 200, createMockProjectRateLimit("rl_a", "gpt-4o"))
+
+	var err error
+	output := captureOutput(func() {
+		err = h.runCmd(BudgetCommand(), []string{"budget", "enforce", "--policy", policy, "--journal", journal})
+	})
+	if err != nil {
+		t.Fatalf("runCmd() error = %v", err)
+	}
+	for _, want := range []string{
+		"Project | Budget | Spend | Status | Changes",
+		"proj_a | 100.00 | 150.00 | throttled | gpt-4o max_requests_per_1_minute: 100 -> 10",
+		"proj_b | 100.00 | 20.00 | within_budget |",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got: %s", want, output)
+		}
+	}
+	h.assertRequest("POST", "/organization/projects/proj_a/rate_limits/rl_a", 1)
+
+	output = captureOutput(func() {
+		err = h.runCmd(BudgetCommand(), []string{"--output", "json", "budget", "release", "--journal", journal, "--yes"})
+	})
+	if err != nil {
+		t.Fatalf("runCmd() release error = %v", err)
+	}
+	var statuses []openaiorgs.BudgetStatus
+	if err := json.Unmarshal([]byte(output), &statuses); err != nil {
+		t.Fatalf("failed to parse JSON output: %v\n%s", err, output)
+	}
+	if len(statuses) != 1 || statuses[0].ProjectID != "proj_a" || statuses[0].Status != openaiorgs.BudgetStatusRestored {
+		t.Errorf("unexpected release statuses: %+v", statuses)
+	}
+	h.assertRequest("POST", "/organization/projects/proj_a/rate_limits/rl_a", 2)
+}
+
+func TestReleaseBudgetCommand_NothingThrottled(t *testing.T) {
+	h := newCmdTestHelper(t)
+	defer h.cleanup()
+
+	var err error
+	output := captureOutput(func() {
+		err = h.runCmd(BudgetCommand(), []string{"budget", "release", "--journal", filepath.Join(t.TempDir(), "journal.jsonl")})
+	})
+	if err != nil {
+		t.Fatalf("runCmd() error = %v", err)
+	}
+	if !strings.Contains(output, "No projects are throttled.") {
+		t.Errorf("unexpected output: %s", output)
+	}
+}
@@ -2,10 +2,15 @@ package cmd
 
 import (
 	"context"
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
+	"time"
 
+	openaiorgs "github.com/klauern/openai-orgs"
 	"github.com/urfave/cli/v3"
 )
 
@@ -16,6 +21,12 @@ func CertificatesCommand() *cli.Command {
 		Commands: []*cli.Command{
 			certificatesOrgCommand(),
 			certificatesProjectCommand(),
+			inspectCertificateCommand(),
+			expiringCertificatesCommand(),
+			rotateCertificateCommand(),
+			certificateMatrixCommand(),
+			generateCertificateCommand(),
+			verifyClientCertificateCommand(),
 		},
 	}
 }
@@ -74,14 +85,21 @@ func uploadCertificateCommand() *cli.Command {
 		Flags: []cli.Flag{
 			nameFlag,
 			&cli.StringFlag{
-				Name:     "content",
-				Usage:    "PEM-encoded certificate content",
-				Required: true,
+				Name:  "content",
+				Usage: "PEM-encoded certificate content",
 			},
 			&cli.StringFlag{
 				Name:  "content-file",
 				Usage: "Path to file containing PEM-encoded certificate content",
 			},
+			&cli.BoolFlag{
+				Name:  "allow-leaf",
+				Usage: "Allow uploading a certificate that is not a CA certificate",
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Validate and show the certificate without uploading it",
+			},
 		},
 		Action: uploadCertificate,
 	}
@@ -155,6 +173,198 @@ func deactivateOrgCertificatesCommand() *cli.Command {
 	}
 }
 
+func inspectCertificateCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "inspect",
+		Usage: "Decode an uploaded certificate (with --include-content) or a local PEM file",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "id",
+				Usage: "ID of the uploaded certificate",
+			},
+			&cli.BoolFlag{
+				Name:  "include-content",
+				Usage: "Fetch the certificate PEM content and decode it",
+			},
+			&cli.StringFlag{
+				Name:  "content-file",
+				Usage: "Path to a local PEM file to decode instead of an uploaded certificate",
+			},
+		},
+		Action: inspectCertificate,
+	}
+}
+
+func expiringCertificatesCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "expiring",
+		Usage: "List organization certificates that expire soon (or have expired), soonest first",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "within",
+				Usage: "Time window to look ahead (e.g., 30d, 72h)",
+				Value: "30d",
+			},
+		},
+		Action: expiringCertificates,
+	}
+}
+
+func rotateCertificateCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "rotate",
+		Usage: "Upload a replacement certificate, activate it wherever the old one is active, then deactivate the old one",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "old",
+				Usage:    "ID of the certificate to replace",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "file",
+				Usage:    "Path to the PEM-encoded replacement certificate",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "name",
+				Usage: "Name of the new certificate (default: the old name followed by today's date)",
+			},
+			&cli.BoolFlag{
+				Name:  "allow-leaf",
+				Usage: "Allow a replacement certificate that is not a CA certificate",
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Show the steps without changing anything",
+			},
+			&cli.BoolFlag{
+				Name:    "yes",
+				Aliases: []string{"y"},
+				Usage:   "Rotate without asking",
+			},
+		},
+		Action: rotateCertificate,
+	}
+}
+
+func certificateMatrixCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "matrix",
+		Usage: "Show which organization certificates are active in which projects",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "expiring-within",
+				Usage: "Flag projects using an active certificate that expires within this window (e.g., 30d)",
+				Value: "30d",
+			},
+			&cli.BoolFlag{
+				Name:  "include-archived",
+				Usage: "Include archived projects",
+			},
+			&cli.IntFlag{
+				Name:  "concurrency",
+				Usage: "Number of projects to fetch in parallel",
+				Value: openaiorgs.DefaultInventoryConcurrency,
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "Output format (pretty, csv, json)",
+				Value:   "pretty",
+			},
+		},
+		Action: certificateMatrix,
+	}
+}
+
+func generateCertificateCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "generate",
+		Usage: "Generate a local CA (--ca) or a client certificate signed by it (--client) for testing mutual TLS",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "ca",
+				Usage: "Generate a self-signed CA certificate",
+			},
+			&cli.BoolFlag{
+				Name:  "client",
+				Usage: "Generate a client certificate signed by --ca-cert and --ca-key",
+			},
+			&cli.StringFlag{
+				Name:     "common-name",
+				Aliases:  []string{"cn"},
+				Usage:    "Subject common name",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "organization",
+				Usage: "Subject organization",
+			},
+			&cli.StringSliceFlag{
+				Name:  "dns",
+				Usage: "DNS subject alternative name (repeatable)",
+			},
+			&cli.StringSliceFlag{
+				Name:  "ip",
+				Usage: "IP address subject alternative name (repeatable)",
+			},
+			&cli.StringSliceFlag{
+				Name:  "email",
+				Usage: "Email subject alternative name (repeatable)",
+			},
+			&cli.StringSliceFlag{
+				Name:  "uri",
+				Usage: "URI subject alternative name (repeatable)",
+			},
+			&cli.StringFlag{
+				Name:  "validity",
+				Usage: "How long the certificate is valid (e.g., 365d, 720h)",
+				Value: "365d",
+			},
+			&cli.StringFlag{
+				Name:  "ca-cert",
+				Usage: "Path to the PEM-encoded CA certificate (with --client)",
+			},
+			&cli.StringFlag{
+				Name:  "ca-key",
+				Usage: "Path to the PEM-encoded CA private key (with --client)",
+			},
+			&cli.StringFlag{
+				Name:  "out",
+				Usage: "Output path prefix; writes <out>.pem and <out>-key.pem (default: ca or client)",
+			},
+			&cli.BoolFlag{
+				Name:  "force",
+				Usage: "Overwrite existing output files",
+			},
+			&cli.BoolFlag{
+				Name:  "upload",
+				Usage: "Upload the generated CA certificate to the organization (with --ca)",
+			},
+			&cli.StringFlag{
+				Name:  "name",
+				Usage: "Name of the uploaded certificate (default: the common name)",
+			},
+		},
+		Action: generateCertificate,
+	}
+}
+
+func verifyClientCertificateCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "verify-client",
+		Usage: "Check that a client certificate chains to one of the organization's active certificates",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "cert",
+				Usage:    "Path to the PEM-encoded client certificate (followed by any intermediates)",
+				Required: true,
+			},
+		},
+		Action: verifyClientCertificate,
+	}
+}
+
 // Project certificate commands
 
 func listProjectCertificatesCommand() *cli.Command {
@@ -297,7 +507,20 @@ func uploadCertificate(ctx context.Context, cmd *cli.Command) error {
 		content = string(data)
 	}
 
-	certificate, err := client.UploadCertificate(content, name)
+	opts := openaiorgs.CertificateValidationOptions{AllowLeaf: cmd.Bool("allow-leaf")}
+	infos, err := openaiorgs.ValidateCertificatePEM(content, opts)
+	if len(infos) > 0 {
+		printCertificateInfos(infos)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid certificate: %w", err)
+	}
+	if cmd.Bool("dry-run") {
+		fmt.Println("Certificate is valid; not uploaded (dry run)")
+		return nil
+	}
+
+	certificate, err := client.UploadCertificateWithOptions(content, name, opts)
 	if err != nil {
 		return wrapError("upload certificate", err)
 	}
@@ -334,6 +557,437 @@ func getCertificate(ctx context.Context, cmd *cli.Command) error {
 	return nil
 }
 
+func inspectCertificate(ctx context.Context, cmd *cli.Command) error {
+	id := cmd.String("id")
+	contentFile := cmd.String("content-file")
+	if (id == "") == (contentFile == "") {
+		return fmt.Errorf("exactly one of --id or --content-file must be provided")
+	}
+
+	result := struct {
+		Certificate  *openaiorgs.Certificate      `json:"certificate,omitempty"`
+		Certificates []openaiorgs.CertificateInfo `json:"certificates,omitempty"`
+	}{}
+
+	var content string
+	if contentFile != "" {
+		data, err := os.ReadFile(contentFile)
+		if err != nil {
+			return fmt.Errorf("failed to read certificate file: %v", err)
+		}
+		content = string(data)
+	} else {
+		client := newClient(ctx, cmd)
+		certificate, err := client.GetCertificate(id, cmd.Bool("include-content"))
+		if err != nil {
+			return wrapError("get certificate", err)
+		}
+		result.Certificate = certificate
+		if certificate.CertificateDetails.Content != nil {
+			content = *certificate.CertificateDetails.Content
+		}
+	}
+
+	if content != "" {
+		infos, err := openaiorgs.InspectCertificatePEM(content)
+		if err != nil {
+			return fmt.Errorf("failed to decode certificate content: %w", err)
+		}
+		result.Certificates = infos
+	}
+
+	if cmd.String("output") == OutputFormatJSON {
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal certificate: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if certificate := result.Certificate; certificate != nil {
+		fmt.Printf("ID: %s\nName: %s\nValid At: %s\nExpires At: %s\n",
+			certificate.ID,
+			certificate.Name,
+			certificate.CertificateDetails.ValidAt.String(),
+			certificate.CertificateDetails.ExpiresAt.String())
+		if content == "" {
+			fmt.Println("Use --include-content to decode the certificate itself")
+			return nil
+		}
+		fmt.Println()
+	}
+	printCertificateInfos(result.Certificates)
+	return nil
+}
+
+// printCertificateInfos prints the decoded fields of each certificate in a PEM bundle.
+func printCertificateInfos(infos []openaiorgs.CertificateInfo) {
+	now := time.Now()
+	for i, info := range infos {
+		if len(infos) > 1 {
+			fmt.Printf("Certificate %d of %d:\n", i+1, len(infos))
+		}
+		status := "valid"
+		switch {
+		case now.After(info.NotAfter):
+			status = "expired"
+		case now.Before(info.NotBefore):
+			status = "not yet valid"
+		}
+		sans := strings.Join(info.SANs(), ", ")
+		if sans == "" {
+			sans = "none"
+		}
+		ca := "No"
+		if info.IsCA {
+			ca = "Yes"
+		}
+		fmt.Printf("Subject: %s\nIssuer: %s\nSerial Number: %s\nSANs: %s\nCA: %s\nNot Before: %s\nNot After: %s (%s)\nSHA-256 Fingerprint: %s\n\n",
+			info.Subject,
+			info.Issuer,
+			info.SerialNumber,
+			sans,
+			ca,
+			info.NotBefore.Format(time.RFC3339),
+			info.NotAfter.Format(time.RFC3339),
+			status,
+			info.SHA256Fingerprint)
+	}
+}
+
+func expiringCertificates(ctx context.Context, cmd *cli.Command) error {
+	within, err := parseDuration(cmd.String("within"))
+	if err != nil {
+		return fmt.Errorf("invalid --within: %w", err)
+	}
+
+	client := newClient(ctx, cmd)
+	certificates, err := openaiorgs.ExpiringCertificates(client, within, time.Time{})
+	if err != nil {
+		return wrapError("list expiring certificates", err)
+	}
+
+	if cmd.String("output") == OutputFormatJSON {
+		data, err := json.MarshalIndent(certificates, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal certificates: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	now := time.Now()
+	data := TableData{Headers: []string{"ID", "Name", "Active", "Expires At", "Days Left"}}
+	for _, cert := range certificates {
+		expiresAt := time.Time(cert.CertificateDetails.ExpiresAt)
+		daysLeft := "expired"
+		if expiresAt.After(now) {
+			daysLeft = fmt.Sprintf("%d", int(expiresAt.Sub(now).Hours()/24))
+		}
+		active := "N/A"
+		if cert.Active != nil {
+			if *cert.Active {
+				active = "Yes"
+			} else {
+				active = "No"
+			}
+		}
+		data.Rows = append(data.Rows, []string{cert.ID, cert.Name, active, cert.CertificateDetails.ExpiresAt.String(), daysLeft})
+	}
+	printTableData(data)
+	return nil
+}
+
+func rotateCertificate(ctx context.Context, cmd *cli.Command) error {
+	// The plan is only printed in pretty mode, so it cannot be reviewed at a prompt.
+	if cmd.String("output") == OutputFormatJSON && !cmd.Bool("dry-run") && !cmd.Bool("yes") {
+		return fmt.Errorf("--yes or --dry-run is required with --output %s", OutputFormatJSON)
+	}
+	content, err := os.ReadFile(cmd.String("file"))
+	if err != nil {
+		return fmt.Errorf("failed to read certificate file: %v", err)
+	}
+
+	client := newClient(ctx, cmd)
+	opts := openaiorgs.CertificateRotationOptions{Name: cmd.String("name"), AllowLeaf: cmd.Bool("allow-leaf"), DryRun: true}
+	plan, err := openaiorgs.RotateCertificate(client, cmd.String("old"), string(content), opts)
+	if err != nil {
+		return wrapError("plan certificate rotation", err)
+	}
+
+	if cmd.Bool("dry-run") {
+		return printCertificateRotation(cmd, plan)
+	}
+	if cmd.String("output") != OutputFormatJSON {
+		if err := printCertificateRotation(cmd, plan); err != nil {
+			return err
+		}
+	}
+	if !cmd.Bool("yes") && !confirm(fmt.Sprintf("Rotate %s to %q?", plan.OldCertificateID, plan.Name)) {
+		fmt.Println("Aborted.")
+		return nil
+	}
+
+	opts.Name = plan.Name
+	opts.DryRun = false
+	rotation, err := openaiorgs.RotateCertificate(client, cmd.String("old"), string(content), opts)
+	if rotation != nil {
+		if printErr := printCertificateRotation(cmd, rotation); printErr != nil {
+			return printErr
+		}
+	}
+	if err != nil {
+		return wrapError("rotate certificate", err)
+	}
+	return nil
+}
+
+func printCertificateRotation(cmd *cli.Command, rotation *openaiorgs.CertificateRotation) error {
+	if cmd.String("output") == OutputFormatJSON {
+		data, err := json.MarshalIndent(rotation, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal certificate rotation: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	fmt.Printf("Rotate %s to %q (%s)\n", rotation.OldCertificateID, rotation.Name, rotation.Status)
+	data := TableData{Headers: []string{"Step", "Project", "Certificate", "Status", "Error"}}
+	for _, step := range rotation.Steps {
+		action := step.Action
+		if step.Rollback {
+			action = "rollback " + action
+		}
+		certificateID := step.CertificateID
+		if certificateID == "" {
+			certificateID = "(new)"
+		}
+		data.Rows = append(data.Rows, []string{action, step.ProjectID, certificateID, step.Status, step.Error})
+	}
+	printTableData(data)
+	return nil
+}
+
+func certificateMatrix(ctx context.Context, cmd *cli.Command) error {
+	outputFormat := cmd.String("output")
+	switch outputFormat {
+	case OutputFormatPretty, "csv", OutputFormatJSON:
+	default:
+		return fmt.Errorf("unknown output format: %s", outputFormat)
+	}
+
+	within, err := parseDuration(cmd.String("expiring-within"))
+	if err != nil {
+		return fmt.Errorf("invalid --expiring-within: %w", err)
+	}
+
+	client := newClient(ctx, cmd)
+	matrix, err := openaiorgs.BuildCertificateMatrix(client, openaiorgs.CertificateMatrixOptions{
+		ExpiringWithin:  within,
+		IncludeArchived: cmd.Bool("include-archived"),
+		Concurrency:     int(cmd.Int("concurrency")),
+	})
+	if err != nil {
+		return wrapError("build certificate matrix", err)
+	}
+
+	switch outputFormat {
+	case OutputFormatJSON:
+		data, err := json.MarshalIndent(matrix, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal certificate matrix: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	case "csv":
+		return printCertificateMatrixCSV(matrix)
+	default:
+		printCertificateMatrix(matrix, cmd.String("expiring-within"))
+		return nil
+	}
+}
+
+func certificateMatrixHeaders(matrix *openaiorgs.CertificateMatrix) []string {
+	headers := []string{"Project", "Name"}
+	for _, cert := range matrix.Certificates {
+		headers = append(headers, fmt.Sprintf("%s (%s)", cert.Name, cert.ID))
+	}
+	return append(headers, "Flags")
+}
+
+func printCertificateMatrix(matrix *openaiorgs.CertificateMatrix, within string) {
+	data := TableData{Headers: certificateMatrixHeaders(matrix)}
+	var noActive, expiring []string
+	for _, project := range matrix.Projects {
+		row := []string{project.ProjectID, project.ProjectName}
+		for _, cert := range matrix.Certificates {
+			cell := "-"
+			if project.IsActive(cert.ID) {
+				cell = "active"
+				if slices.Contains(project.Expiring, cert.ID) {
+					cell = "active (expiring)"
+				}
+			}
+			row = append(row, cell)
+		}
+		data.Rows = append(data.Rows, append(row, strings.Join(project.Flags, ", ")))
+
+		if slices.Contains(project.Flags, openaiorgs.CertificateFlagNoActive) {
+			noActive = append(noActive, project.ProjectID)
+		}
+		if len(project.Expiring) > 0 {
+			expiring = append(expiring, fmt.Sprintf("%s (%s)", project.ProjectID, strings.Join(project.Expiring, ", ")))
+		}
+	}
+	printTableData(data)
+
+	if len(noActive) > 0 {
+		fmt.Printf("\nProjects without an active certificate: %s\n", strings.Join(noActive, ", "))
+	}
+	if len(expiring) > 0 {
+		fmt.Printf("\nProjects using a certificate that expires within %s: %s\n", within, strings.Join(expiring, "; "))
+	}
+}
+
+func printCertificateMatrixCSV(matrix *openaiorgs.CertificateMatrix) error {
+	w := csv.NewWriter(os.Stdout)
+	headers := []string{"project_id", "project_name"}
+	for _, cert := range matrix.Certificates {
+		headers = append(headers, cert.ID)
+	}
+	if err := w.Write(append(headers, "flags")); err != nil {
+		return err
+	}
+	for _, project := range matrix.Projects {
+		row := []string{project.ProjectID, project.ProjectName}
+		for _, cert := range matrix.Certificates {
+			if project.IsActive(cert.ID) {
+				row = append(row, "active")
+			} else {
+				row = append(row, "inactive")
+			}
+		}
+		if err := w.Write(append(row, strings.Join(project.Flags, ";"))); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func generateCertificate(ctx context.Context, cmd *cli.Command) error {
+	isCA, isClient := cmd.Bool("ca"), cmd.Bool("client")
+	if isCA == isClient {
+		return fmt.Errorf("exactly one of --ca or --client must be provided")
+	}
+	if cmd.Bool("upload") && !isCA {
+		return fmt.Errorf("--upload can only be used with --ca")
+	}
+	validity, err := parseDuration(cmd.String("validity"))
+	if err != nil {
+		return fmt.Errorf("invalid --validity: %w", err)
+	}
+
+	req := openaiorgs.CertificateRequest{
+		CommonName:     cmd.String("common-name"),
+		Organization:   cmd.String("organization"),
+		DNSNames:       cmd.StringSlice("dns"),
+		IPAddresses:    cmd.StringSlice("ip"),
+		EmailAddresses: cmd.StringSlice("email"),
+		URIs:           cmd.StringSlice("uri"),
+		Validity:       validity,
+	}
+
+	out := cmd.String("out")
+	var generated *openaiorgs.GeneratedCertificate
+	if isCA {
+		if out == "" {
+			out = "ca"
+		}
+		generated, err = openaiorgs.GenerateCA(req)
+	} else {
+		if out == "" {
+			out = "client"
+		}
+		if cmd.String("ca-cert") == "" || cmd.String("ca-key") == "" {
+			return fmt.Errorf("--ca-cert and --ca-key are required with --client")
+		}
+		caCert, readErr := os.ReadFile(cmd.String("ca-cert"))
+		if readErr != nil {
+			return fmt.Errorf("failed to read CA certificate: %v", readErr)
+		}
+		caKey, readErr := os.ReadFile(cmd.String("ca-key"))
+		if readErr != nil {
+			return fmt.Errorf("failed to read CA key: %v", readErr)
+		}
+		generated, err = openaiorgs.GenerateClientCertificate(req, string(caCert), string(caKey))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to generate certificate: %w", err)
+	}
+
+	certPath, keyPath := out+".pem", out+"-key.pem"
+	if err := generated.WriteFiles(certPath, keyPath, cmd.Bool("force")); err != nil {
+		return err
+	}
+	fmt.Printf("Wrote %s and %s\n\n", certPath, keyPath)
+	printCertificateInfos([]openaiorgs.CertificateInfo{generated.Info})
+
+	if !cmd.Bool("upload") {
+		return nil
+	}
+	name := cmd.String("name")
+	if name == "" {
+		name = req.CommonName
+	}
+	client := newClient(ctx, cmd)
+	certificate, err := client.UploadCertificate(generated.CertificatePEM, name)
+	if err != nil {
+		return wrapError("upload certificate", err)
+	}
+	fmt.Printf("Certificate uploaded:\n")
+	fmt.Printf("ID: %s\nName: %s\nValid At: %s\nExpires At: %s\n",
+		certificate.ID,
+		certificate.Name,
+		certificate.CertificateDetails.ValidAt.String(),
+		certificate.CertificateDetails.ExpiresAt.String())
+	return nil
+}
+
+func verifyClientCertificate(ctx context.Context, cmd *cli.Command) error {
+	content, err := os.ReadFile(cmd.String("cert"))
+	if err != nil {
+		return fmt.Errorf("failed to read certificate file: %v", err)
+	}
+
+	client := newClient(ctx, cmd)
+	result, err := openaiorgs.VerifyClientCertificate(client, string(content), time.Time{})
+	if err != nil {
+		return wrapError("verify client certificate", err)
+	}
+
+	if cmd.String("output") == OutputFormatJSON {
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal verification: %w", err)
+		}
+		fmt.Println(string(data))
+	} else {
+		printCertificateInfos([]openaiorgs.CertificateInfo{result.Client})
+		if result.Trusted {
+			fmt.Printf("Trusted: chains to %s (%s)\n", result.CertificateID, result.CertificateName)
+		} else {
+			fmt.Printf("Not trusted: %s (checked %d active certificates)\n", result.Reason, result.Checked)
+		}
+	}
+	if !result.Trusted {
+		return fmt.Errorf("client certificate does not chain to an active organization certificate")
+	}
+	return nil
+}
+
 func modifyCertificate(ctx context.Context, cmd *cli.Command) error {
 	client := newClient(ctx, cmd)
 
@@ -131,3 +131,14 @@ func captureOutput(f func()) string {
 	_ = r.Close()
 	return buf.String()
 }
+
+// setConfirmInput makes confirmation prompts read answers from input and discards
+// the prompts themselves. It returns a function that restores the originals.
+func setConfirmInput(input string) func() {
+	oldInput, oldOutput := confirmInput, confirmOutput
+	confirmInput = strings.NewReader(input)
+	confirmOutput = io.Discard
+	return func() {
+		confirmInput, confirmOutput = oldInput, oldOutput
+	}
+}
@@ -29,6 +29,9 @@ func main() {
 			cmd.ProjectAPIKeysCommand(),
 			cmd.ProjectRateLimitsCommand(),
 			cmd.UsageCommand(),
+			cmd.ReportCommand(),
+			cmd.BudgetCommand(),
+			cmd.SearchCommand(),
 		},
 		Flags: []cli.Flag{
 			&cli.StringFlag{
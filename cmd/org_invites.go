@@ -2,8 +2,11 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"time"
 
+	openaiorgs "github.com/klauern/openai-orgs"
 	"github.com/urfave/cli/v3"
 )
 
@@ -16,6 +19,9 @@ func InvitesCommand() *cli.Command {
 			createInviteCommand(),
 			retrieveInviteCommand(),
 			deleteInviteCommand(),
+			pruneInvitesCommand(),
+			resendInvitesCommand(),
+			expiringInvitesCommand(),
 		},
 	}
 }
@@ -34,9 +40,16 @@ func listInvitesCommand() *cli.Command {
 
 func createInviteCommand() *cli.Command {
 	return &cli.Command{
-		Name:   "create",
-		Usage:  "Create a new invite",
-		Flags:  []cli.Flag{emailFlag, roleFlag},
+		Name:  "create",
+		Usage: "Create a new invite",
+		Flags: []cli.Flag{
+			emailFlag,
+			roleFlag,
+			&cli.StringSliceFlag{
+				Name:  "project",
+				Usage: "Project to join on acceptance, as project_id:role (can be repeated)",
+			},
+		},
 		Action: createInvite,
 	}
 }
@@ -61,6 +74,53 @@ func deleteInviteCommand() *cli.Command {
 	}
 }
 
+func pruneInvitesCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "prune",
+		Usage: "Delete expired invites, and optionally pending invites older than a given age",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "older-than",
+				Usage: "Also delete pending invites created longer ago than this (e.g., 14d, 72h)",
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Show the invites that would be deleted without deleting them",
+			},
+		},
+		Action: pruneInvites,
+	}
+}
+
+func resendInvitesCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "resend",
+		Usage: "Recreate expired invites with the same email and role",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Show the invites that would be resent without changing them",
+			},
+		},
+		Action: resendInvites,
+	}
+}
+
+func expiringInvitesCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "expiring",
+		Usage: "List pending invites that expire soon",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "within",
+				Usage: "Time window to look ahead (e.g., 48h, 3d)",
+				Value: "48h",
+			},
+		},
+		Action: listExpiringInvites,
+	}
+}
+
 func listInvites(ctx context.Context, cmd *cli.Command) error {
 	client := newClient(ctx, cmd)
 
@@ -96,11 +156,21 @@ func listInvites(ctx context.Context, cmd *cli.Command) error {
 }
 
 func createInvite(ctx context.Context, cmd *cli.Command) error {
+	var projects []openaiorgs.ProjectAssignment
+	for _, value := range cmd.StringSlice("project") {
+		assignments, err := openaiorgs.ParseProjectAssignments(value)
+		if err != nil {
+			return fmt.Errorf("invalid --project: %w", err)
+		}
+		projects = append(projects, assignments...)
+	}
+
 	client := newClient(ctx, cmd)
 
-	invite, err := client.CreateInvite(
+	invite, err := client.CreateInviteWithProjects(
 		cmd.String("email"),
 		cmd.String("role"),
+		projects,
 	)
 	if err != nil {
 		return wrapError("create invite", err)
@@ -115,6 +185,7 @@ func createInvite(ctx context.Context, cmd *cli.Command) error {
 		invite.CreatedAt.String(),
 		invite.ExpiresAt.String(),
 	)
+	printInviteProjects(invite)
 
 	return nil
 }
@@ -136,6 +207,7 @@ func retrieveInvite(ctx context.Context, cmd *cli.Command) error {
 		invite.CreatedAt.String(),
 		invite.ExpiresAt.String(),
 	)
+	printInviteProjects(invite)
 
 	return nil
 }
@@ -151,3 +223,132 @@ func deleteInvite(ctx context.Context, cmd *cli.Command) error {
 	fmt.Printf("Invite %s deleted successfully\n", cmd.String("id"))
 	return nil
 }
+
+func pruneInvites(ctx context.Context, cmd *cli.Command) error {
+	var olderThan time.Duration
+	if value := cmd.String("older-than"); value != "" {
+		d, err := parseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid --older-than: %w", err)
+		}
+		olderThan = d
+	}
+
+	client := newClient(ctx, cmd)
+	actions, err := openaiorgs.PruneInvites(client, openaiorgs.InviteLifecycleOptions{
+		OlderThan: olderThan,
+		DryRun:    cmd.Bool("dry-run"),
+	})
+	if err != nil {
+		return wrapError("prune invites", err)
+	}
+	return printInviteActions(cmd, actions)
+}
+
+func resendInvites(ctx context.Context, cmd *cli.Command) error {
+	client := newClient(ctx, cmd)
+	actions, err := openaiorgs.ResendExpiredInvites(client, openaiorgs.InviteLifecycleOptions{
+		DryRun: cmd.Bool("dry-run"),
+	})
+	if err != nil {
+		return wrapError("resend invites", err)
+	}
+	return printInviteActions(cmd, actions)
+}
+
+func listExpiringInvites(ctx context.Context, cmd *cli.Command) error {
+	within, err := parseDuration(cmd.String("within"))
+	if err != nil {
+		return fmt.Errorf("invalid --within: %w", err)
+	}
+
+	client := newClient(ctx, cmd)
+	invites, err := openaiorgs.ExpiringInvites(client, within, time.Time{})
+	if err != nil {
+		return wrapError("list expiring invites", err)
+	}
+
+	if cmd.String("output") == OutputFormatJSON {
+		data, err := json.MarshalIndent(invites, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal invites: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(invites) == 0 {
+		fmt.Printf("No pending invites expire within %s\n", cmd.String("within"))
+		return nil
+	}
+	data := TableData{
+		Headers: []string{"ID", "Email", "Role", "Created At", "Expires At", "Expires In"},
+		Rows:    make([][]string, len(invites)),
+	}
+	for i, invite := range invites {
+		data.Rows[i] = []string{
+			invite.ID,
+			invite.Email,
+			invite.Role,
+			invite.CreatedAt.String(),
+			invite.ExpiresAt.String(),
+			time.Until(invite.ExpiresAt.Time()).Round(time.Minute).String(),
+		}
+	}
+	printTableData(data)
+	return nil
+}
+
+// printInviteProjects lists the projects an invite grants access to, if any.
+func printInviteProjects(invite *openaiorgs.Invite) {
+	if len(invite.Projects) == 0 {
+		return
+	}
+	fmt.Printf("Projects:\n")
+	for _, project := range invite.Projects {
+		fmt.Printf("  %s (%s)\n", project.ProjectID, project.Role)
+	}
+}
+
+// printInviteActions prints the outcome of a prune or resend and returns an error
+// if any invite could not be processed.
+func printInviteActions(cmd *cli.Command, actions []openaiorgs.InviteAction) error {
+	if cmd.String("output") == OutputFormatJSON {
+		data, err := json.MarshalIndent(actions, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal invite actions: %w", err)
+		}
+		fmt.Println(string(data))
+	} else if len(actions) == 0 {
+		fmt.Println("No matching invites found")
+	} else {
+		data := TableData{
+			Headers: []string{"ID", "Email", "Role", "Action", "Reason", "Status", "New Invite", "Error"},
+			Rows:    make([][]string, len(actions)),
+		}
+		for i, action := range actions {
+			data.Rows[i] = []string{
+				action.InviteID,
+				action.Email,
+				action.Role,
+				action.Action,
+				action.Reason,
+				action.Status,
+				action.NewInviteID,
+				action.Error,
+			}
+		}
+		printTableData(data)
+	}
+
+	failed := 0
+	for _, action := range actions {
+		if action.Status == openaiorgs.InviteActionFailed {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d invite(s) failed", failed, len(actions))
+	}
+	return nil
+}
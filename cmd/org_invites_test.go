@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"encoding/json"
 	"strings"
 	"testing"
 	"time"
@@ -153,6 +154,46 @@ func TestCreateInviteCommand(t *testing.T) {
 			t.Error("Expected error, got nil")
 		}
 	})
+
+	t.Run("with projects", func(t *testing.T) {
+		h := newCmdTestHelper(t)
+		defer h.cleanup()
+
+		invite := createMockInvite("inv_123", "alice@example.com", "reader", "pending", nil)
+		invite.Projects = []openaiorgs.ProjectAssignment{
+			{ProjectID: "proj_x", Role: "member"},
+			{ProjectID: "proj_y", Role: "owner"},
+		}
+		h.mockResponse("POST", "/organization/invites", 200, invite)
+
+		output := captureOutput(func() {
+			err := h.runCmd(InvitesCommand(), []string{
+				"invites", "create", "--email", "alice@example.com", "--role", "reader",
+				"--project", "proj_x:member", "--project", "proj_y:owner",
+			})
+			if err != nil {
+				t.Errorf("runCmd() error = %v", err)
+			}
+		})
+
+		for _, want := range []string{"Projects:", "proj_x (member)", "proj_y (owner)"} {
+			if !strings.Contains(output, want) {
+				t.Errorf("Expected output to contain %q, got: %s", want, output)
+			}
+		}
+		h.assertRequest("POST", "/organization/invites", 1)
+	})
+
+	t.Run("invalid project", func(t *testing.T) {
+		h := newCmdTestHelper(t)
+		defer h.cleanup()
+
+		err := h.runCmd(InvitesCommand(), []string{"invites", "create", "--email", "alice@example.com", "--role", "reader", "--project", "proj_x"})
+		if err == nil || !strings.Contains(err.Error(), "invalid --project") {
+			t.Errorf("Expected invalid --project error, got %v", err)
+		}
+		h.assertRequest("POST", "/organization/invites", 0)
+	})
 }
 
 func TestRetrieveInviteCommand(t *testing.T) {
@@ -223,3 +264,155 @@ func TestDeleteInviteCommand(t *testing.T) {
 		}
 	})
 }
+
+// lifecycleInvites returns one invite that has lapsed, one created two weeks ago that
+// is still valid for a day, and one fresh invite.
+func lifecycleInvites() openaiorgs.ListResponse[openaiorgs.Invite] {
+	now := time.Now()
+	return openaiorgs.ListResponse[openaiorgs.Invite]{
+		Object: "list",
+		Data: []openaiorgs.Invite{
+			{ID: "inv_lapsed", Email: "lapsed@example.com", Role: "reader", Status: "pending", CreatedAt: openaiorgs.UnixSeconds(now.Add(-8 * 24 * time.Hour)), ExpiresAt: openaiorgs.UnixSeconds(now.Add(-time.Hour))},
+			{ID: "inv_old", Email: "old@example.com", Role: "owner", Status: "pending", CreatedAt: openaiorgs.UnixSeconds(now.Add(-14 * 24 * time.Hour)), ExpiresAt: openaiorgs.UnixSeconds(now.Add(24 * time.Hour))},
+			{ID: "inv_fresh", Email: "fresh@example.com", Role: "reader", Status: "pending", CreatedAt: openaiorgs.UnixSeconds(now), ExpiresAt: openaiorgs.UnixSeconds(now.Add(7 * 24 * time.Hour))},
+		},
+	}
+}
+
+func TestPruneInvitesCommand(t *testing.T) {
+	t.Run("expired only", func(t *testing.T) {
+		h := newCmdTestHelper(t)
+		defer h.cleanup()
+
+		h.mockResponse("GET", "/organization/invites", 200, lifecycleInvites())
+		h.mockResponse("DELETE", "/organization/invites/inv_lapsed", 200, map[string]any{"deleted": true})
+
+		output := captureOutput(func() {
+			if err := h.runCmd(InvitesCommand(), []string{"invites", "prune"}); err != nil {
+				t.Errorf("runCmd() error = %v", err)
+			}
+		})
+
+		if !strings.Contains(output, "inv_lapsed | lapsed@example.com | reader | delete | expired | done") {
+			t.Errorf("Expected pruned invite in output, got: %s", output)
+		}
+		if strings.Contains(output, "inv_old") {
+			t.Errorf("Did not expect inv_old to be pruned, got: %s", output)
+		}
+		h.assertRequest("DELETE", "/organization/invites/inv_lapsed", 1)
+	})
+
+	t.Run("older than dry run", func(t *testing.T) {
+		h := newCmdTestHelper(t)
+		defer h.cleanup()
+
+		h.mockResponse("GET", "/organization/invites", 200, lifecycleInvites())
+
+		output := captureOutput(func() {
+			if err := h.runCmd(InvitesCommand(), []string{"invites", "prune", "--older-than", "7d", "--dry-run"}); err != nil {
+				t.Errorf("runCmd() error = %v", err)
+			}
+		})
+
+		for _, want := range []string{"inv_lapsed", "inv_old | old@example.com | owner | delete | pending for more than 168h0m0s | planned"} {
+			if !strings.Contains(output, want) {
+				t.Errorf("Expected output to contain %q, got: %s", want, output)
+			}
+		}
+		h.assertRequest("DELETE", "/organization/invites/inv_lapsed", 0)
+		h.assertRequest("DELETE", "/organization/invites/inv_old", 0)
+	})
+
+	t.Run("invalid duration", func(t *testing.T) {
+		h := newCmdTestHelper(t)
+		defer h.cleanup()
+
+		err := h.runCmd(InvitesCommand(), []string{"invites", "prune", "--older-than", "soon"})
+		if err == nil || !strings.Contains(err.Error(), "invalid --older-than") {
+			t.Errorf("Expected invalid --older-than error, got %v", err)
+		}
+	})
+
+	t.Run("delete failure", func(t *testing.T) {
+		h := newCmdTestHelper(t)
+		defer h.cleanup()
+
+		h.mockResponse("GET", "/organization/invites", 200, lifecycleInvites())
+		h.mockResponse("DELETE", "/organization/invites/inv_lapsed", 500, map[string]string{"error": "boom"})
+
+		var err error
+		captureOutput(func() {
+			err = h.runCmd(InvitesCommand(), []string{"invites", "prune"})
+		})
+		if err == nil || !strings.Contains(err.Error(), "1 of 1 invite(s) failed") {
+			t.Errorf("Expected failure count error, got %v", err)
+		}
+	})
+}
+
+func TestResendInvitesCommand(t *testing.T) {
+	h := newCmdTestHelper(t)
+	defer h.cleanup()
+
+	h.mockResponse("GET", "/organization/invites", 200, lifecycleInvites())
+	h.mockResponse("DELETE", "/organization/invites/inv_lapsed", 200, map[string]any{"deleted": true})
+	h.mockResponse("POST", "/organization/invites", 200, openaiorgs.Invite{ID: "inv_new", Email: "lapsed@example.com", Role: "reader", Status: "pending"})
+
+	var err error
+	output := captureOutput(func() {
+		err = h.runCmd(InvitesCommand(), []string{"--output", "json", "invites", "resend"})
+	})
+	if err != nil {
+		t.Fatalf("runCmd() error = %v", err)
+	}
+
+	var actions []openaiorgs.InviteAction
+	if err := json.Unmarshal([]byte(output), &actions); err != nil {
+		t.Fatalf("failed to parse JSON output: %v\n%s", err, output)
+	}
+	if len(actions) != 1 || actions[0].InviteID != "inv_lapsed" || actions[0].NewInviteID != "inv_new" || actions[0].Status != openaiorgs.InviteActionDone {
+		t.Errorf("unexpected actions: %+v", actions)
+	}
+	h.assertRequest("POST", "/organization/invites", 1)
+}
+
+func TestExpiringInvitesCommand(t *testing.T) {
+	t.Run("default window", func(t *testing.T) {
+		h := newCmdTestHelper(t)
+		defer h.cleanup()
+
+		h.mockResponse("GET", "/organization/invites", 200, lifecycleInvites())
+
+		output := captureOutput(func() {
+			if err := h.runCmd(InvitesCommand(), []string{"invites", "expiring"}); err != nil {
+				t.Errorf("runCmd() error = %v", err)
+			}
+		})
+
+		if !strings.Contains(output, "ID | Email | Role | Created At | Expires At | Expires In") || !strings.Contains(output, "inv_old") {
+			t.Errorf("Expected inv_old in output, got: %s", output)
+		}
+		for _, unwanted := range []string{"inv_lapsed", "inv_fresh"} {
+			if strings.Contains(output, unwanted) {
+				t.Errorf("Did not expect %s in output, got: %s", unwanted, output)
+			}
+		}
+	})
+
+	t.Run("nothing expiring", func(t *testing.T) {
+		h := newCmdTestHelper(t)
+		defer h.cleanup()
+
+		h.mockResponse("GET", "/organization/invites", 200, lifecycleInvites())
+
+		output := captureOutput(func() {
+			if err := h.runCmd(InvitesCommand(), []string{"invites", "expiring", "--within", "1h"}); err != nil {
+				t.Errorf("runCmd() error = %v", err)
+			}
+		})
+
+		if !strings.Contains(output, "No pending invites expire within 1h") {
+			t.Errorf("Expected empty message, got: %s", output)
+		}
+	})
+}
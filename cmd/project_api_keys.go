@@ -4,18 +4,24 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
+	openaiorgs "github.com/klauern/openai-orgs"
 	"github.com/urfave/cli/v3"
 )
 
 func ProjectAPIKeysCommand() *cli.Command {
 	return &cli.Command{
-		Name:  "project-api-keys",
-		Usage: "Manage project API keys",
+		Name:    "project-api-keys",
+		Aliases: []string{"api-keys"},
+		Usage:   "Manage project API keys",
 		Commands: []*cli.Command{
 			listProjectAPIKeysCommand(),
 			retrieveProjectAPIKeyCommand(),
 			deleteProjectAPIKeyCommand(),
+			auditProjectAPIKeysCommand(),
 		},
 	}
 }
@@ -57,6 +63,49 @@ func deleteProjectAPIKeyCommand() *cli.Command {
 	}
 }
 
+func auditProjectAPIKeysCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "audit",
+		Usage: "Find unused, old and orphaned API keys across all projects",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:  "unused-days",
+				Usage: "Flag keys with no completions usage in this many days",
+				Value: 30,
+			},
+			&cli.IntFlag{
+				Name:  "max-age-days",
+				Usage: "Flag keys created more than this many days ago",
+				Value: 365,
+			},
+			&cli.BoolFlag{
+				Name:  "include-archived",
+				Usage: "Include archived projects",
+			},
+			&cli.IntFlag{
+				Name:  "concurrency",
+				Usage: "Number of projects to scan in parallel",
+				Value: openaiorgs.DefaultInventoryConcurrency,
+			},
+			&cli.BoolFlag{
+				Name:  "revoke",
+				Usage: "Delete flagged keys matching --revoke-findings after confirmation",
+			},
+			&cli.StringSliceFlag{
+				Name:  "revoke-findings",
+				Usage: "Finding kinds to revoke with --revoke (owner_left, service_account_deleted, unused, too_old)",
+				Value: slices.Clone(openaiorgs.DefaultAPIKeyRevokeFindings),
+			},
+			&cli.BoolFlag{
+				Name:    "yes",
+				Aliases: []string{"y"},
+				Usage:   "Skip the confirmation prompt when revoking",
+			},
+		},
+		Action: auditProjectAPIKeys,
+	}
+}
+
 func listProjectAPIKeys(ctx context.Context, cmd *cli.Command) error {
 	client := newClient(ctx, cmd)
 
@@ -141,3 +190,120 @@ func deleteProjectAPIKey(ctx context.Context, cmd *cli.Command) error {
 	fmt.Printf("Successfully deleted project API key %s\n", cmd.String("id"))
 	return nil
 }
+
+func auditProjectAPIKeys(ctx context.Context, cmd *cli.Command) error {
+	unusedDays := cmd.Int("unused-days")
+	maxAgeDays := cmd.Int("max-age-days")
+	if unusedDays <= 0 || maxAgeDays <= 0 {
+		return fmt.Errorf("--unused-days and --max-age-days must be greater than 0")
+	}
+	revokeFindings := cmd.StringSlice("revoke-findings")
+	for _, kind := range revokeFindings {
+		switch kind {
+		case openaiorgs.APIKeyFindingOwnerLeft, openaiorgs.APIKeyFindingServiceAccountDeleted,
+			openaiorgs.APIKeyFindingUnused, openaiorgs.APIKeyFindingTooOld:
+		default:
+			return fmt.Errorf("invalid --revoke-findings value %q", kind)
+		}
+	}
+	jsonOutput := cmd.String("output") == OutputFormatJSON
+	// The flagged keys are only printed in pretty mode, so they cannot be reviewed at a prompt.
+	if jsonOutput && cmd.Bool("revoke") && !cmd.Bool("yes") {
+		return fmt.Errorf("--yes is required with --revoke and --output %s", OutputFormatJSON)
+	}
+
+	client := newClient(ctx, cmd)
+	audit, err := openaiorgs.AuditAPIKeys(client, openaiorgs.APIKeyAuditOptions{
+		UnusedAfter:     time.Duration(unusedDays) * 24 * time.Hour,
+		MaxAge:          time.Duration(maxAgeDays) * 24 * time.Hour,
+		IncludeArchived: cmd.Bool("include-archived"),
+		Concurrency:     int(cmd.Int("concurrency")),
+	})
+	if err != nil {
+		return wrapError("audit API keys", err)
+	}
+	remediations := audit.Remediations()
+
+	if !jsonOutput {
+		printAPIKeyRemediations(audit, remediations)
+	}
+
+	var revocations []openaiorgs.APIKeyRevocation
+	toRevoke := openaiorgs.FilterAPIKeyFindings(remediations, revokeFindings)
+	if cmd.Bool("revoke") && len(toRevoke) > 0 {
+		prompt := fmt.Sprintf("Revoke %d API key(s) flagged %s?", len(toRevoke), strings.Join(revokeFindings, ", "))
+		if cmd.Bool("yes") || confirm(prompt) {
+			revocations = openaiorgs.RevokeAPIKeys(client, toRevoke)
+		} else {
+			fmt.Println("Aborted.")
+		}
+	}
+
+	if jsonOutput {
+		data, err := json.MarshalIndent(struct {
+			*openaiorgs.APIKeyAudit
+			Remediations []openaiorgs.APIKeyAuditEntry `json:"remediations"`
+			Revocations  []openaiorgs.APIKeyRevocation `json:"revocations,omitempty"`
+		}{audit, remediations, revocations}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal API key audit: %w", err)
+		}
+		fmt.Println(string(data))
+	} else if len(revocations) > 0 {
+		fmt.Println()
+		data := TableData{Headers: []string{"Project", "Key ID", "Name", "Result"}}
+		for _, r := range revocations {
+			result := "revoked"
+			if !r.Revoked {
+				result = "failed: " + r.Error
+			}
+			data.Rows = append(data.Rows, []string{r.ProjectID, r.KeyID, r.KeyName, result})
+		}
+		printTableData(data)
+	}
+
+	failed := 0
+	for _, r := range revocations {
+		if !r.Revoked {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to revoke %d of %d API key(s)", failed, len(revocations))
+	}
+	return nil
+}
+
+func printAPIKeyRemediations(audit *openaiorgs.APIKeyAudit, remediations []openaiorgs.APIKeyAuditEntry) {
+	fmt.Printf("Audited %d API key(s); %d need attention\n", len(audit.Keys), len(remediations))
+	if len(remediations) == 0 {
+		return
+	}
+	fmt.Println()
+
+	data := TableData{
+		Headers: []string{"Priority", "Project", "Key ID", "Name", "Owner", "Created At", "Last Used", "Findings"},
+		Rows:    make([][]string, len(remediations)),
+	}
+	for i, key := range remediations {
+		owner := key.OwnerName
+		if key.OwnerType != "" {
+			owner = fmt.Sprintf("%s (%s)", key.OwnerName, key.OwnerType)
+		}
+		lastUsed := "never"
+		if key.LastUsed != nil {
+			lastUsed = key.LastUsed.UTC().Format(time.DateOnly)
+		}
+		data.Rows[i] = []string{
+			fmt.Sprintf("%d", key.Priority),
+			key.ProjectName,
+			key.KeyID,
+			key.KeyName,
+			owner,
+			key.CreatedAt.UTC().Format(time.DateOnly),
+			lastUsed,
+			strings.Join(key.Findings, ", "),
+		}
+	}
+	printTableData(data)
+}
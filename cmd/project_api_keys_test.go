@@ -231,3 +231,175 @@ func TestDeleteProjectAPIKeyCommand(t *testing.T) {
 		})
 	}
 }
+
+// mockAPIKeyAudit sets up one project with a healthy key (owned by user_1, used recently)
+// and a key owned by a user who has left the organization.
+func mockAPIKeyAudit(h *cmdTestHelper) {
+	healthy := createMockProjectApiKey("key_ok", "healthy")
+	orphaned := createMockProjectApiKey("key_orphan", "orphaned")
+	orphaned.Owner.User = &openaiorgs.User{ID: "user_gone", Email: "gone@example.com"}
+
+	h.mockResponse("GET", "/organization/users", 200, openaiorgs.ListResponse[openaiorgs.User]{
+		Object: "list",
+		Data:   []openaiorgs.User{{ID: "user_1", Email: "test@example.com"}},
+	})
+	h.mockResponse("GET", "/organization/usage/completions", 200, openaiorgs.CompletionsUsageResponse{
+		Object: "page",
+		Data: []openaiorgs.CompletionsUsageBucket{{
+			StartTime: time.Now().Add(-24 * time.Hour).Unix(),
+			Results: []openaiorgs.CompletionsUsageResult{
+				{APIKeyID: "key_ok", NumModelRequests: 4},
+				{APIKeyID: "key_orphan", NumModelRequests: 1},
+			},
+		}},
+	})
+	h.mockResponse("GET", "/organization/projects", 200, openaiorgs.ListResponse[openaiorgs.Project]{
+		Object: "list",
+		Data:   []openaiorgs.Project{{ID: "proj_1", Name: "One"}},
+	})
+	h.mockResponse("GET", "/organization/projects/proj_1/users", 200, openaiorgs.ListResponse[openaiorgs.ProjectUser]{Object: "list"})
+	h.mockResponse("GET", "/organization/projects/proj_1/service_accounts", 200, openaiorgs.ListResponse[openaiorgs.ProjectServiceAccount]{Object: "list"})
+	h.mockResponse("GET", "/organization/projects/proj_1/api_keys", 200, openaiorgs.ListResponse[openaiorgs.ProjectApiKey]{
+		Object: "list",
+		Data:   []openaiorgs.ProjectApiKey{healthy, orphaned},
+	})
+}
+
+func TestAuditProjectAPIKeysCommand(t *testing.T) {
+	t.Run("report only", func(t *testing.T) {
+		h := newCmdTestHelper(t)
+		defer h.cleanup()
+		mockAPIKeyAudit(h)
+
+		output := captureOutput(func() {
+			if err := h.runCmd(ProjectAPIKeysCommand(), []string{"api-keys", "audit"}); err != nil {
+				t.Errorf("runCmd() error = %v", err)
+			}
+		})
+
+		for _, want := range []string{
+			"Audited 2 API key(s); 1 need attention",
+			"Priority | Project | Key ID | Name | Owner | Created At | Last Used | Findings",
+			"1 | One | key_orphan | orphaned | gone@example.com (user)",
+			"owner_left",
+		} {
+			if !strings.Contains(output, want) {
+				t.Errorf("Expected output to contain %q, got: %s", want, output)
+			}
+		}
+		if strings.Contains(output, "key_ok") {
+			t.Errorf("Did not expect healthy key in remediation list, got: %s", output)
+		}
+		h.assertRequest("DELETE", "/organization/projects/proj_1/api_keys/key_orphan", 0)
+	})
+
+	t.Run("revoke confirmed", func(t *testing.T) {
+		h := newCmdTestHelper(t)
+		defer h.cleanup()
+		mockAPIKeyAudit(h)
+		h.mockResponse("DELETE", "/organization/projects/proj_1/api_keys/key_orphan", 200, map[string]any{"deleted": true})
+		defer setConfirmInput("y\n")()
+
+		output := captureOutput(func() {
+			if err := h.runCmd(ProjectAPIKeysCommand(), []string{"api-keys", "audit", "--revoke"}); err != nil {
+				t.Errorf("runCmd() error = %v", err)
+			}
+		})
+
+		if !strings.Contains(output, "proj_1 | key_orphan | orphaned | revoked") {
+			t.Errorf("Expected revoke result, got: %s", output)
+		}
+		h.assertRequest("DELETE", "/organization/projects/proj_1/api_keys/key_orphan", 1)
+		h.assertRequest("DELETE", "/organization/projects/proj_1/api_keys/key_ok", 0)
+	})
+
+	t.Run("revoke declined", func(t *testing.T) {
+		h := newCmdTestHelper(t)
+		defer h.cleanup()
+		mockAPIKeyAudit(h)
+		defer setConfirmInput("n\n")()
+
+		output := captureOutput(func() {
+			if err := h.runCmd(ProjectAPIKeysCommand(), []string{"api-keys", "audit", "--revoke"}); err != nil {
+				t.Errorf("runCmd() error = %v", err)
+			}
+		})
+
+		if !strings.Contains(output, "Aborted.") {
+			t.Errorf("Expected abort message, got: %s", output)
+		}
+		h.assertRequest("DELETE", "/organization/projects/proj_1/api_keys/key_orphan", 0)
+	})
+
+	t.Run("json with revoke failure", func(t *testing.T) {
+		h := newCmdTestHelper(t)
+		defer h.cleanup()
+		mockAPIKeyAudit(h)
+		h.mockResponse("DELETE", "/organization/projects/proj_1/api_keys/key_orphan", 500, map[string]string{"error": "boom"})
+
+		var err error
+		output := captureOutput(func() {
+			err = h.runCmd(ProjectAPIKeysCommand(), []string{"--output", "json", "api-keys", "audit", "--revoke", "--yes"})
+		})
+		if err == nil || !strings.Contains(err.Error(), "failed to revoke 1 of 1 API key(s)") {
+			t.Errorf("Expected revoke failure error, got %v", err)
+		}
+
+		var result struct {
+			Keys         []openaiorgs.APIKeyAuditEntry `json:"keys"`
+			Remediations []openaiorgs.APIKeyAuditEntry `json:"remediations"`
+			Revocations  []openaiorgs.APIKeyRevocation `json:"revocations"`
+		}
+		if err := json.Unmarshal([]byte(output), &result); err != nil {
+			t.Fatalf("failed to parse JSON output: %v\n%s", err, output)
+		}
+		if len(result.Keys) != 2 || len(result.Remediations) != 1 || len(result.Revocations) != 1 || result.Revocations[0].Revoked {
+			t.Errorf("unexpected JSON result: %+v", result)
+		}
+	})
+
+	t.Run("revoke only matching findings", func(t *testing.T) {
+		h := newCmdTestHelper(t)
+		defer h.cleanup()
+		mockAPIKeyAudit(h)
+
+		captureOutput(func() {
+			if err := h.runCmd(ProjectAPIKeysCommand(), []string{"api-keys", "audit", "--revoke", "--revoke-findings", "unused,too_old", "--yes"}); err != nil {
+				t.Errorf("runCmd() error = %v", err)
+			}
+		})
+		h.assertRequest("DELETE", "/organization/projects/proj_1/api_keys/key_orphan", 0)
+	})
+
+	t.Run("invalid revoke findings", func(t *testing.T) {
+		h := newCmdTestHelper(t)
+		defer h.cleanup()
+
+		err := h.runCmd(ProjectAPIKeysCommand(), []string{"api-keys", "audit", "--revoke", "--revoke-findings", "stale"})
+		if err == nil || !strings.Contains(err.Error(), `invalid --revoke-findings value "stale"`) {
+			t.Errorf("Expected invalid finding error, got %v", err)
+		}
+	})
+
+	t.Run("json revoke requires yes", func(t *testing.T) {
+		h := newCmdTestHelper(t)
+		defer h.cleanup()
+		mockAPIKeyAudit(h)
+
+		err := h.runCmd(ProjectAPIKeysCommand(), []string{"--output", "json", "api-keys", "audit", "--revoke"})
+		if err == nil || !strings.Contains(err.Error(), "--yes is required") {
+			t.Errorf("Expected --yes error, got %v", err)
+		}
+		h.assertRequest("GET", "/organization/projects", 0)
+	})
+
+	t.Run("invalid thresholds", func(t *testing.T) {
+		h := newCmdTestHelper(t)
+		defer h.cleanup()
+
+		err := h.runCmd(ProjectAPIKeysCommand(), []string{"api-keys", "audit", "--unused-days", "0"})
+		if err == nil {
+			t.Error("Expected error, got nil")
+		}
+	})
+}
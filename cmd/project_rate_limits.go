@@ -4,8 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/urfave/cli/v3"
 
@@ -19,6 +22,11 @@ func ProjectRateLimitsCommand() *cli.Command {
 		Commands: []*cli.Command{
 			listProjectRateLimitsCommand(),
 			modifyProjectRateLimitsCommand(),
+			applyRateLimitProfileCommand(),
+			diffProjectRateLimitsCommand(),
+			copyProjectRateLimitsCommand(),
+			scaleProjectRateLimitsCommand(),
+			headroomProjectRateLimitsCommand(),
 		},
 	}
 }
@@ -84,6 +92,147 @@ func modifyProjectRateLimitsCommand() *cli.Command {
 	}
 }
 
+func applyRateLimitProfileCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "apply-profile",
+		Usage: "Apply a named set of per-model rate limits to many projects",
+		Flags: append([]cli.Flag{
+			&cli.StringFlag{
+				Name:     "profile",
+				Usage:    "Name of the profile to apply",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "profiles-file",
+				Usage: "YAML or JSON file mapping profile names to models to limits",
+				Value: "rate-limit-profiles.yaml",
+			},
+		}, multiProjectFlags()...),
+		Action: applyRateLimitProfile,
+	}
+}
+
+func scaleProjectRateLimitsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "scale",
+		Usage: "Set rate limits to a percentage of their current values across many projects",
+		Flags: append([]cli.Flag{
+			&cli.FloatFlag{
+				Name:     "percent",
+				Usage:    "New limit as a percentage of the current one (e.g., 50 halves the limits)",
+				Required: true,
+			},
+			&cli.StringSliceFlag{
+				Name:  "model",
+				Usage: "Only scale this model's rate limits (repeatable; default: every model)",
+			},
+			&cli.StringSliceFlag{
+				Name:  "field",
+				Usage: "Only scale this field, e.g. max_tokens_per_1_minute (repeatable; default: every field)",
+			},
+		}, multiProjectFlags()...),
+		Action: scaleProjectRateLimits,
+	}
+}
+
+func headroomProjectRateLimitsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "headroom",
+		Usage: "Compare per-minute usage peaks with rate limits and recommend limits",
+		Flags: []cli.Flag{
+			projectIDFlag,
+			&cli.StringFlag{
+				Name:  "window",
+				Usage: "How far back to read usage (e.g., 24h, 7d)",
+				Value: "24h",
+			},
+			&cli.FloatFlag{
+				Name:  "target-utilization",
+				Usage: "Share of the recommended limit the observed peak should use (0-1)",
+				Value: openaiorgs.DefaultHeadroomTargetUtilization,
+			},
+		},
+		Action: headroomProjectRateLimits,
+	}
+}
+
+// multiProjectFlags returns the flags shared by commands that change rate limits in many projects.
+func multiProjectFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:  "project-ids",
+			Usage: "Projects to change (comma-separated or repeated)",
+		},
+		&cli.BoolFlag{
+			Name:  "all-projects",
+			Usage: "Change every active project",
+		},
+		&cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "Show the changes without making them",
+		},
+		&cli.BoolFlag{
+			Name:    "yes",
+			Aliases: []string{"y"},
+			Usage:   "Skip the confirmation prompt",
+		},
+		&cli.IntFlag{
+			Name:  "concurrency",
+			Usage: "Number of projects to read in parallel",
+			Value: openaiorgs.DefaultInventoryConcurrency,
+		},
+	}
+}
+
+func diffProjectRateLimitsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "diff",
+		Usage: "Show per-model rate limit differences between two projects",
+		Flags: []cli.Flag{
+			fromProjectFlag(),
+			toProjectFlag(),
+		},
+		Action: diffProjectRateLimits,
+	}
+}
+
+func copyProjectRateLimitsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "copy",
+		Usage: "Make a project's rate limits match another project's",
+		Flags: []cli.Flag{
+			fromProjectFlag(),
+			toProjectFlag(),
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Show the changes without making them",
+			},
+			&cli.BoolFlag{
+				Name:    "yes",
+				Aliases: []string{"y"},
+				Usage:   "Skip the confirmation prompt",
+			},
+		},
+		Action: copyProjectRateLimits,
+	}
+}
+
+func fromProjectFlag() *cli.StringFlag {
+	return &cli.StringFlag{
+		Name:     "from",
+		Usage:    "ID of the source project",
+		Required: true,
+	}
+}
+
+func toProjectFlag() *cli.StringFlag {
+	return &cli.StringFlag{
+		Name:     "to",
+		Usage:    "ID of the target project",
+		Required: true,
+	}
+}
+
 func printProjectRateLimitsJSON(projectRateLimits *openaiorgs.ListResponse[openaiorgs.ProjectRateLimit]) error {
 	marshalled, err := json.Marshal(projectRateLimits.Data)
 	if err != nil {
@@ -206,32 +355,39 @@ func validateModifyProjectRateLimitContext(_ context.Context, cmd *cli.Command)
 		return errors.New("rate-limit-id is required")
 	}
 
-	// At least one rate limit field must be set
-	if cmd.Int("max-requests-per-1-minute") == 0 &&
-		cmd.Int("max-tokens-per-1-minute") == 0 &&
-		cmd.Int("max-images-per-1-minute") == 0 &&
-		cmd.Int("max-audio-megabytes-per-1-minute") == 0 &&
-		cmd.Int("max-requests-per-1-day") == 0 &&
-		cmd.Int("batch-1-day-max-input-tokens") == 0 {
-		return errors.New("must set at least one rate limit field to modify")
+	// At least one rate limit field must be set; zero is a valid value.
+	if err := rateLimitFieldsFromFlags(cmd).Validate(); err != nil {
+		return fmt.Errorf("invalid rate limit fields: %w", err)
 	}
 
 	return nil
 }
 
+// rateLimitFieldsFromFlags returns the rate limit fields whose flags were given,
+// so that an explicit zero is sent while omitted flags are left unchanged.
+func rateLimitFieldsFromFlags(cmd *cli.Command) openaiorgs.ProjectRateLimitRequestFields {
+	optional := func(name string) *int64 {
+		if !cmd.IsSet(name) {
+			return nil
+		}
+		return openaiorgs.Int64Ptr(int64(cmd.Int(name)))
+	}
+	return openaiorgs.ProjectRateLimitRequestFields{
+		MaxRequestsPer1Minute:       optional("max-requests-per-1-minute"),
+		MaxTokensPer1Minute:         optional("max-tokens-per-1-minute"),
+		MaxImagesPer1Minute:         optional("max-images-per-1-minute"),
+		MaxAudioMegabytesPer1Minute: optional("max-audio-megabytes-per-1-minute"),
+		MaxRequestsPer1Day:          optional("max-requests-per-1-day"),
+		Batch1DayMaxInputTokens:     optional("batch-1-day-max-input-tokens"),
+	}
+}
+
 func modifyProjectRateLimit(ctx context.Context, cmd *cli.Command) error {
 	if err := validateModifyProjectRateLimitContext(ctx, cmd); err != nil {
 		return err
 	}
 	client := newClient(ctx, cmd)
-	fields := openaiorgs.ProjectRateLimitRequestFields{
-		MaxRequestsPer1Minute:       int64(cmd.Int("max-requests-per-1-minute")),
-		MaxTokensPer1Minute:         int64(cmd.Int("max-tokens-per-1-minute")),
-		MaxImagesPer1Minute:         int64(cmd.Int("max-images-per-1-minute")),
-		MaxAudioMegabytesPer1Minute: int64(cmd.Int("max-audio-megabytes-per-1-minute")),
-		MaxRequestsPer1Day:          int64(cmd.Int("max-requests-per-1-day")),
-		Batch1DayMaxInputTokens:     int64(cmd.Int("batch-1-day-max-input-tokens")),
-	}
+	fields := rateLimitFieldsFromFlags(cmd)
 	projectRateLimit, err := client.ModifyProjectRateLimit(
 		cmd.String("project-id"),
 		cmd.String("rate-limit-id"),
@@ -247,3 +403,263 @@ func modifyProjectRateLimit(ctx context.Context, cmd *cli.Command) error {
 		return printProjectRateLimitTable(projectRateLimit)
 	}
 }
+
+// selectedProjectIDs returns the projects chosen with --project-ids or --all-projects.
+func selectedProjectIDs(client *openaiorgs.Client, cmd *cli.Command) ([]string, error) {
+	ids := cmd.StringSlice("project-ids")
+	all := cmd.Bool("all-projects")
+	switch {
+	case all && len(ids) > 0:
+		return nil, errors.New("use either --project-ids or --all-projects, not both")
+	case len(ids) > 0:
+		return ids, nil
+	case !all:
+		return nil, errors.New("one of --project-ids or --all-projects is required")
+	}
+
+	projects, err := openaiorgs.ListAll(func(after string) (*openaiorgs.ListResponse[openaiorgs.Project], error) {
+		return client.ListProjects(100, after, false)
+	})
+	if err != nil {
+		return nil, wrapError("list projects", err)
+	}
+	ids = make([]string, len(projects))
+	for i, project := range projects {
+		ids[i] = project.ID
+	}
+	return ids, nil
+}
+
+func applyRateLimitProfile(ctx context.Context, cmd *cli.Command) error {
+	name := cmd.String("profile")
+	profile, err := openaiorgs.LoadRateLimitProfile(cmd.String("profiles-file"), name)
+	if err != nil {
+		return err
+	}
+
+	client := newClient(ctx, cmd)
+	projectIDs, err := selectedProjectIDs(client, cmd)
+	if err != nil {
+		return err
+	}
+
+	plans := openaiorgs.PlanRateLimitProfile(client, projectIDs, profile, int(cmd.Int("concurrency")))
+	return runRateLimitPlans(cmd, client, "already matches "+name, plans)
+}
+
+func scaleProjectRateLimits(ctx context.Context, cmd *cli.Command) error {
+	client := newClient(ctx, cmd)
+	projectIDs, err := selectedProjectIDs(client, cmd)
+	if err != nil {
+		return err
+	}
+
+	plans, err := openaiorgs.PlanRateLimitScale(client, projectIDs, openaiorgs.RateLimitScaleOptions{
+		Models:      cmd.StringSlice("model"),
+		Fields:      cmd.StringSlice("field"),
+		Percent:     cmd.Float("percent"),
+		Concurrency: int(cmd.Int("concurrency")),
+	})
+	if err != nil {
+		return err
+	}
+	return runRateLimitPlans(cmd, client, "no limits to scale", plans)
+}
+
+func headroomProjectRateLimits(ctx context.Context, cmd *cli.Command) error {
+	window, err := parseDuration(cmd.String("window"))
+	if err != nil {
+		return fmt.Errorf("invalid --window: %w", err)
+	}
+
+	client := newClient(ctx, cmd)
+	report, err := openaiorgs.AnalyzeRateLimitHeadroom(client, cmd.String("project-id"), openaiorgs.HeadroomOptions{
+		Window:            window,
+		TargetUtilization: cmd.Float("target-utilization"),
+	})
+	if err != nil {
+		return wrapError("analyze rate limit headroom", err)
+	}
+
+	if cmd.String("output") == OutputFormatJSON {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal headroom report: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	fmt.Printf("Rate limit headroom for %s, %s to %s (recommendations at %.0f%% peak utilization)\n\n",
+		report.ProjectID, report.Start.Format(time.RFC3339), report.End.Format(time.RFC3339), report.TargetUtilization*100)
+
+	data := TableData{Headers: []string{
+		"Model", "Active Min",
+		"RPM Limit", "Peak RPM", "P95 RPM", "RPM Used", "Rec. RPM",
+		"TPM Limit", "Peak TPM", "P95 TPM", "TPM Used", "Rec. TPM",
+	}}
+	for _, m := range report.Models {
+		data.Rows = append(data.Rows, []string{
+			m.Model,
+			strconv.Itoa(m.ActiveMinutes),
+			formatLimit(m.RateLimitID, m.MaxRequestsPer1Minute),
+			strconv.FormatInt(m.PeakRequestsPerMinute, 10),
+			strconv.FormatInt(m.P95RequestsPerMinute, 10),
+			formatUtilization(m.RateLimitID, m.RequestUtilization),
+			formatRecommendation(m.ActiveMinutes, m.RecommendedRequestsPerMinute),
+			formatLimit(m.RateLimitID, m.MaxTokensPer1Minute),
+			strconv.FormatInt(m.PeakTokensPerMinute, 10),
+			strconv.FormatInt(m.P95TokensPerMinute, 10),
+			formatUtilization(m.RateLimitID, m.TokenUtilization),
+			formatRecommendation(m.ActiveMinutes, m.RecommendedTokensPerMinute),
+		})
+	}
+	printTableData(data)
+	return nil
+}
+
+func formatLimit(rateLimitID string, limit int64) string {
+	if rateLimitID == "" {
+		return "none"
+	}
+	return strconv.FormatInt(limit, 10)
+}
+
+func formatUtilization(rateLimitID string, utilization float64) string {
+	if rateLimitID == "" {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f%%", utilization*100)
+}
+
+func formatRecommendation(activeMinutes int, recommended int64) string {
+	if activeMinutes == 0 {
+		return "no usage"
+	}
+	return strconv.FormatInt(recommended, 10)
+}
+
+func diffProjectRateLimits(ctx context.Context, cmd *cli.Command) error {
+	client := newClient(ctx, cmd)
+	from, to := cmd.String("from"), cmd.String("to")
+
+	plan, err := openaiorgs.PlanRateLimitCopy(client, from, to)
+	if err != nil {
+		return wrapError("compare project rate limits", err)
+	}
+
+	if cmd.String("output") == OutputFormatJSON {
+		data, err := json.MarshalIndent(plan, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal rate limit diff: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	fmt.Printf("Changes to make %s match %s:\n", to, from)
+	printRateLimitPlans("already matches "+from, []openaiorgs.RateLimitProjectPlan{*plan})
+	return nil
+}
+
+func copyProjectRateLimits(ctx context.Context, cmd *cli.Command) error {
+	client := newClient(ctx, cmd)
+	from := cmd.String("from")
+
+	plan, err := openaiorgs.PlanRateLimitCopy(client, from, cmd.String("to"))
+	if err != nil {
+		return wrapError("compare project rate limits", err)
+	}
+	return runRateLimitPlans(cmd, client, "already matches "+from, []openaiorgs.RateLimitProjectPlan{*plan})
+}
+
+// runRateLimitPlans shows the planned changes, asks for confirmation unless --yes or
+// --dry-run is set, applies them and reports the per-project results. With JSON output
+// the plan is not shown first, so --yes or --dry-run is required to apply changes.
+func runRateLimitPlans(cmd *cli.Command, client *openaiorgs.Client, unchanged string, plans []openaiorgs.RateLimitProjectPlan) error {
+	dryRun := cmd.Bool("dry-run")
+	jsonOutput := cmd.String("output") == OutputFormatJSON
+
+	changes := 0
+	for _, plan := range plans {
+		changes += len(plan.Changes)
+	}
+
+	// The plan is only printed in pretty mode, so it cannot be reviewed at a prompt.
+	if jsonOutput && changes > 0 && !dryRun && !cmd.Bool("yes") {
+		return fmt.Errorf("--yes or --dry-run is required to apply %d rate limit change(s) with --output %s", changes, OutputFormatJSON)
+	}
+
+	if !jsonOutput {
+		printRateLimitPlans(unchanged, plans)
+	}
+
+	if changes > 0 && !dryRun {
+		if !cmd.Bool("yes") && !confirm(fmt.Sprintf("Apply %d rate limit change(s)?", changes)) {
+			fmt.Println("Aborted.")
+			return nil
+		}
+		openaiorgs.ApplyRateLimitPlans(client, plans)
+	}
+
+	if jsonOutput {
+		data, err := json.MarshalIndent(plans, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal rate limit plans: %w", err)
+		}
+		fmt.Println(string(data))
+	} else if changes > 0 && !dryRun {
+		printRateLimitPlanResults(plans)
+	}
+
+	failed := 0
+	for _, plan := range plans {
+		if len(plan.Errors) > 0 {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d project(s) had errors", failed, len(plans))
+	}
+	return nil
+}
+
+// printRateLimitPlans prints the per-project differences between the current and the
+// desired limits. unchanged is printed for projects without differences (e.g., "already matches tier-small").
+func printRateLimitPlans(unchanged string, plans []openaiorgs.RateLimitProjectPlan) {
+	for _, plan := range plans {
+		fmt.Printf("%s:\n", plan.ProjectID)
+		for _, change := range plan.Changes {
+			fmt.Printf("  %s (%s)\n", change.Model, change.RateLimitID)
+			for _, field := range change.Fields {
+				fmt.Printf("    %s: %d -> %d\n", field.Field, field.From, field.To)
+			}
+		}
+		if len(plan.MissingModels) > 0 {
+			fmt.Printf("  no rate limit for: %s\n", strings.Join(plan.MissingModels, ", "))
+		}
+		if len(plan.ExtraModels) > 0 {
+			fmt.Printf("  only in this project: %s\n", strings.Join(plan.ExtraModels, ", "))
+		}
+		for _, msg := range plan.Errors {
+			fmt.Printf("  error: %s\n", msg)
+		}
+		if len(plan.Changes) == 0 && len(plan.Errors) == 0 {
+			fmt.Printf("  %s\n", unchanged)
+		}
+	}
+	fmt.Println()
+}
+
+func printRateLimitPlanResults(plans []openaiorgs.RateLimitProjectPlan) {
+	data := TableData{Headers: []string{"Project", "Changes", "Applied", "Errors"}}
+	for _, plan := range plans {
+		data.Rows = append(data.Rows, []string{
+			plan.ProjectID,
+			strconv.Itoa(len(plan.Changes)),
+			strconv.Itoa(plan.Applied),
+			strings.Join(plan.Errors, "; "),
+		})
+	}
+	printTableData(data)
+}
@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"encoding/json"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -190,6 +192,41 @@ func TestModifyProjectRateLimitTableCommand(t *testing.T) {
 			}(),
 			wantContains: []string{"rl_1", "gpt-4", "200", "100000"},
 		},
+		{
+			name: "explicit zero",
+			args: []string{
+				"project-rate-limits", "modify",
+				"--project-id", "proj_123",
+				"--rate-limit-id", "rl_1",
+				"--max-images-per-1-minute", "0",
+			},
+			statusCode: 200,
+			response: func() openaiorgs.ProjectRateLimit {
+				rl := createMockProjectRateLimit("rl_1", "gpt-4")
+				rl.MaxImagesPer1Minute = 0
+				return rl
+			}(),
+			wantContains: []string{"rl_1", "gpt-4"},
+		},
+		{
+			name: "no fields",
+			args: []string{
+				"project-rate-limits", "modify",
+				"--project-id", "proj_123",
+				"--rate-limit-id", "rl_1",
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative value",
+			args: []string{
+				"project-rate-limits", "modify",
+				"--project-id", "proj_123",
+				"--rate-limit-id", "rl_1",
+				"--max-requests-per-1-day", "-1",
+			},
+			wantErr: true,
+		},
 		{
 			name: "error from API",
 			args: []string{
@@ -304,3 +341,260 @@ func TestModifyProjectRateLimitJSONCommand(t *testing.T) {
 		})
 	}
 }
+
+func writeProfilesFile(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "profiles.yaml")
+	contents := "tier-small:\n  gpt-4o:\n    max_requests_per_1_minute: 100\n    max_tokens_per_1_minute: 30000\n"
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestApplyRateLimitProfileCommand(t *testing.T) {
+	t.Run("all projects with confirmation", func(t *testing.T) {
+		h := newCmdTestHelper(t)
+		defer h.cleanup()
+		defer setConfirmInput("y\n")()
+
+		h.mockResponse("GET", "/organization/projects", 200, openaiorgs.ListResponse[openaiorgs.Project]{
+			Object: "list",
+			Data:   []openaiorgs.Project{{ID: "proj_a"}, {ID: "proj_b"}},
+		})
+		matching := createMockProjectRateLimit("rl_b", "gpt-4o")
+		matching.MaxTokensPer1Minute = 30000
+		h.mockResponse("GET", "/organization/projects/proj_a/rate_limits", 200, openaiorgs.ListResponse[openaiorgs.ProjectRateLimit]{
+			Object: "list", Data: []openaiorgs.ProjectRateLimit{createMockProjectRateLimit("rl_a", "gpt-4o")},
+		})
+		h.mockResponse("GET", "/organization/projects/proj_b/rate_limits", 200, openaiorgs.ListResponse[openaiorgs.ProjectRateLimit]{
+			Object: "list", Data: []openaiorgs.ProjectRateLimit{matching},
+		})
+		h.mockResponse("POST", "/organization/projects/proj_a/rate_limits/rl_a", 200, createMockProjectRateLimit("rl_a", "gpt-4o"))
+
+		var err error
+		output := captureOutput(func() {
+			err = h.runCmd(ProjectRateLimitsCommand(), []string{
+				"project-rate-limits", "apply-profile", "--profile", "tier-small",
+				"--profiles-file", writeProfilesFile(t), "--all-projects",
+			})
+		})
+		if err != nil {
+			t.Fatalf("runCmd() error = %v", err)
+		}
+
+		for _, want := range []string{
+			"max_tokens_per_1_minute: 50000 -> 30000",
+			"already matches tier-small",
+			"proj_a | 1 | 1 | ",
+		} {
+			if !strings.Contains(output, want) {
+				t.Errorf("Expected output to contain %q, got: %s", want, output)
+			}
+		}
+		h.assertRequest("POST", "/organization/projects/proj_a/rate_limits/rl_a", 1)
+		h.assertRequest("POST", "/organization/projects/proj_b/rate_limits/rl_b", 0)
+	})
+
+	t.Run("json requires yes", func(t *testing.T) {
+		h := newCmdTestHelper(t)
+		defer h.cleanup()
+		defer setConfirmInput("y\n")()
+
+		h.mockResponse("GET", "/organization/projects/proj_a/rate_limits", 200, openaiorgs.ListResponse[openaiorgs.ProjectRateLimit]{
+			Object: "list", Data: []openaiorgs.ProjectRateLimit{createMockProjectRateLimit("rl_a", "gpt-4o")},
+		})
+
+		var err error
+		output := captureOutput(func() {
+			err = h.runCmd(ProjectRateLimitsCommand(), []string{
+				"--output", "json", "project-rate-limits", "apply-profile", "--profile", "tier-small",
+				"--profiles-file", writeProfilesFile(t), "--project-ids", "proj_a",
+			})
+		})
+		if err == nil || !strings.Contains(err.Error(), "--yes or --dry-run is required") {
+			t.Fatalf("Expected --yes error, got %v", err)
+		}
+		if output != "" {
+			t.Errorf("Expected no output, got: %s", output)
+		}
+		h.assertRequest("POST", "/organization/projects/proj_a/rate_limits/rl_a", 0)
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		h := newCmdTestHelper(t)
+		defer h.cleanup()
+
+		h.mockResponse("GET", "/organization/projects/proj_a/rate_limits", 200, openaiorgs.ListResponse[openaiorgs.ProjectRateLimit]{
+			Object: "list", Data: []openaiorgs.ProjectRateLimit{createMockProjectRateLimit("rl_a", "gpt-4o")},
+		})
+
+		var err error
+		output := captureOutput(func() {
+			err = h.runCmd(ProjectRateLimitsCommand(), []string{
+				"project-rate-limits", "apply-profile", "--profile", "tier-small",
+				"--profiles-file", writeProfilesFile(t), "--project-ids", "proj_a", "--dry-run",
+			})
+		})
+		if err != nil {
+			t.Fatalf("runCmd() error = %v", err)
+		}
+		if !strings.Contains(output, "max_tokens_per_1_minute: 50000 -> 30000") {
+			t.Errorf("Expected diff in output, got: %s", output)
+		}
+		h.assertRequest("POST", "/organization/projects/proj_a/rate_limits/rl_a", 0)
+	})
+
+	t.Run("requires project selection", func(t *testing.T) {
+		h := newCmdTestHelper(t)
+		defer h.cleanup()
+
+		err := h.runCmd(ProjectRateLimitsCommand(), []string{
+			"project-rate-limits", "apply-profile", "--profile", "tier-small", "--profiles-file", writeProfilesFile(t),
+		})
+		if err == nil || !strings.Contains(err.Error(), "--project-ids or --all-projects") {
+			t.Errorf("Expected project selection error, got %v", err)
+		}
+	})
+}
+
+func mockRateLimitCopy(h *cmdTestHelper) {
+	target := createMockProjectRateLimit("rl_staging", "gpt-4o")
+	target.MaxTokensPer1Minute = 10000
+	h.mockResponse("GET", "/organization/projects/proj_prod/rate_limits", 200, openaiorgs.ListResponse[openaiorgs.ProjectRateLimit]{
+		Object: "list", Data: []openaiorgs.ProjectRateLimit{createMockProjectRateLimit("rl_prod", "gpt-4o")},
+	})
+	h.mockResponse("GET", "/organization/projects/proj_staging/rate_limits", 200, openaiorgs.ListResponse[openaiorgs.ProjectRateLimit]{
+		Object: "list", Data: []openaiorgs.ProjectRateLimit{target},
+	})
+	h.mockResponse("POST", "/organization/projects/proj_staging/rate_limits/rl_staging", 200, createMockProjectRateLimit("rl_staging", "gpt-4o"))
+}
+
+func TestDiffProjectRateLimitsCommand(t *testing.T) {
+	h := newCmdTestHelper(t)
+	defer h.cleanup()
+	mockRateLimitCopy(h)
+
+	var err error
+	output := captureOutput(func() {
+		err = h.runCmd(ProjectRateLimitsCommand(), []string{
+			"project-rate-limits", "diff", "--from", "proj_prod", "--to", "proj_staging",
+		})
+	})
+	if err != nil {
+		t.Fatalf("runCmd() error = %v", err)
+	}
+	for _, want := range []string{
+		"Changes to make proj_staging match proj_prod:",
+		"gpt-4o (rl_staging)",
+		"max_tokens_per_1_minute: 10000 -> 50000",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got: %s", want, output)
+		}
+	}
+	h.assertRequest("POST", "/organization/projects/proj_staging/rate_limits/rl_staging", 0)
+}
+
+func TestCopyProjectRateLimitsCommand(t *testing.T) {
+	t.Run("dry run", func(t *testing.T) {
+		h := newCmdTestHelper(t)
+		defer h.cleanup()
+		mockRateLimitCopy(h)
+
+		err := h.runCmd(ProjectRateLimitsCommand(), []string{
+			"project-rate-limits", "copy", "--from", "proj_prod", "--to", "proj_staging", "--dry-run",
+		})
+		if err != nil {
+			t.Fatalf("runCmd() error = %v", err)
+		}
+		h.assertRequest("POST", "/organization/projects/proj_staging/rate_limits/rl_staging", 0)
+	})
+
+	t.Run("json output", func(t *testing.T) {
+		h := newCmdTestHelper(t)
+		defer h.cleanup()
+		mockRateLimitCopy(h)
+
+		var err error
+		output := captureOutput(func() {
+			err = h.runCmd(ProjectRateLimitsCommand(), []string{
+				"--output", "json", "project-rate-limits", "copy", "--from", "proj_prod", "--to", "proj_staging", "--yes",
+			})
+		})
+		if err != nil {
+			t.Fatalf("runCmd() error = %v", err)
+		}
+
+		var plans []openaiorgs.RateLimitProjectPlan
+		if err := json.Unmarshal([]byte(output), &plans); err != nil {
+			t.Fatalf("failed to parse JSON output: %v\n%s", err, output)
+		}
+		if len(plans) != 1 || plans[0].Applied != 1 {
+			t.Errorf("unexpected plans: %+v", plans)
+		}
+		h.assertRequest("POST", "/organization/projects/proj_staging/rate_limits/rl_staging", 1)
+	})
+}
+
+func TestScaleProjectRateLimitsCommand(t *testing.T) {
+	h := newCmdTestHelper(t)
+	defer h.cleanup()
+
+	h.mockResponse("GET", "/organization/projects/proj_a/rate_limits", 200, openaiorgs.ListResponse[openaiorgs.ProjectRateLimit]{
+		Object: "list", Data: []openaiorgs.ProjectRateLimit{createMockProjectRateLimit("rl_a", "gpt-4o"), createMockProjectRateLimit("rl_b", "gpt-4o-mini")},
+	})
+	h.mockResponse("POST", "/organization/projects/proj_a/rate_limits/rl_a", 200, createMockProjectRateLimit("rl_a", "gpt-4o"))
+
+	var err error
+	output := captureOutput(func() {
+		err = h.runCmd(ProjectRateLimitsCommand(), []string{
+			"project-rate-limits", "scale", "--project-ids", "proj_a", "--model", "gpt-4o",
+			"--field", "max_tokens_per_1_minute", "--percent", "50", "--yes",
+		})
+	})
+	if err != nil {
+		t.Fatalf("runCmd() error = %v", err)
+	}
+	if !strings.Contains(output, "max_tokens_per_1_minute: 50000 -> 25000") {
+		t.Errorf("Expected scaled value in output, got: %s", output)
+	}
+	h.assertRequest("POST", "/organization/projects/proj_a/rate_limits/rl_a", 1)
+	h.assertRequest("POST", "/organization/projects/proj_a/rate_limits/rl_b", 0)
+}
+
+func TestHeadroomProjectRateLimitsCommand(t *testing.T) {
+	h := newCmdTestHelper(t)
+	defer h.cleanup()
+
+	limit := createMockProjectRateLimit("rl_a", "gpt-4o")
+	h.mockResponse("GET", "/organization/projects/proj_a/rate_limits", 200, openaiorgs.ListResponse[openaiorgs.ProjectRateLimit]{
+		Object: "list", Data: []openaiorgs.ProjectRateLimit{limit},
+	})
+	h.mockResponse("GET", "/organization/usage/completions", 200, openaiorgs.CompletionsUsageResponse{
+		Object: "page",
+		Data: []openaiorgs.CompletionsUsageBucket{
+			{Results: []openaiorgs.CompletionsUsageResult{{Model: "gpt-4o", NumModelRequests: 80, InputTokens: 30000, OutputTokens: 10000}}},
+			{Results: []openaiorgs.CompletionsUsageResult{{Model: "o1", NumModelRequests: 4, InputTokens: 100}}},
+		},
+	})
+
+	var err error
+	output := captureOutput(func() {
+		err = h.runCmd(ProjectRateLimitsCommand(), []string{
+			"project-rate-limits", "headroom", "--project-id", "proj_a", "--window", "1d",
+		})
+	})
+	if err != nil {
+		t.Fatalf("runCmd() error = %v", err)
+	}
+	for _, want := range []string{
+		"recommendations at 80% peak utilization",
+		"gpt-4o | 1 | 100 | 80 | 80 | 80% | 100 | 50000 | 40000 | 40000 | 80% | 50000",
+		"o1 | 1 | none | 4 | 4 | - | 5 | none | 100 | 100 | - | 125",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got: %s", want, output)
+		}
+	}
+}
@@ -2,8 +2,12 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
+	"time"
 
+	openaiorgs "github.com/klauern/openai-orgs"
 	"github.com/urfave/cli/v3"
 )
 
@@ -16,6 +20,7 @@ func ProjectServiceAccountsCommand() *cli.Command {
 			createProjectServiceAccountCommand(),
 			retrieveProjectServiceAccountCommand(),
 			deleteProjectServiceAccountCommand(),
+			rotateProjectServiceAccountCommand(),
 		},
 	}
 }
@@ -37,10 +42,10 @@ func createProjectServiceAccountCommand() *cli.Command {
 	return &cli.Command{
 		Name:  "create",
 		Usage: "Create a new project service account",
-		Flags: []cli.Flag{
+		Flags: append([]cli.Flag{
 			projectIDFlag,
 			nameFlag,
-		},
+		}, secretSinkFlags()...),
 		Action: createProjectServiceAccount,
 	}
 }
@@ -69,6 +74,40 @@ func deleteProjectServiceAccountCommand() *cli.Command {
 	}
 }
 
+func rotateProjectServiceAccountCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "rotate",
+		Usage: "Replace a service account with a new one of the same name, store its key, then delete the old account",
+		Flags: append([]cli.Flag{
+			projectIDFlag,
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "Name of the service account to rotate",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "service-account-id",
+				Usage: "ID of the account to rotate when several share the name (e.g., after an old account was kept)",
+			},
+			&cli.StringFlag{
+				Name:  "grace",
+				Usage: "Wait this long before deleting the old account instead of asking (e.g., 10m, 1d)",
+			},
+			&cli.BoolFlag{
+				Name:    "yes",
+				Aliases: []string{"y"},
+				Usage:   "Delete the old account without asking",
+			},
+			&cli.StringFlag{
+				Name:  "log-file",
+				Usage: "Rotation log to append to (JSON lines)",
+				Value: "service-account-rotations.jsonl",
+			},
+		}, secretSinkFlags()...),
+		Action: rotateProjectServiceAccount,
+	}
+}
+
 func listProjectServiceAccounts(ctx context.Context, cmd *cli.Command) error {
 	client := newClient(ctx, cmd)
 
@@ -100,6 +139,11 @@ func listProjectServiceAccounts(ctx context.Context, cmd *cli.Command) error {
 }
 
 func createProjectServiceAccount(ctx context.Context, cmd *cli.Command) error {
+	sink, err := secretSinkFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
 	client := newClient(ctx, cmd)
 
 	serviceAccount, err := client.CreateProjectServiceAccount(
@@ -118,6 +162,16 @@ func createProjectServiceAccount(ctx context.Context, cmd *cli.Command) error {
 		serviceAccount.CreatedAt.String(),
 	)
 
+	if sink != nil {
+		if serviceAccount.APIKey == nil || serviceAccount.APIKey.Value == "" {
+			return fmt.Errorf("service account %s was created without an API key", serviceAccount.ID)
+		}
+		return storeSecret(sink, openaiorgs.Secret{
+			Name:  serviceAccount.Name,
+			ID:    serviceAccount.APIKey.ID,
+			Value: serviceAccount.APIKey.Value,
+		})
+	}
 	return nil
 }
 
@@ -157,3 +211,81 @@ func deleteProjectServiceAccount(ctx context.Context, cmd *cli.Command) error {
 	fmt.Printf("Project Service Account %s deleted successfully\n", cmd.String("id"))
 	return nil
 }
+
+func rotateProjectServiceAccount(ctx context.Context, cmd *cli.Command) error {
+	name := cmd.String("name")
+	sink, err := secretSinkFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	if sink == nil {
+		return fmt.Errorf("--secret-sink is required so the new key is not lost")
+	}
+
+	var grace time.Duration
+	if value := cmd.String("grace"); value != "" {
+		d, err := parseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid --grace: %w", err)
+		}
+		grace = d
+	}
+
+	jsonOutput := cmd.String("output") == OutputFormatJSON
+
+	client := newClient(ctx, cmd)
+	record, err := openaiorgs.RotateServiceAccount(client, cmd.String("project-id"), name, openaiorgs.ServiceAccountRotationOptions{
+		ServiceAccountID: cmd.String("service-account-id"),
+		Sink: openaiorgs.SecretSinkFunc(func(secret openaiorgs.Secret) (string, error) {
+			ref, err := sink.Store(secret)
+			if err == nil && !jsonOutput {
+				fmt.Printf("Stored API key %s (%s) in %s\n", secret.ID, openaiorgs.RedactSecret(secret.Value), ref)
+			}
+			return ref, err
+		}),
+		BeforeDelete: func(old, replacement *openaiorgs.ProjectServiceAccount) bool {
+			switch {
+			case grace > 0:
+				if !jsonOutput {
+					fmt.Printf("Waiting %s before deleting old service account %s...\n", grace, old.ID)
+				}
+				select {
+				case <-time.After(grace):
+					return true
+				case <-ctx.Done():
+					return false
+				}
+			case cmd.Bool("yes"):
+				return true
+			default:
+				return confirm(fmt.Sprintf("Delete old service account %s?", old.ID))
+			}
+		},
+	})
+	if record == nil {
+		return wrapError("rotate project service account", err)
+	}
+
+	// A log failure is reported on stderr right away, so it isn't lost if the
+	// rotation itself failed, and fails the command once the result is printed.
+	logErr := openaiorgs.AppendRotationLog(cmd.String("log-file"), record)
+	if logErr != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", logErr)
+	}
+	if err != nil {
+		return wrapError("rotate project service account", err)
+	}
+
+	if jsonOutput {
+		data, err := json.MarshalIndent(record, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal rotation record: %w", err)
+		}
+		fmt.Println(string(data))
+	} else if record.Status == openaiorgs.RotationStatusOldAccountKept {
+		fmt.Printf("Old service account %s was kept; delete it once nothing uses its key\n", record.OldServiceAccountID)
+	} else {
+		fmt.Printf("Deleted old service account %s\n", record.OldServiceAccountID)
+	}
+	return logErr
+}
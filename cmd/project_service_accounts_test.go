@@ -1,6 +1,9 @@
 package cmd
 
 import (
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -137,6 +140,41 @@ func TestCreateProjectServiceAccountCommand(t *testing.T) {
 	}
 }
 
+func TestCreateProjectServiceAccountCommand_SecretSink(t *testing.T) {
+	h := newCmdTestHelper(t)
+	defer h.cleanup()
+
+	account := createMockProjectServiceAccount("sa_new", "New SA")
+	account.APIKey = &openaiorgs.ProjectServiceAccountAPIKey{ID: "key_new", Value: "sk-proj-abcdefghijklmnop"}
+	h.mockResponse("POST", "/organization/projects/proj_123/service_accounts", 200, account)
+
+	keyFile := filepath.Join(t.TempDir(), "key")
+	var err error
+	output := captureOutput(func() {
+		err = h.runCmd(ProjectServiceAccountsCommand(), []string{
+			"project-service-accounts", "create", "--project-id", "proj_123", "--name", "New SA",
+			"--secret-sink", "file", "--secret-path", keyFile,
+		})
+	})
+	if err != nil {
+		t.Fatalf("runCmd() error = %v", err)
+	}
+
+	if want := "API Key: sk-pro...mnop stored in " + keyFile; !strings.Contains(output, want) {
+		t.Errorf("Expected output to contain %q, got: %s", want, output)
+	}
+	if strings.Contains(output, "sk-proj-abcdefghijklmnop") {
+		t.Errorf("API key must not be printed, got: %s", output)
+	}
+	data, err := os.ReadFile(keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "sk-proj-abcdefghijklmnop\n" {
+		t.Errorf("unexpected key file contents %q", data)
+	}
+}
+
 func TestRetrieveProjectServiceAccountCommand(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -238,3 +276,202 @@ func TestDeleteProjectServiceAccountCommand(t *testing.T) {
 		})
 	}
 }
+
+func mockServiceAccountRotation(h *cmdTestHelper) {
+	h.mockResponse("GET", "/organization/projects/proj_1/service_accounts", 200, openaiorgs.ListResponse[openaiorgs.ProjectServiceAccount]{
+		Object: "list",
+		Data:   []openaiorgs.ProjectServiceAccount{createMockProjectServiceAccount("svc_old", "deployer")},
+	})
+	replacement := createMockProjectServiceAccount("svc_new", "deployer")
+	replacement.APIKey = &openaiorgs.ProjectServiceAccountAPIKey{ID: "key_new", Value: "sk-rotated"}
+	h.mockResponse("POST", "/organization/projects/proj_1/service_accounts", 200, replacement)
+	h.mockResponse("DELETE", "/organization/projects/proj_1/service_accounts/svc_old", 200, map[string]any{"deleted": true})
+}
+
+func readRotationLog(t *testing.T, path string) []openaiorgs.ServiceAccountRotation {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read rotation log: %v", err)
+	}
+	var records []openaiorgs.ServiceAccountRotation
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		var record openaiorgs.ServiceAccountRotation
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("invalid rotation log line %q: %v", line, err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestRotateProjectServiceAccountCommand(t *testing.T) {
+	t.Run("k8s secret with confirmation", func(t *testing.T) {
+		h := newCmdTestHelper(t)
+		defer h.cleanup()
+		mockServiceAccountRotation(h)
+		defer setConfirmInput("y\n")()
+
+		dir := t.TempDir()
+		keyFile := filepath.Join(dir, "secret.yaml")
+		logFile := filepath.Join(dir, "rotations.jsonl")
+
+		output := captureOutput(func() {
+			err := h.runCmd(ProjectServiceAccountsCommand(), []string{
+				"project-service-accounts", "rotate", "--project-id", "proj_1", "--name", "deployer",
+				"--secret-sink", "k8s-secret", "--secret-path", keyFile, "--secret-namespace", "ci", "--log-file", logFile,
+			})
+			if err != nil {
+				t.Errorf("runCmd() error = %v", err)
+			}
+		})
+
+		for _, want := range []string{
+			"Stored API key key_new (**********) in " + keyFile,
+			"Deleted old service account svc_old",
+		} {
+			if !strings.Contains(output, want) {
+				t.Errorf("Expected output to contain %q, got: %s", want, output)
+			}
+		}
+		if strings.Contains(output, "sk-rotated") {
+			t.Errorf("API key must not be printed, got: %s", output)
+		}
+
+		secret, err := os.ReadFile(keyFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range []string{"kind: Secret", "name: deployer", "namespace: ci", `OPENAI_API_KEY: "sk-rotated"`} {
+			if !strings.Contains(string(secret), want) {
+				t.Errorf("Expected secret manifest to contain %q, got: %s", want, secret)
+			}
+		}
+
+		records := readRotationLog(t, logFile)
+		if len(records) != 1 || records[0].Status != openaiorgs.RotationStatusCompleted || records[0].OldServiceAccountID != "svc_old" || records[0].NewServiceAccountID != "svc_new" {
+			t.Errorf("unexpected rotation log: %+v", records)
+		}
+		h.assertRequest("DELETE", "/organization/projects/proj_1/service_accounts/svc_old", 1)
+	})
+
+	t.Run("declined keeps old account", func(t *testing.T) {
+		h := newCmdTestHelper(t)
+		defer h.cleanup()
+		mockServiceAccountRotation(h)
+		defer setConfirmInput("n\n")()
+
+		dir := t.TempDir()
+		logFile := filepath.Join(dir, "rotations.jsonl")
+
+		output := captureOutput(func() {
+			err := h.runCmd(ProjectServiceAccountsCommand(), []string{
+				"project-service-accounts", "rotate", "--project-id", "proj_1", "--name", "deployer",
+				"--secret-sink", "file", "--secret-path", filepath.Join(dir, "key"), "--log-file", logFile,
+			})
+			if err != nil {
+				t.Errorf("runCmd() error = %v", err)
+			}
+		})
+
+		if !strings.Contains(output, "Old service account svc_old was kept") {
+			t.Errorf("Expected kept message, got: %s", output)
+		}
+		if records := readRotationLog(t, logFile); records[0].Status != openaiorgs.RotationStatusOldAccountKept {
+			t.Errorf("unexpected rotation log: %+v", records)
+		}
+		h.assertRequest("DELETE", "/organization/projects/proj_1/service_accounts/svc_old", 0)
+	})
+
+	t.Run("grace period and json output", func(t *testing.T) {
+		h := newCmdTestHelper(t)
+		defer h.cleanup()
+		mockServiceAccountRotation(h)
+
+		dir := t.TempDir()
+		keyFile := filepath.Join(dir, ".env")
+
+		var err error
+		output := captureOutput(func() {
+			err = h.runCmd(ProjectServiceAccountsCommand(), []string{
+				"--output", "json", "project-service-accounts", "rotate", "--project-id", "proj_1", "--name", "deployer",
+				"--secret-sink", "dotenv", "--secret-path", keyFile, "--secret-key", "DEPLOY_KEY", "--grace", "10ms",
+				"--log-file", filepath.Join(dir, "rotations.jsonl"),
+			})
+		})
+		if err != nil {
+			t.Fatalf("runCmd() error = %v", err)
+		}
+
+		var record openaiorgs.ServiceAccountRotation
+		if err := json.Unmarshal([]byte(output), &record); err != nil {
+			t.Fatalf("failed to parse JSON output: %v\n%s", err, output)
+		}
+		if record.Status != openaiorgs.RotationStatusCompleted || record.Destination != keyFile {
+			t.Errorf("unexpected record: %+v", record)
+		}
+		env, _ := os.ReadFile(keyFile)
+		if string(env) != "DEPLOY_KEY=\"sk-rotated\"\n" {
+			t.Errorf("unexpected dotenv contents %q", env)
+		}
+		info, _ := os.Stat(keyFile)
+		if perm := info.Mode().Perm(); perm != 0o600 {
+			t.Errorf("expected 0600 permissions, got %o", perm)
+		}
+	})
+
+	t.Run("log write failure", func(t *testing.T) {
+		h := newCmdTestHelper(t)
+		defer h.cleanup()
+		mockServiceAccountRotation(h)
+
+		dir := t.TempDir()
+		var err error
+		output := captureOutput(func() {
+			err = h.runCmd(ProjectServiceAccountsCommand(), []string{
+				"--output", "json", "project-service-accounts", "rotate", "--project-id", "proj_1", "--name", "deployer",
+				"--secret-sink", "file", "--secret-path", filepath.Join(dir, "key"), "--yes",
+				"--log-file", filepath.Join(dir, "missing", "rotations.jsonl"),
+			})
+		})
+		if err == nil || !strings.Contains(err.Error(), "failed to open rotation log") {
+			t.Errorf("Expected rotation log error, got %v", err)
+		}
+
+		// The rotation result is still reported, and stdout stays valid JSON.
+		var record openaiorgs.ServiceAccountRotation
+		if err := json.Unmarshal([]byte(output), &record); err != nil {
+			t.Fatalf("failed to parse JSON output: %v\n%s", err, output)
+		}
+		if record.Status != openaiorgs.RotationStatusCompleted {
+			t.Errorf("unexpected record: %+v", record)
+		}
+	})
+
+	t.Run("invalid sink", func(t *testing.T) {
+		h := newCmdTestHelper(t)
+		defer h.cleanup()
+
+		err := h.runCmd(ProjectServiceAccountsCommand(), []string{
+			"project-service-accounts", "rotate", "--project-id", "proj_1", "--name", "deployer",
+			"--secret-sink", "toml", "--secret-path", filepath.Join(t.TempDir(), "key"),
+		})
+		if err == nil || !strings.Contains(err.Error(), "unknown secret sink") {
+			t.Errorf("Expected sink error, got %v", err)
+		}
+		h.assertRequest("POST", "/organization/projects/proj_1/service_accounts", 0)
+	})
+
+	t.Run("sink required", func(t *testing.T) {
+		h := newCmdTestHelper(t)
+		defer h.cleanup()
+
+		err := h.runCmd(ProjectServiceAccountsCommand(), []string{
+			"project-service-accounts", "rotate", "--project-id", "proj_1", "--name", "deployer",
+		})
+		if err == nil || !strings.Contains(err.Error(), "--secret-sink is required") {
+			t.Errorf("Expected missing sink error, got %v", err)
+		}
+		h.assertRequest("POST", "/organization/projects/proj_1/service_accounts", 0)
+	})
+}
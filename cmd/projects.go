@@ -2,8 +2,14 @@ package cmd
 
 import (
 	"context"
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"os"
+	"strings"
+	"time"
 
+	openaiorgs "github.com/klauern/openai-orgs"
 	"github.com/urfave/cli/v3"
 )
 
@@ -17,6 +23,9 @@ func ProjectsCommand() *cli.Command {
 			retrieveProjectCommand(),
 			modifyProjectCommand(),
 			archiveProjectCommand(),
+			cloneProjectCommand(),
+			idleProjectsCommand(),
+			describeProjectCommand(),
 		},
 	}
 }
@@ -40,14 +49,114 @@ func listProjectsCommand() *cli.Command {
 func createProjectCommand() *cli.Command {
 	return &cli.Command{
 		Name:  "create",
-		Usage: "Create a new project",
-		Flags: []cli.Flag{
+		Usage: "Create a new project, optionally from a template of users, service accounts, rate limits and certificates",
+		Flags: append([]cli.Flag{
+			&cli.StringFlag{
+				Name:  "name",
+				Usage: "Project name (required unless the template has one)",
+			},
+			&cli.StringFlag{
+				Name:  "template",
+				Usage: "Path to a YAML project template",
+			},
+		}, projectProvisionFlags()...),
+		Action: createProject,
+	}
+}
+
+func cloneProjectCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "clone",
+		Usage: "Create a project with the same users, service accounts, rate limits and certificates as another",
+		Flags: append([]cli.Flag{
+			&cli.StringFlag{
+				Name:     "from",
+				Usage:    "ID of the project to copy",
+				Required: true,
+			},
 			nameFlag,
+		}, projectProvisionFlags()...),
+		Action: cloneProject,
+	}
+}
+
+func idleProjectsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "idle",
+		Usage: "List active projects with no requests, spend or audit activity, with their members and keys",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:  "days",
+				Usage: "Number of days without activity",
+				Value: 60,
+			},
+			&cli.IntFlag{
+				Name:  "concurrency",
+				Usage: "Number of idle projects to fetch in parallel",
+				Value: openaiorgs.DefaultInventoryConcurrency,
+			},
+			&cli.BoolFlag{
+				Name:  "archive",
+				Usage: "Archive the idle projects",
+			},
+			&cli.BoolFlag{
+				Name:    "yes",
+				Aliases: []string{"y"},
+				Usage:   "Archive without asking",
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "Output format (pretty, csv, json)",
+				Value:   "pretty",
+			},
 		},
-		Action: createProject,
+		Action: idleProjects,
+	}
+}
+
+func describeProjectCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "describe",
+		Usage: "Show a project with its members, keys, rate limits, certificates, costs and usage",
+		Flags: []cli.Flag{
+			projectIDFlag,
+			&cli.IntFlag{
+				Name:  "days",
+				Usage: "Number of days of costs and usage to include",
+				Value: 30,
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "Output format (pretty, json, markdown)",
+				Value:   "pretty",
+			},
+		},
+		Action: describeProject,
 	}
 }
 
+// projectProvisionFlags returns the flags shared by commands that provision a project
+// from a template.
+func projectProvisionFlags() []cli.Flag {
+	return append([]cli.Flag{
+		&cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "Show the steps without changing anything",
+		},
+		&cli.BoolFlag{
+			Name:  "keep-partial",
+			Usage: "On failure, keep what was created instead of deleting new service accounts and archiving the project",
+		},
+		&cli.BoolFlag{
+			Name:    "yes",
+			Aliases: []string{"y"},
+			Usage:   "Create without asking",
+		},
+	}, secretSinkFlags()...)
+}
+
 func retrieveProjectCommand() *cli.Command {
 	return &cli.Command{
 		Name:  "retrieve",
@@ -123,6 +232,17 @@ func createProject(ctx context.Context, cmd *cli.Command) error {
 
 	name := cmd.String("name")
 
+	if path := cmd.String("template"); path != "" {
+		template, err := openaiorgs.LoadProjectTemplate(path)
+		if err != nil {
+			return err
+		}
+		return provisionProject(cmd, client, template, name)
+	}
+	if name == "" {
+		return fmt.Errorf("--name is required without --template")
+	}
+
 	project, err := client.CreateProject(name)
 	if err != nil {
 		return fmt.Errorf("failed to create project: %w", err)
@@ -140,6 +260,380 @@ func createProject(ctx context.Context, cmd *cli.Command) error {
 	return nil
 }
 
+func cloneProject(ctx context.Context, cmd *cli.Command) error {
+	client := newClient(ctx, cmd)
+
+	template, err := openaiorgs.ProjectTemplateFromProject(client, cmd.String("from"))
+	if err != nil {
+		return wrapError("read source project", err)
+	}
+	return provisionProject(cmd, client, template, cmd.String("name"))
+}
+
+// provisionProject shows the steps needed to create the project from template, asks
+// for confirmation and then runs them.
+func provisionProject(cmd *cli.Command, client *openaiorgs.Client, template *openaiorgs.ProjectTemplate, name string) error {
+	// The plan is only printed in pretty mode, so it cannot be reviewed at a prompt.
+	if cmd.String("output") == OutputFormatJSON && !cmd.Bool("dry-run") && !cmd.Bool("yes") {
+		return fmt.Errorf("--yes or --dry-run is required with --output %s", OutputFormatJSON)
+	}
+	sink, err := namedSecretSinkFromFlags(cmd, len(template.ServiceAccounts))
+	if err != nil {
+		return err
+	}
+	if sink == nil && len(template.ServiceAccounts) > 0 && !cmd.Bool("dry-run") {
+		return fmt.Errorf("the template creates %d service accounts; use --secret-sink to store their keys", len(template.ServiceAccounts))
+	}
+
+	opts := openaiorgs.ProjectProvisionOptions{Name: name, Sink: sink, KeepPartial: cmd.Bool("keep-partial"), DryRun: true}
+	plan, err := openaiorgs.ProvisionProject(client, template, opts)
+	if err != nil {
+		return wrapError("plan project", err)
+	}
+
+	if cmd.Bool("dry-run") {
+		return printProjectProvision(cmd, plan)
+	}
+	if cmd.String("output") != OutputFormatJSON {
+		if err := printProjectProvision(cmd, plan); err != nil {
+			return err
+		}
+	}
+	if !cmd.Bool("yes") && !confirm(fmt.Sprintf("Create project %q?", plan.ProjectName)) {
+		fmt.Println("Aborted.")
+		return nil
+	}
+
+	opts.DryRun = false
+	provision, err := openaiorgs.ProvisionProject(client, template, opts)
+	if provision != nil {
+		if printErr := printProjectProvision(cmd, provision); printErr != nil {
+			return printErr
+		}
+	}
+	if err != nil {
+		return wrapError("create project", err)
+	}
+	return nil
+}
+
+func idleProjects(ctx context.Context, cmd *cli.Command) error {
+	outputFormat := cmd.String("output")
+	switch outputFormat {
+	case OutputFormatPretty, "csv", OutputFormatJSON:
+	default:
+		return fmt.Errorf("unknown output format: %s", outputFormat)
+	}
+	days := cmd.Int("days")
+	if days <= 0 {
+		return fmt.Errorf("--days must be positive")
+	}
+	// The report is only printed before the prompt in pretty mode; in csv and json it
+	// is printed once, after archiving, with the results.
+	if cmd.Bool("archive") && outputFormat != OutputFormatPretty && !cmd.Bool("yes") {
+		return fmt.Errorf("--yes is required with --archive and --output %s", outputFormat)
+	}
+
+	client := newClient(ctx, cmd)
+	report, err := openaiorgs.FindIdleProjects(client, openaiorgs.IdleProjectOptions{
+		Window:      time.Duration(days) * 24 * time.Hour,
+		Concurrency: int(cmd.Int("concurrency")),
+	})
+	if err != nil {
+		return wrapError("find idle projects", err)
+	}
+
+	archive := cmd.Bool("archive") && len(report.Projects) > 0
+	if !archive || outputFormat == OutputFormatPretty {
+		if err := printIdleProjects(report, outputFormat); err != nil {
+			return err
+		}
+	}
+	if !archive {
+		return nil
+	}
+	if !cmd.Bool("yes") && !confirm(fmt.Sprintf("Archive %d idle projects?", len(report.Projects))) {
+		fmt.Println("Aborted.")
+		return nil
+	}
+
+	archived := openaiorgs.ArchiveIdleProjects(client, report)
+	if outputFormat != OutputFormatPretty {
+		if err := printIdleProjects(report, outputFormat); err != nil {
+			return err
+		}
+	} else {
+		for _, project := range report.Projects {
+			if project.ArchiveError != "" {
+				fmt.Printf("Failed to archive %s: %s\n", project.Project.ID, project.ArchiveError)
+			} else {
+				fmt.Printf("Archived %s (%s)\n", project.Project.ID, project.Project.Name)
+			}
+		}
+	}
+	if failed := len(report.Projects) - archived; failed > 0 {
+		return fmt.Errorf("failed to archive %d of %d projects", failed, len(report.Projects))
+	}
+	return nil
+}
+
+func printIdleProjects(report *openaiorgs.IdleProjectReport, outputFormat string) error {
+	switch outputFormat {
+	case OutputFormatJSON:
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal idle projects: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	case "csv":
+		return printIdleProjectsCSV(report)
+	}
+
+	fmt.Printf("%d of %d projects have had no requests, spend or audit activity since %s\n",
+		len(report.Projects), report.Checked, report.Since.Format("2006-01-02"))
+	if len(report.Projects) == 0 {
+		return nil
+	}
+	data := TableData{Headers: []string{"ID", "Name", "Created At", "Owners", "Users", "Service Accounts", "API Keys", "Last Audit Event"}}
+	for _, project := range report.Projects {
+		owners := strings.Join(project.Owners(), ", ")
+		if owners == "" {
+			owners = "none"
+		}
+		data.Rows = append(data.Rows, []string{
+			project.Project.ID,
+			project.Project.Name,
+			project.Project.CreatedAt.String(),
+			owners,
+			fmt.Sprintf("%d", len(project.Users)),
+			fmt.Sprintf("%d", len(project.ServiceAccounts)),
+			fmt.Sprintf("%d", len(project.APIKeys)),
+			lastAuditEvent(project),
+		})
+	}
+	printTableData(data)
+	return nil
+}
+
+func describeProject(ctx context.Context, cmd *cli.Command) error {
+	outputFormat := cmd.String("output")
+	switch outputFormat {
+	case OutputFormatPretty, OutputFormatJSON, "markdown":
+	default:
+		return fmt.Errorf("unknown output format: %s", outputFormat)
+	}
+	days := cmd.Int("days")
+	if days <= 0 {
+		return fmt.Errorf("--days must be positive")
+	}
+
+	client := newClient(ctx, cmd)
+	summary, err := openaiorgs.DescribeProject(client, cmd.String("project-id"), openaiorgs.ProjectSummaryOptions{
+		Window: time.Duration(days) * 24 * time.Hour,
+	})
+	if err != nil {
+		return wrapError("describe project", err)
+	}
+
+	if outputFormat == OutputFormatJSON {
+		data, err := json.MarshalIndent(summary, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal project summary: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+	printProjectSummary(summary, outputFormat == "markdown")
+	return nil
+}
+
+// printProjectSummary prints the summary as one table per section, either as plain
+// tables or as a Markdown document.
+func printProjectSummary(summary *openaiorgs.ProjectSummary, markdown bool) {
+	project := summary.Project
+	costs := fmt.Sprintf("%.2f %s", summary.Costs, strings.ToUpper(summary.Currency))
+	if markdown {
+		fmt.Printf("# Project: %s (%s)\n\n", markdownCell(project.Name), project.ID)
+		fmt.Printf("Status: %s  \nCreated At: %s  \n", project.Status, project.CreatedAt.String())
+		if project.ArchivedAt != nil {
+			fmt.Printf("Archived At: %s  \n", project.ArchivedAt.String())
+		}
+		fmt.Printf("Costs since %s: %s\n\n", summary.Since.Format("2006-01-02"), strings.TrimSpace(costs))
+	} else {
+		fmt.Printf("Project: %s (%s)\n", project.Name, project.ID)
+		fmt.Printf("Status: %s\nCreated At: %s\n", project.Status, project.CreatedAt.String())
+		if project.ArchivedAt != nil {
+			fmt.Printf("Archived At: %s\n", project.ArchivedAt.String())
+		}
+		fmt.Printf("Costs since %s: %s\n", summary.Since.Format("2006-01-02"), strings.TrimSpace(costs))
+	}
+
+	users := TableData{Headers: []string{"ID", "Email", "Name", "Role"}}
+	for _, user := range summary.Users {
+		users.Rows = append(users.Rows, []string{user.ID, user.Email, user.Name, user.Role})
+	}
+	serviceAccounts := TableData{Headers: []string{"ID", "Name", "Role", "Created At"}}
+	for _, account := range summary.ServiceAccounts {
+		serviceAccounts.Rows = append(serviceAccounts.Rows, []string{account.ID, account.Name, account.Role, account.CreatedAt.String()})
+	}
+	keys := TableData{Headers: []string{"ID", "Name", "Owner", "Created At"}}
+	for _, key := range summary.APIKeys {
+		keys.Rows = append(keys.Rows, []string{key.ID, key.Name, apiKeyOwner(key.Owner), key.CreatedAt.String()})
+	}
+	limits := TableData{Headers: []string{"Model", "Requests/min", "Tokens/min", "Images/min", "Requests/day"}}
+	for _, limit := range summary.RateLimits {
+		limits.Rows = append(limits.Rows, []string{
+			limit.Model,
+			fmt.Sprintf("%d", limit.MaxRequestsPer1Minute),
+			fmt.Sprintf("%d", limit.MaxTokensPer1Minute),
+			fmt.Sprintf("%d", limit.MaxImagesPer1Minute),
+			fmt.Sprintf("%d", limit.MaxRequestsPer1Day),
+		})
+	}
+	certificates := TableData{Headers: []string{"ID", "Name", "Expires At"}}
+	for _, cert := range summary.Certificates {
+		certificates.Rows = append(certificates.Rows, []string{cert.ID, cert.Name, cert.CertificateDetails.ExpiresAt.String()})
+	}
+	usage := TableData{Headers: []string{"Model", "Requests", "Input Tokens", "Output Tokens"}}
+	for _, u := range summary.Usage {
+		usage.Rows = append(usage.Rows, []string{
+			u.Model,
+			fmt.Sprintf("%d", u.Requests),
+			fmt.Sprintf("%d", u.InputTokens),
+			fmt.Sprintf("%d", u.OutputTokens),
+		})
+	}
+
+	for _, section := range []struct {
+		title string
+		data  TableData
+	}{
+		{"Users", users},
+		{"Service Accounts", serviceAccounts},
+		{"API Keys", keys},
+		{"Rate Limits", limits},
+		{"Active Certificates", certificates},
+		{fmt.Sprintf("Usage since %s", summary.Since.Format("2006-01-02")), usage},
+	} {
+		if markdown {
+			fmt.Printf("## %s\n\n", section.title)
+			printMarkdownTable(section.data)
+			continue
+		}
+		fmt.Printf("\n%s:\n", section.title)
+		if len(section.data.Rows) == 0 {
+			fmt.Println("None")
+			continue
+		}
+		printTableData(section.data)
+	}
+}
+
+// printMarkdownTable prints data as a Markdown table followed by a blank line.
+func printMarkdownTable(data TableData) {
+	if len(data.Rows) == 0 {
+		fmt.Printf("None.\n\n")
+		return
+	}
+	fmt.Printf("| %s |\n", strings.Join(data.Headers, " | "))
+	fmt.Printf("|%s\n", strings.Repeat("---|", len(data.Headers)))
+	for _, row := range data.Rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = markdownCell(cell)
+		}
+		fmt.Printf("| %s |\n", strings.Join(cells, " | "))
+	}
+	fmt.Println()
+}
+
+// apiKeyOwner describes who owns an API key, as "user <email>" or
+// "service_account <name>".
+func apiKeyOwner(owner openaiorgs.Owner) string {
+	switch {
+	case owner.User != nil:
+		return string(openaiorgs.OwnerTypeUser) + " " + owner.User.Email
+	case owner.SA != nil:
+		return string(openaiorgs.OwnerTypeServiceAccount) + " " + owner.SA.Name
+	}
+	return strings.TrimSpace(string(owner.Type) + " " + owner.Name)
+}
+
+// printIdleProjectsCSV writes one row per idle project, with its members and keys,
+// suitable for sending to project owners before archiving.
+func printIdleProjectsCSV(report *openaiorgs.IdleProjectReport) error {
+	w := csv.NewWriter(os.Stdout)
+	if err := w.Write([]string{"project_id", "project_name", "created_at", "owners", "users", "service_accounts", "api_keys", "last_audit_event", "archived"}); err != nil {
+		return err
+	}
+	for _, project := range report.Projects {
+		var users, serviceAccounts, keys []string
+		for _, user := range project.Users {
+			users = append(users, user.Email+":"+user.Role)
+		}
+		for _, account := range project.ServiceAccounts {
+			serviceAccounts = append(serviceAccounts, account.Name)
+		}
+		for _, key := range project.APIKeys {
+			keys = append(keys, key.Name+" ("+key.ID+")")
+		}
+		archived := "no"
+		if project.Archived {
+			archived = "yes"
+		}
+		if err := w.Write([]string{
+			project.Project.ID,
+			project.Project.Name,
+			project.Project.CreatedAt.String(),
+			strings.Join(project.Owners(), ";"),
+			strings.Join(users, ";"),
+			strings.Join(serviceAccounts, ";"),
+			strings.Join(keys, ";"),
+			lastAuditEvent(project),
+			archived,
+		}); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func lastAuditEvent(project openaiorgs.IdleProject) string {
+	if project.LastAuditEvent == nil {
+		return "never"
+	}
+	return project.LastAuditEvent.Format(time.RFC3339)
+}
+
+func printProjectProvision(cmd *cli.Command, provision *openaiorgs.ProjectProvision) error {
+	if cmd.String("output") == OutputFormatJSON {
+		data, err := json.MarshalIndent(provision, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal project provisioning: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	project := provision.ProjectName
+	if provision.ProjectID != "" {
+		project = fmt.Sprintf("%s (%s)", provision.ProjectName, provision.ProjectID)
+	}
+	fmt.Printf("Create project %s (%s)\n", project, provision.Status)
+	data := TableData{Headers: []string{"Step", "Target", "Detail", "Status", "Error"}}
+	for _, step := range provision.Steps {
+		action := step.Action
+		if step.Rollback {
+			action = "rollback " + action
+		}
+		data.Rows = append(data.Rows, []string{action, step.Target, step.Detail, step.Status, step.Error})
+	}
+	printTableData(data)
+	return nil
+}
+
 func retrieveProject(ctx context.Context, cmd *cli.Command) error {
 	client := newClient(ctx, cmd)
 
@@ -1,6 +1,9 @@
 package cmd
 
 import (
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -314,3 +317,263 @@ func TestArchiveProjectCommand(t *testing.T) {
 		})
 	}
 }
+
+// mockProjectClone sets up proj_src with two service accounts and the responses for
+// creating proj_new from it.
+func mockProjectClone(h *cmdTestHelper) {
+	active := true
+	h.mockResponse("GET", "/organization/projects/proj_src", 200, createMockProject("proj_src", "template", "active", false))
+	h.mockResponse("GET", "/organization/projects/proj_src/users", 200, openaiorgs.ListResponse[openaiorgs.ProjectUser]{Object: "list", Data: []openaiorgs.ProjectUser{
+		{ID: "user_1", Email: "lead@example.com", Role: "owner"},
+	}})
+	h.mockResponse("GET", "/organization/projects/proj_src/service_accounts", 200, openaiorgs.ListResponse[openaiorgs.ProjectServiceAccount]{Object: "list", Data: []openaiorgs.ProjectServiceAccount{
+		{ID: "svc_1", Name: "ci"},
+	}})
+	h.mockResponse("GET", "/organization/projects/proj_src/rate_limits", 200, openaiorgs.ListResponse[openaiorgs.ProjectRateLimit]{Object: "list", Data: []openaiorgs.ProjectRateLimit{
+		{ID: "rl_src", Model: "gpt-4o", MaxRequestsPer1Minute: 500},
+	}})
+	h.mockResponse("GET", "/organization/projects/proj_src/certificates", 200, openaiorgs.ListResponse[openaiorgs.Certificate]{Object: "list", Data: []openaiorgs.Certificate{
+		{ID: "cert_a", Active: &active},
+	}})
+
+	h.mockResponse("POST", "/organization/projects", 200, createMockProject("proj_new", "team-x", "active", false))
+	h.mockResponse("POST", "/organization/projects/proj_new/users", 200, openaiorgs.ProjectUser{ID: "user_1", Role: "owner"})
+	h.mockResponse("POST", "/organization/projects/proj_new/service_accounts", 200, openaiorgs.ProjectServiceAccount{
+		ID:     "svc_new",
+		Name:   "ci",
+		APIKey: &openaiorgs.ProjectServiceAccountAPIKey{ID: "key_new", Value: "sk-svcacct-0123456789abcdef"},
+	})
+	h.mockResponse("GET", "/organization/projects/proj_new/rate_limits", 200, openaiorgs.ListResponse[openaiorgs.ProjectRateLimit]{Object: "list", Data: []openaiorgs.ProjectRateLimit{
+		{ID: "rl_new", Model: "gpt-4o", MaxRequestsPer1Minute: 10000},
+	}})
+	h.mockResponse("POST", "/organization/projects/proj_new/rate_limits/rl_new", 200, openaiorgs.ProjectRateLimit{ID: "rl_new"})
+	h.mockResponse("POST", "/organization/projects/proj_new/certificates/activate", 200, openaiorgs.CertificateActivationResponse{Success: true})
+}
+
+func TestCloneProjectCommand(t *testing.T) {
+	h := newCmdTestHelper(t)
+	defer h.cleanup()
+	mockProjectClone(h)
+
+	var err error
+	output := captureOutput(func() {
+		err = h.runCmd(ProjectsCommand(), []string{"projects", "clone", "--from", "proj_src", "--name", "team-x", "--dry-run"})
+	})
+	if err != nil {
+		t.Fatalf("runCmd() dry run error = %v", err)
+	}
+	for _, want := range []string{"Create project team-x (planned)", "add_user | user_1 | owner | planned", "create_service_account | ci |  | planned"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got: %s", want, output)
+		}
+	}
+	h.assertRequest("POST", "/organization/projects", 0)
+
+	err = h.runCmd(ProjectsCommand(), []string{"projects", "clone", "--from", "proj_src", "--name", "team-x", "--yes"})
+	if err == nil || !strings.Contains(err.Error(), "--secret-sink") {
+		t.Errorf("expected secret sink error, got %v", err)
+	}
+
+	secretPath := filepath.Join(t.TempDir(), "{name}.key")
+	err = h.runCmd(ProjectsCommand(), []string{
+		"--output", "json", "projects", "clone", "--from", "proj_src", "--name", "team-x",
+		"--secret-sink", "file", "--secret-path", secretPath,
+	})
+	if err == nil || !strings.Contains(err.Error(), "--yes or --dry-run is required") {
+		t.Errorf("expected --yes error, got %v", err)
+	}
+	h.assertRequest("POST", "/organization/projects", 0)
+
+	output = captureOutput(func() {
+		err = h.runCmd(ProjectsCommand(), []string{
+			"--output", "json", "projects", "clone", "--from", "proj_src", "--name", "team-x", "--yes",
+			"--secret-sink", "file", "--secret-path", secretPath,
+		})
+	})
+	if err != nil {
+		t.Fatalf("runCmd() error = %v", err)
+	}
+	var provision openaiorgs.ProjectProvision
+	if err := json.Unmarshal([]byte(output), &provision); err != nil {
+		t.Fatalf("failed to parse JSON output: %v\n%s", err, output)
+	}
+	if provision.Status != openaiorgs.ProjectProvisionCompleted || provision.ProjectID != "proj_new" || len(provision.Steps) != 5 {
+		t.Errorf("unexpected provision: %+v", provision)
+	}
+	if strings.Contains(output, "sk-svcacct-0123456789abcdef") {
+		t.Error("API key printed to stdout")
+	}
+	key, err := os.ReadFile(strings.ReplaceAll(secretPath, "{name}", "ci"))
+	if err != nil || strings.TrimSpace(string(key)) != "sk-svcacct-0123456789abcdef" {
+		t.Errorf("key file = %q, %v", key, err)
+	}
+	h.assertRequest("POST", "/organization/projects/proj_new/certificates/activate", 1)
+}
+
+func TestCreateProjectFromTemplateCommand(t *testing.T) {
+	h := newCmdTestHelper(t)
+	defer h.cleanup()
+	mockProjectClone(h)
+
+	path := filepath.Join(t.TempDir(), "template.yaml")
+	template := "name: team-x\nusers:\n  - user_id: user_1\n    role: owner\nrate_limits:\n  gpt-4o:\n    max_requests_per_1_minute: 500\n"
+	if err := os.WriteFile(path, []byte(template), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var err error
+	output := captureOutput(func() {
+		err = h.runCmd(ProjectsCommand(), []string{"projects", "create", "--template", path, "--yes"})
+	})
+	if err != nil {
+		t.Fatalf("runCmd() error = %v", err)
+	}
+	for _, want := range []string{"Create project team-x (proj_new) (completed)", "set_rate_limit | gpt-4o | rl_new | done"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got: %s", want, output)
+		}
+	}
+	h.assertRequest("POST", "/organization/projects/proj_new/service_accounts", 0)
+
+	if err := h.runCmd(ProjectsCommand(), []string{"projects", "create"}); err == nil || !strings.Contains(err.Error(), "--name is required") {
+		t.Errorf("expected --name error, got %v", err)
+	}
+}
+
+func TestIdleProjectsCommand(t *testing.T) {
+	old := openaiorgs.UnixSeconds(time.Now().AddDate(-1, 0, 0))
+	setup := func(h *cmdTestHelper) {
+		h.mockResponse("GET", "/organization/projects", 200, openaiorgs.ListResponse[openaiorgs.Project]{Object: "list", Data: []openaiorgs.Project{
+			{ID: "proj_idle", Name: "Idle", CreatedAt: old, Status: "active"},
+			{ID: "proj_used", Name: "Used", CreatedAt: old, Status: "active"},
+		}})
+		h.mockResponse("GET", "/organization/usage/completions", 200, openaiorgs.CompletionsUsageResponse{Data: []openaiorgs.CompletionsUsageBucket{
+			{Results: []openaiorgs.CompletionsUsageResult{{ProjectID: "proj_used", NumModelRequests: 1}}},
+		}})
+		h.mockResponse("GET", "/organization/usage/embeddings", 200, openaiorgs.EmbeddingsUsageResponse{})
+		h.mockResponse("GET", "/organization/usage/images", 200, openaiorgs.ImagesUsageResponse{})
+		h.mockResponse("GET", "/organization/costs", 200, openaiorgs.CostsUsageResponse{})
+		h.mockResponse("GET", "/organization/audit_logs", 200, openaiorgs.ListResponse[openaiorgs.AuditLog]{Object: "list"})
+		h.mockResponse("GET", "/organization/projects/proj_idle/users", 200, openaiorgs.ListResponse[openaiorgs.ProjectUser]{Object: "list", Data: []openaiorgs.ProjectUser{
+			{ID: "user_1", Email: "owner@example.com", Role: "owner"},
+		}})
+		h.mockResponse("GET", "/organization/projects/proj_idle/service_accounts", 200, openaiorgs.ListResponse[openaiorgs.ProjectServiceAccount]{Object: "list"})
+		h.mockResponse("GET", "/organization/projects/proj_idle/api_keys", 200, openaiorgs.ListResponse[openaiorgs.ProjectApiKey]{Object: "list", Data: []openaiorgs.ProjectApiKey{
+			{ID: "key_1", Name: "old-key"},
+		}})
+		h.mockResponse("POST", "/organization/projects/proj_idle/archive", 200, createMockProject("proj_idle", "Idle", "archived", true))
+	}
+
+	t.Run("report", func(t *testing.T) {
+		h := newCmdTestHelper(t)
+		defer h.cleanup()
+		setup(h)
+
+		var err error
+		output := captureOutput(func() {
+			err = h.runCmd(ProjectsCommand(), []string{"projects", "idle", "--days", "60"})
+		})
+		if err != nil {
+			t.Fatalf("runCmd() error = %v", err)
+		}
+		for _, want := range []string{"1 of 2 projects have had no requests", "proj_idle | Idle |", "| owner@example.com | 1 | 0 | 1 | never"} {
+			if !strings.Contains(output, want) {
+				t.Errorf("Expected output to contain %q, got: %s", want, output)
+			}
+		}
+		if strings.Contains(output, "proj_used") {
+			t.Errorf("proj_used should not be listed: %s", output)
+		}
+		h.assertRequest("POST", "/organization/projects/proj_idle/archive", 0)
+	})
+
+	t.Run("archive csv", func(t *testing.T) {
+		h := newCmdTestHelper(t)
+		defer h.cleanup()
+		setup(h)
+
+		var err error
+		output := captureOutput(func() {
+			err = h.runCmd(ProjectsCommand(), []string{"projects", "idle", "--archive", "--yes", "--output", "csv"})
+		})
+		if err != nil {
+			t.Fatalf("runCmd() error = %v", err)
+		}
+		lines := strings.Split(strings.TrimSpace(output), "\n")
+		if len(lines) != 2 || !strings.HasPrefix(lines[1], "proj_idle,Idle,") || !strings.HasSuffix(lines[1], ",owner@example.com,owner@example.com:owner,,old-key (key_1),never,yes") {
+			t.Errorf("unexpected CSV output: %s", output)
+		}
+		h.assertRequest("POST", "/organization/projects/proj_idle/archive", 1)
+	})
+
+	t.Run("archive json requires yes", func(t *testing.T) {
+		h := newCmdTestHelper(t)
+		defer h.cleanup()
+		defer setConfirmInput("y\n")()
+		setup(h)
+
+		var err error
+		output := captureOutput(func() {
+			err = h.runCmd(ProjectsCommand(), []string{"projects", "idle", "--archive", "--output", "json"})
+		})
+		if err == nil || !strings.Contains(err.Error(), "--yes is required") {
+			t.Fatalf("expected --yes error, got %v", err)
+		}
+		if output != "" {
+			t.Errorf("expected no output, got: %s", output)
+		}
+		h.assertRequest("POST", "/organization/projects/proj_idle/archive", 0)
+	})
+}
+
+func TestDescribeProjectCommand(t *testing.T) {
+	setup := func(h *cmdTestHelper) {
+		h.mockResponse("GET", "/organization/projects/proj_1", 200, createMockProject("proj_1", "Team", "active", false))
+		h.mockResponse("GET", "/organization/projects/proj_1/users", 200, openaiorgs.ListResponse[openaiorgs.ProjectUser]{Object: "list", Data: []openaiorgs.ProjectUser{
+			{ID: "user_1", Email: "owner@example.com", Role: "owner"},
+		}})
+		h.mockResponse("GET", "/organization/projects/proj_1/service_accounts", 200, openaiorgs.ListResponse[openaiorgs.ProjectServiceAccount]{Object: "list"})
+		h.mockResponse("GET", "/organization/projects/proj_1/api_keys", 200, openaiorgs.ListResponse[openaiorgs.ProjectApiKey]{Object: "list", Data: []openaiorgs.ProjectApiKey{
+			{ID: "key_1", Name: "deploy", Owner: openaiorgs.Owner{Type: openaiorgs.OwnerTypeUser, User: &openaiorgs.User{ID: "user_1", Email: "owner@example.com"}}},
+		}})
+		h.mockResponse("GET", "/organization/projects/proj_1/rate_limits", 200, openaiorgs.ListResponse[openaiorgs.ProjectRateLimit]{Object: "list", Data: []openaiorgs.ProjectRateLimit{
+			{ID: "rl_1", Model: "gpt-4o", MaxRequestsPer1Minute: 500, MaxTokensPer1Minute: 30000},
+		}})
+		h.mockResponse("GET", "/organization/projects/proj_1/certificates", 200, openaiorgs.ListResponse[openaiorgs.Certificate]{Object: "list"})
+		h.mockResponse("GET", "/organization/costs", 200, openaiorgs.CostsUsageResponse{Data: []openaiorgs.CostsUsageBucket{
+			{Results: []openaiorgs.CostsUsageResult{{Amount: openaiorgs.CostAmount{Value: 12.5, Currency: "usd"}}}},
+		}})
+		h.mockResponse("GET", "/organization/usage/completions", 200, openaiorgs.CompletionsUsageResponse{Data: []openaiorgs.CompletionsUsageBucket{
+			{Results: []openaiorgs.CompletionsUsageResult{{Model: "gpt-4o", NumModelRequests: 3, InputTokens: 120, OutputTokens: 30}}},
+		}})
+		h.mockResponse("GET", "/organization/usage/embeddings", 200, openaiorgs.EmbeddingsUsageResponse{})
+	}
+
+	for _, tt := range []struct {
+		format string
+		want   []string
+	}{
+		{"pretty", []string{"Project: Team (proj_1)", "12.50 USD", "user_1 | owner@example.com |  | owner", "key_1 | deploy | user owner@example.com |", "gpt-4o | 500 | 30000 | 0 | 0", "Active Certificates:\nNone", "gpt-4o | 3 | 120 | 30"}},
+		{"markdown", []string{"# Project: Team (proj_1)", "## API Keys", "| key_1 | deploy | user owner@example.com |", "## Active Certificates\n\nNone.", "| gpt-4o | 3 | 120 | 30 |"}},
+		{"json", []string{`"costs": 12.5`, `"model": "gpt-4o"`, `"rate_limits": [`}},
+	} {
+		t.Run(tt.format, func(t *testing.T) {
+			h := newCmdTestHelper(t)
+			defer h.cleanup()
+			setup(h)
+
+			var err error
+			output := captureOutput(func() {
+				err = h.runCmd(ProjectsCommand(), []string{"projects", "describe", "--project-id", "proj_1", "--output", tt.format})
+			})
+			if err != nil {
+				t.Fatalf("runCmd() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(output, want) {
+					t.Errorf("Expected output to contain %q, got: %s", want, output)
+				}
+			}
+		})
+	}
+}
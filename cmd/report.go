@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	openaiorgs "github.com/klauern/openai-orgs"
+	"github.com/urfave/cli/v3"
+)
+
+func ReportCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "report",
+		Usage: "Generate organization-wide reports",
+		Commands: []*cli.Command{
+			accessReportCommand(),
+		},
+	}
+}
+
+func accessReportCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "access",
+		Usage: "Report who has access to what, flagging stale users, orphaned keys and projects without an owner",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:  "stale-days",
+				Usage: "Flag users with no successful login in this many days",
+				Value: 90,
+			},
+			&cli.BoolFlag{
+				Name:  "include-archived",
+				Usage: "Include archived projects",
+			},
+			&cli.IntFlag{
+				Name:  "concurrency",
+				Usage: "Number of projects to fetch in parallel",
+				Value: openaiorgs.DefaultInventoryConcurrency,
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "Output format (markdown, csv, json)",
+				Value:   "markdown",
+			},
+		},
+		Action: accessReport,
+	}
+}
+
+func accessReport(ctx context.Context, cmd *cli.Command) error {
+	outputFormat := cmd.String("output")
+	switch outputFormat {
+	case "markdown", "csv", "json":
+	default:
+		return fmt.Errorf("unknown output format: %s", outputFormat)
+	}
+
+	staleDays := cmd.Int("stale-days")
+	if staleDays <= 0 {
+		return fmt.Errorf("--stale-days must be greater than 0")
+	}
+
+	client := newClient(ctx, cmd)
+	review, err := openaiorgs.BuildAccessReview(client, openaiorgs.AccessReviewOptions{
+		StaleAfter:      time.Duration(staleDays) * 24 * time.Hour,
+		IncludeArchived: cmd.Bool("include-archived"),
+		Concurrency:     int(cmd.Int("concurrency")),
+	})
+	if err != nil {
+		return wrapError("build access review", err)
+	}
+
+	switch outputFormat {
+	case "csv":
+		return printAccessReviewCSV(review)
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(review)
+	default:
+		printAccessReviewMarkdown(review)
+		return nil
+	}
+}
+
+func printAccessReviewCSV(review *openaiorgs.AccessReview) error {
+	w := csv.NewWriter(os.Stdout)
+	if err := w.Write([]string{"scope", "project_id", "project_name", "principal_type", "principal_id", "name", "email", "role", "owner", "last_login", "flags"}); err != nil {
+		return err
+	}
+	for _, entry := range review.Entries {
+		if err := w.Write([]string{
+			accessScope(entry),
+			entry.ProjectID,
+			entry.ProjectName,
+			entry.PrincipalType,
+			entry.PrincipalID,
+			entry.Name,
+			entry.Email,
+			entry.Role,
+			entry.Owner,
+			formatLastLogin(entry.LastLogin),
+			strings.Join(entry.Flags, ";"),
+		}); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func printAccessReviewMarkdown(review *openaiorgs.AccessReview) {
+	fmt.Printf("# Access Review\n\n")
+	fmt.Printf("Generated: %s  \n", review.GeneratedAt.UTC().Format(time.RFC3339))
+	fmt.Printf("Stale login threshold: %d days\n\n", int(review.StaleAfter.Hours()/24))
+
+	fmt.Printf("## Findings\n\n")
+	findings := review.SortedFindings()
+	if len(findings) == 0 {
+		fmt.Printf("No issues found.\n\n")
+	} else {
+		for _, finding := range findings {
+			fmt.Printf("- **%s**: %s\n", finding.Kind, finding.Message)
+		}
+		fmt.Println()
+	}
+
+	// Entries are grouped by scope, keeping the order they were collected in.
+	var scopes []string
+	grouped := make(map[string][]openaiorgs.AccessEntry)
+	for _, entry := range review.Entries {
+		title := "Organization"
+		if entry.ProjectID != "" {
+			title = fmt.Sprintf("Project: %s (%s)", entry.ProjectName, entry.ProjectID)
+		}
+		if _, ok := grouped[title]; !ok {
+			scopes = append(scopes, title)
+		}
+		grouped[title] = append(grouped[title], entry)
+	}
+
+	for _, title := range scopes {
+		fmt.Printf("## %s\n\n", title)
+		fmt.Println("| Type | ID | Name | Email | Role | Owner | Last Login | Flags |")
+		fmt.Println("|---|---|---|---|---|---|---|---|")
+		for _, entry := range grouped[title] {
+			fmt.Printf("| %s | %s | %s | %s | %s | %s | %s | %s |\n",
+				entry.PrincipalType,
+				entry.PrincipalID,
+				markdownCell(entry.Name),
+				markdownCell(entry.Email),
+				entry.Role,
+				markdownCell(entry.Owner),
+				formatLastLogin(entry.LastLogin),
+				strings.Join(entry.Flags, ", "),
+			)
+		}
+		fmt.Println()
+	}
+}
+
+func accessScope(entry openaiorgs.AccessEntry) string {
+	if entry.ProjectID == "" {
+		return "organization"
+	}
+	return "project"
+}
+
+func formatLastLogin(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// markdownCell escapes characters that would break a Markdown table row.
+func markdownCell(s string) string {
+	return strings.ReplaceAll(s, "|", "\\|")
+}
@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	openaiorgs "github.com/klauern/openai-orgs"
+)
+
+func mockAccessReviewResponses(h *cmdTestHelper) {
+	h.mockResponse("GET", "/organization/users", 200, openaiorgs.ListResponse[openaiorgs.User]{
+		Object: "list",
+		Data: []openaiorgs.User{
+			{ID: "user_active", Email: "active@example.com", Role: "owner"},
+			{ID: "user_idle", Email: "idle@example.com", Role: "reader"},
+		},
+	})
+	h.mockResponse("GET", "/organization/audit_logs", 200, openaiorgs.ListResponse[openaiorgs.AuditLog]{
+		Object: "list",
+		Data: []openaiorgs.AuditLog{{
+			ID:          "log_1",
+			Type:        "login.succeeded",
+			EffectiveAt: openaiorgs.UnixSeconds(time.Now().Add(-time.Hour)),
+			Actor: openaiorgs.Actor{
+				Type:    "session",
+				Session: &openaiorgs.Session{User: openaiorgs.AuditUser{ID: "user_active"}},
+			},
+		}},
+	})
+	h.mockResponse("GET", "/organization/projects", 200, openaiorgs.ListResponse[openaiorgs.Project]{
+		Object: "list",
+		Data:   []openaiorgs.Project{{ID: "proj_1", Name: "Alpha"}},
+	})
+	h.mockResponse("GET", "/organization/projects/proj_1/users", 200, openaiorgs.ListResponse[openaiorgs.ProjectUser]{
+		Object: "list",
+		Data:   []openaiorgs.ProjectUser{{ID: "user_idle", Email: "idle@example.com", Role: "member"}},
+	})
+	h.mockResponse("GET", "/organization/projects/proj_1/service_accounts", 200, openaiorgs.ListResponse[openaiorgs.ProjectServiceAccount]{
+		Object: "list",
+	})
+	h.mockResponse("GET", "/organization/projects/proj_1/api_keys", 200, openaiorgs.ListResponse[openaiorgs.ProjectApiKey]{
+		Object: "list",
+		Data: []openaiorgs.ProjectApiKey{{
+			ID:    "key_1",
+			Name:  "legacy",
+			Owner: openaiorgs.Owner{Type: openaiorgs.OwnerTypeUser, User: &openaiorgs.User{ID: "user_gone", Email: "gone@example.com"}},
+		}},
+	})
+}
+
+func TestAccessReportCommand(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         []string
+		wantErr      string
+		wantContains []string
+	}{
+		{
+			name: "markdown",
+			args: []string{"report", "access", "--stale-days", "30"},
+			wantContains: []string{
+				"# Access Review",
+				"Stale login threshold: 30 days",
+				"**no_project_owner**: project Alpha (proj_1) has no owner",
+				"**orphaned_key**: API key legacy in project Alpha is owned by gone@example.com",
+				"**stale_login**: idle@example.com has not logged in for 30 days",
+				"## Project: Alpha (proj_1)",
+				"| api_key | key_1 | legacy |  |  | gone@example.com |  | orphaned_key |",
+			},
+		},
+		{
+			name: "csv",
+			args: []string{"report", "access", "--output", "csv"},
+			wantContains: []string{
+				"scope,project_id,project_name,principal_type,principal_id,name,email,role,owner,last_login,flags",
+				"organization,,,user,user_idle,,idle@example.com,reader,,,stale_login",
+				"project,proj_1,Alpha,api_key,key_1,legacy,,,gone@example.com,,orphaned_key",
+			},
+		},
+		{
+			name:         "json",
+			args:         []string{"report", "access", "--output", "json"},
+			wantContains: []string{`"kind": "orphaned_key"`, `"principal_id": "user_active"`},
+		},
+		{
+			name:    "invalid format",
+			args:    []string{"report", "access", "--output", "xml"},
+			wantErr: "unknown output format: xml",
+		},
+		{
+			name:    "invalid stale days",
+			args:    []string{"report", "access", "--stale-days", "0"},
+			wantErr: "--stale-days must be greater than 0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newCmdTestHelper(t)
+			defer h.cleanup()
+			mockAccessReviewResponses(h)
+
+			var err error
+			output := captureOutput(func() {
+				err = h.runCmd(ReportCommand(), tt.args)
+			})
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("runCmd() error = %v", err)
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(output, want) {
+					t.Errorf("expected output to contain %q, got: %s", want, output)
+				}
+			}
+			h.assertRequest("GET", "/organization/projects/proj_1/api_keys", 1)
+		})
+	}
+}
+
+func TestAccessReportCommand_Error(t *testing.T) {
+	h := newCmdTestHelper(t)
+	defer h.cleanup()
+
+	h.mockResponse("GET", "/organization/users", 500, map[string]string{"error": "boom"})
+
+	err := h.runCmd(ReportCommand(), []string{"report", "access"})
+	if err == nil || !strings.Contains(err.Error(), "failed to build access review") {
+		t.Errorf("expected wrapped error, got %v", err)
+	}
+}
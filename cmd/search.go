@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	openaiorgs "github.com/klauern/openai-orgs"
+	"github.com/urfave/cli/v3"
+)
+
+func SearchCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "search",
+		Usage:     "Find projects, users, invites, service accounts, API keys and certificates by a fragment of their ID, name, email or redacted key",
+		ArgsUsage: "<term>",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "include-archived",
+				Usage: "Include archived projects",
+			},
+			&cli.IntFlag{
+				Name:  "concurrency",
+				Usage: "Number of projects to search in parallel",
+				Value: openaiorgs.DefaultInventoryConcurrency,
+			},
+		},
+		Action: search,
+	}
+}
+
+func search(ctx context.Context, cmd *cli.Command) error {
+	if cmd.Args().Len() != 1 {
+		return fmt.Errorf("expected exactly one search term")
+	}
+	term := cmd.Args().First()
+
+	client := newClient(ctx, cmd)
+	matches, err := openaiorgs.Search(client, term, openaiorgs.SearchOptions{
+		IncludeArchived: cmd.Bool("include-archived"),
+		Concurrency:     int(cmd.Int("concurrency")),
+	})
+	if err != nil {
+		return wrapError("search", err)
+	}
+
+	if cmd.String("output") == OutputFormatJSON {
+		data, err := json.MarshalIndent(matches, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal search results: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(matches) == 0 {
+		fmt.Printf("No matches for %q\n", term)
+		return nil
+	}
+	data := TableData{
+		Headers: []string{"Kind", "ID", "Name", "Matched", "Project"},
+		Rows:    make([][]string, len(matches)),
+	}
+	for i, match := range matches {
+		project := ""
+		if match.ProjectID != "" {
+			project = fmt.Sprintf("%s (%s)", match.ProjectName, match.ProjectID)
+		}
+		data.Rows[i] = []string{match.Kind, match.ID, match.Name, match.Field, project}
+	}
+	printTableData(data)
+	return nil
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	openaiorgs "github.com/klauern/openai-orgs"
+)
+
+func mockSearchResponses(h *cmdTestHelper) {
+	h.mockResponse("GET", "/organization/projects", 200, openaiorgs.ListResponse[openaiorgs.Project]{
+		Object: "list",
+		Data:   []openaiorgs.Project{{ID: "proj_1", Name: "Alpha"}},
+	})
+	h.mockResponse("GET", "/organization/users", 200, openaiorgs.ListResponse[openaiorgs.User]{
+		Object: "list",
+		Data:   []openaiorgs.User{{ID: "user_1", Email: "dana@example.com"}},
+	})
+	h.mockResponse("GET", "/organization/invites", 200, openaiorgs.ListResponse[openaiorgs.Invite]{Object: "list"})
+	h.mockResponse("GET", "/organization/admin_api_keys", 200, openaiorgs.ListResponse[openaiorgs.AdminAPIKey]{Object: "list"})
+	h.mockResponse("GET", "/organization/certificates", 200, openaiorgs.ListResponse[openaiorgs.Certificate]{Object: "list"})
+	h.mockResponse("GET", "/organization/projects/proj_1/users", 200, openaiorgs.ListResponse[openaiorgs.ProjectUser]{Object: "list"})
+	h.mockResponse("GET", "/organization/projects/proj_1/service_accounts", 200, openaiorgs.ListResponse[openaiorgs.ProjectServiceAccount]{
+		Object: "list",
+		Data:   []openaiorgs.ProjectServiceAccount{{ID: "svc_1", Name: "batch"}},
+	})
+	h.mockResponse("GET", "/organization/projects/proj_1/api_keys", 200, openaiorgs.ListResponse[openaiorgs.ProjectApiKey]{
+		Object: "list",
+		Data:   []openaiorgs.ProjectApiKey{{ID: "key_1", Name: "laptop", RedactedValue: "sk-proj-...Q7aB"}},
+	})
+}
+
+func TestSearchCommand(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         []string
+		wantErr      string
+		wantContains []string
+	}{
+		{
+			name:         "redacted key suffix",
+			args:         []string{"search", "q7ab"},
+			wantContains: []string{"Kind | ID | Name | Matched | Project", "api_key | key_1 | laptop | redacted_value | Alpha (proj_1)"},
+		},
+		{
+			name:         "service account as json",
+			args:         []string{"--output", "json", "search", "batch"},
+			wantContains: []string{`"kind": "service_account"`, `"project_id": "proj_1"`},
+		},
+		{
+			name:         "no matches",
+			args:         []string{"search", "nothing"},
+			wantContains: []string{`No matches for "nothing"`},
+		},
+		{
+			name:    "missing term",
+			args:    []string{"search"},
+			wantErr: "expected exactly one search term",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newCmdTestHelper(t)
+			defer h.cleanup()
+			mockSearchResponses(h)
+
+			var err error
+			output := captureOutput(func() {
+				err = h.runCmd(SearchCommand(), tt.args)
+			})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("runCmd() error = %v", err)
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(output, want) {
+					t.Errorf("Expected output to contain %q, got: %s", want, output)
+				}
+			}
+		})
+	}
+}
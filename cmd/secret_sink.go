@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	openaiorgs "github.com/klauern/openai-orgs"
+	"github.com/urfave/cli/v3"
+)
+
+// secretSinkFlags returns the flags that configure where a newly issued key is stored.
+func secretSinkFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  "secret-sink",
+			Usage: "Store the new key instead of printing it (file, dotenv, k8s-secret, vault)",
+		},
+		&cli.StringFlag{
+			Name:  "secret-path",
+			Usage: "File to write (created with 0600 permissions), or secret path within the Vault mount",
+		},
+		&cli.StringFlag{
+			Name:  "secret-key",
+			Usage: "Variable name (dotenv), data key (k8s-secret) or field (vault) for the key",
+			Value: openaiorgs.DefaultSecretKey,
+		},
+		&cli.StringFlag{
+			Name:  "secret-name",
+			Usage: "Kubernetes Secret name (default: derived from the resource name)",
+		},
+		&cli.StringFlag{
+			Name:  "secret-namespace",
+			Usage: "Kubernetes namespace for the Secret",
+		},
+		&cli.StringFlag{
+			Name:    "vault-addr",
+			Usage:   "Vault server address",
+			Sources: cli.EnvVars("VAULT_ADDR"),
+		},
+		&cli.StringFlag{
+			Name:    "vault-token",
+			Usage:   "Vault token",
+			Sources: cli.EnvVars("VAULT_TOKEN"),
+		},
+		&cli.StringFlag{
+			Name:    "vault-namespace",
+			Usage:   "Vault Enterprise namespace",
+			Sources: cli.EnvVars("VAULT_NAMESPACE"),
+		},
+		&cli.StringFlag{
+			Name:  "vault-mount",
+			Usage: "Vault KV v2 mount path",
+			Value: openaiorgs.DefaultVaultMount,
+		},
+	}
+}
+
+// secretSinkFromFlags builds the sink selected with --secret-sink, or returns nil if none was selected.
+func secretSinkFromFlags(cmd *cli.Command) (openaiorgs.SecretSink, error) {
+	if cmd.String("secret-sink") == "" {
+		return nil, nil
+	}
+	sink, err := openaiorgs.NewSecretSink(secretSinkConfigFromFlags(cmd))
+	if err != nil {
+		return nil, fmt.Errorf("invalid secret sink: %w", err)
+	}
+	return sink, nil
+}
+
+// secretNamePlaceholder is replaced by the resource name in --secret-path and
+// --secret-name when a command issues several keys.
+const secretNamePlaceholder = "{name}"
+
+// namedSecretSinkFromFlags is secretSinkFromFlags for commands that issue several keys.
+// Each key is stored in its own sink, with {name} in --secret-path and --secret-name
+// replaced by the resource name (converted with KubernetesName). The placeholder is
+// required in --secret-path when more than one key will be issued.
+func namedSecretSinkFromFlags(cmd *cli.Command, keys int) (openaiorgs.SecretSink, error) {
+	if cmd.String("secret-sink") == "" {
+		return nil, nil
+	}
+	cfg := secretSinkConfigFromFlags(cmd)
+	if keys > 1 && !strings.Contains(cfg.Path, secretNamePlaceholder) {
+		return nil, fmt.Errorf("--secret-path must contain %s when %d keys are issued", secretNamePlaceholder, keys)
+	}
+	// Check the configuration before any key is issued.
+	if _, err := openaiorgs.NewSecretSink(cfg); err != nil {
+		return nil, fmt.Errorf("invalid secret sink: %w", err)
+	}
+	return openaiorgs.SecretSinkFunc(func(secret openaiorgs.Secret) (string, error) {
+		named := cfg
+		name := openaiorgs.KubernetesName(secret.Name)
+		named.Path = strings.ReplaceAll(cfg.Path, secretNamePlaceholder, name)
+		named.SecretName = strings.ReplaceAll(cfg.SecretName, secretNamePlaceholder, name)
+		sink, err := openaiorgs.NewSecretSink(named)
+		if err != nil {
+			return "", err
+		}
+		return sink.Store(secret)
+	}), nil
+}
+
+func secretSinkConfigFromFlags(cmd *cli.Command) openaiorgs.SecretSinkConfig {
+	return openaiorgs.SecretSinkConfig{
+		Kind:           cmd.String("secret-sink"),
+		Path:           cmd.String("secret-path"),
+		Key:            cmd.String("secret-key"),
+		SecretName:     cmd.String("secret-name"),
+		Namespace:      cmd.String("secret-namespace"),
+		VaultAddress:   cmd.String("vault-addr"),
+		VaultToken:     cmd.String("vault-token"),
+		VaultNamespace: cmd.String("vault-namespace"),
+		VaultMount:     cmd.String("vault-mount"),
+	}
+}
+
+// storeSecret hands secret to sink and prints a redacted reference to it.
+func storeSecret(sink openaiorgs.SecretSink, secret openaiorgs.Secret) error {
+	ref, err := sink.Store(secret)
+	if err != nil {
+		return fmt.Errorf("failed to store key %s: %w", secret.ID, err)
+	}
+	fmt.Printf("API Key: %s stored in %s\n", openaiorgs.RedactSecret(secret.Value), ref)
+	return nil
+}
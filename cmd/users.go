@@ -2,8 +2,14 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 
+	openaiorgs "github.com/klauern/openai-orgs"
 	"github.com/urfave/cli/v3"
 )
 
@@ -16,6 +22,9 @@ func UsersCommand() *cli.Command {
 			retrieveUserCommand(),
 			deleteUserCommand(),
 			modifyUserRoleCommand(),
+			offboardUserCommand(),
+			onboardUsersCommand(),
+			describeUserCommand(),
 		},
 	}
 }
@@ -156,3 +165,316 @@ func modifyUserRole(ctx context.Context, cmd *cli.Command) error {
 
 	return nil
 }
+
+func offboardUserCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "offboard",
+		Usage: "Remove a user's project memberships, API keys, pending invites and organization membership",
+		Flags: []cli.Flag{
+			emailFlag,
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Show the plan without making any changes",
+			},
+			&cli.BoolFlag{
+				Name:    "yes",
+				Aliases: []string{"y"},
+				Usage:   "Skip the confirmation prompt",
+			},
+			&cli.IntFlag{
+				Name:  "concurrency",
+				Usage: "Number of projects to scan in parallel",
+				Value: openaiorgs.DefaultInventoryConcurrency,
+			},
+		},
+		Action: offboardUser,
+	}
+}
+
+func onboardUsersCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "onboard",
+		Usage: "Invite people and assign project roles from a CSV file (email, role, project_id:role pairs)",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "file",
+				Aliases:  []string{"f"},
+				Usage:    "Path to the onboarding CSV file",
+				Required: true,
+			},
+			&cli.IntFlag{
+				Name:  "concurrency",
+				Usage: "Number of rows to process in parallel",
+				Value: openaiorgs.DefaultInventoryConcurrency,
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Show the changes without making them",
+			},
+		},
+		Action: onboardUsers,
+	}
+}
+
+func describeUserCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "describe",
+		Usage: "Show a user's organization role, project memberships, API keys, recent audit events and usage",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "email",
+				Usage: "Email address of the user",
+			},
+			&cli.StringFlag{
+				Name:  "id",
+				Usage: "ID of the user",
+			},
+			&cli.IntFlag{
+				Name:  "days",
+				Usage: "Number of days of audit events and usage to include",
+				Value: 30,
+			},
+			&cli.IntFlag{
+				Name:  "events",
+				Usage: "Maximum number of recent audit events to show",
+				Value: openaiorgs.DefaultUserAuditEvents,
+			},
+			&cli.IntFlag{
+				Name:  "concurrency",
+				Usage: "Number of projects to scan in parallel",
+				Value: openaiorgs.DefaultInventoryConcurrency,
+			},
+		},
+		Action: describeUser,
+	}
+}
+
+func offboardUser(ctx context.Context, cmd *cli.Command) error {
+	email := cmd.String("email")
+	dryRun := cmd.Bool("dry-run")
+	jsonOutput := cmd.String("output") == OutputFormatJSON
+	// The plan is only printed in pretty mode, so it cannot be reviewed at a prompt.
+	if jsonOutput && !dryRun && !cmd.Bool("yes") {
+		return fmt.Errorf("--yes or --dry-run is required with --output %s", OutputFormatJSON)
+	}
+
+	client := newClient(ctx, cmd)
+	plan, err := openaiorgs.PlanOffboarding(client, email, openaiorgs.InventoryOptions{
+		Concurrency: int(cmd.Int("concurrency")),
+	})
+	if err != nil {
+		return wrapError("plan offboarding", err)
+	}
+
+	if !jsonOutput {
+		printOffboardingPlan(plan)
+	}
+
+	if !dryRun && !cmd.Bool("yes") {
+		if !confirm(fmt.Sprintf("Offboard %s?", email)) {
+			fmt.Println("Aborted.")
+			return nil
+		}
+	}
+
+	report := openaiorgs.ExecuteOffboarding(client, plan, dryRun)
+
+	if jsonOutput {
+		data, err := json.MarshalIndent(struct {
+			Plan   *openaiorgs.OffboardingPlan   `json:"plan"`
+			Report *openaiorgs.OffboardingReport `json:"report"`
+		}{plan, report}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal offboarding report: %w", err)
+		}
+		fmt.Println(string(data))
+	} else if !dryRun {
+		printOffboardingReport(report)
+	}
+
+	if report.Failed > 0 {
+		return fmt.Errorf("offboarding %s: %d step(s) failed", email, report.Failed)
+	}
+	return nil
+}
+
+func describeUser(ctx context.Context, cmd *cli.Command) error {
+	email, id := cmd.String("email"), cmd.String("id")
+	if (email == "") == (id == "") {
+		return fmt.Errorf("exactly one of --email or --id is required")
+	}
+	days := cmd.Int("days")
+	if days <= 0 {
+		return fmt.Errorf("--days must be positive")
+	}
+	identifier := id
+	if email != "" {
+		identifier = email
+	}
+
+	client := newClient(ctx, cmd)
+	description, err := openaiorgs.DescribeUser(client, identifier, openaiorgs.UserDescriptionOptions{
+		Window:      time.Duration(days) * 24 * time.Hour,
+		AuditEvents: int(cmd.Int("events")),
+		Concurrency: int(cmd.Int("concurrency")),
+	})
+	if err != nil {
+		return wrapError("describe user", err)
+	}
+
+	if cmd.String("output") == OutputFormatJSON {
+		data, err := json.MarshalIndent(description, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal user description: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+	printUserDescription(description)
+	return nil
+}
+
+func printUserDescription(description *openaiorgs.UserDescription) {
+	user := description.User
+	fmt.Printf("User: %s (%s)\n", user.Email, user.ID)
+	if user.Name != "" {
+		fmt.Printf("Name: %s\n", user.Name)
+	}
+	fmt.Printf("Organization Role: %s\nAdded At: %s\n", user.Role, user.AddedAt.String())
+
+	memberships := TableData{Headers: []string{"Project ID", "Project", "Role", "Added At"}}
+	for _, m := range description.Memberships {
+		memberships.Rows = append(memberships.Rows, []string{m.ProjectID, m.ProjectName, m.Role, m.AddedAt.String()})
+	}
+	keys := TableData{Headers: []string{"Project", "ID", "Name", "Redacted Value", "Created At"}}
+	for _, key := range description.APIKeys {
+		keys.Rows = append(keys.Rows, []string{key.ProjectName, key.KeyID, key.KeyName, key.RedactedValue, key.CreatedAt.String()})
+	}
+	events := TableData{Headers: []string{"Time", "Type", "Project", "Resource"}}
+	for _, event := range description.AuditEvents {
+		project := ""
+		if event.Project != nil {
+			project = event.Project.ID
+		}
+		events.Rows = append(events.Rows, []string{event.EffectiveAt.String(), event.Type, project, event.ResourceID()})
+	}
+	usage := TableData{Headers: []string{"Project ID", "Project", "Requests", "Input Tokens", "Output Tokens"}}
+	for _, u := range description.Usage {
+		usage.Rows = append(usage.Rows, []string{
+			u.ProjectID,
+			u.ProjectName,
+			strconv.Itoa(u.Requests),
+			strconv.Itoa(u.InputTokens),
+			strconv.Itoa(u.OutputTokens),
+		})
+	}
+
+	since := description.Since.Format("2006-01-02")
+	for _, section := range []struct {
+		title string
+		data  TableData
+	}{
+		{"Project Memberships", memberships},
+		{"API Keys", keys},
+		{"Audit Events since " + since, events},
+		{"Usage since " + since, usage},
+	} {
+		fmt.Printf("\n%s:\n", section.title)
+		if len(section.data.Rows) == 0 {
+			fmt.Println("None")
+			continue
+		}
+		printTableData(section.data)
+	}
+}
+
+func printOffboardingPlan(plan *openaiorgs.OffboardingPlan) {
+	if plan.User != nil {
+		fmt.Printf("Offboarding %s (%s, role %s)\n\n", plan.Email, plan.User.ID, plan.User.Role)
+	} else {
+		fmt.Printf("Offboarding %s (not an organization member)\n\n", plan.Email)
+	}
+
+	data := TableData{Headers: []string{"Step", "Project", "Resource", "Detail"}}
+	for _, key := range plan.APIKeys {
+		data.Rows = append(data.Rows, []string{"delete API key", key.ProjectName, key.KeyID, key.KeyName})
+	}
+	for _, m := range plan.Memberships {
+		data.Rows = append(data.Rows, []string{"remove from project", m.ProjectName, m.ProjectID, "role " + m.Role})
+	}
+	for _, invite := range plan.Invites {
+		data.Rows = append(data.Rows, []string{"delete invite", "", invite.ID, invite.Email})
+	}
+	if plan.User != nil {
+		data.Rows = append(data.Rows, []string{"delete user", "", plan.User.ID, plan.User.Email})
+	}
+	printTableData(data)
+	fmt.Println()
+}
+
+func printOffboardingReport(report *openaiorgs.OffboardingReport) {
+	data := TableData{
+		Headers: []string{"Action", "Project", "Resource", "Status", "Error"},
+		Rows:    make([][]string, len(report.Actions)),
+	}
+	for i, action := range report.Actions {
+		data.Rows[i] = []string{action.Action, action.ProjectID, action.ResourceID, action.Status, action.Error}
+	}
+	printTableData(data)
+}
+
+func onboardUsers(ctx context.Context, cmd *cli.Command) error {
+	file, err := os.Open(cmd.String("file"))
+	if err != nil {
+		return fmt.Errorf("failed to open onboarding file: %w", err)
+	}
+	defer file.Close()
+
+	rows, err := openaiorgs.ParseOnboardingCSV(file)
+	if err != nil {
+		return err
+	}
+
+	client := newClient(ctx, cmd)
+	results, err := openaiorgs.Onboard(client, rows, openaiorgs.OnboardingOptions{
+		Concurrency: int(cmd.Int("concurrency")),
+		DryRun:      cmd.Bool("dry-run"),
+	})
+	if err != nil {
+		return wrapError("onboard users", err)
+	}
+
+	if cmd.String("output") == OutputFormatJSON {
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal onboarding results: %w", err)
+		}
+		fmt.Println(string(data))
+	} else {
+		data := TableData{
+			Headers: []string{"Line", "Email", "Status", "Actions", "Errors"},
+			Rows:    make([][]string, len(results)),
+		}
+		for i, result := range results {
+			data.Rows[i] = []string{
+				strconv.Itoa(result.Line),
+				result.Email,
+				result.Status,
+				strings.Join(result.Actions, "; "),
+				strings.Join(result.Errors, "; "),
+			}
+		}
+		printTableData(data)
+	}
+
+	failed := 0
+	for _, result := range results {
+		if result.Status == openaiorgs.OnboardingStatusFailed {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("onboarding failed for %d of %d row(s)", failed, len(results))
+	}
+	return nil
+}
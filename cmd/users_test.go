@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -278,3 +280,319 @@ func TestModifyUserRoleCommand(t *testing.T) {
 		})
 	}
 }
+
+func mockOffboardingResponses(h *cmdTestHelper) {
+	h.mockResponse("GET", "/organization/users", 200, openaiorgs.ListResponse[openaiorgs.User]{
+		Object: "list",
+		Data:   []openaiorgs.User{createMockUser("user_gone", "gone@example.com", "Gone", "reader")},
+	})
+	h.mockResponse("GET", "/organization/invites", 200, openaiorgs.ListResponse[openaiorgs.Invite]{
+		Object: "list",
+		Data:   []openaiorgs.Invite{{ID: "invite_1", Email: "gone@example.com", Status: "pending"}},
+	})
+	h.mockResponse("GET", "/organization/projects", 200, openaiorgs.ListResponse[openaiorgs.Project]{
+		Object: "list",
+		Data:   []openaiorgs.Project{{ID: "proj_1", Name: "Alpha"}},
+	})
+	h.mockResponse("GET", "/organization/projects/proj_1/users", 200, openaiorgs.ListResponse[openaiorgs.ProjectUser]{
+		Object: "list",
+		Data:   []openaiorgs.ProjectUser{{ID: "user_gone", Role: "owner"}},
+	})
+	h.mockResponse("GET", "/organization/projects/proj_1/service_accounts", 200, openaiorgs.ListResponse[openaiorgs.ProjectServiceAccount]{Object: "list"})
+	h.mockResponse("GET", "/organization/projects/proj_1/api_keys", 200, openaiorgs.ListResponse[openaiorgs.ProjectApiKey]{
+		Object: "list",
+		Data: []openaiorgs.ProjectApiKey{{
+			ID:    "key_1",
+			Name:  "laptop",
+			Owner: openaiorgs.Owner{Type: openaiorgs.OwnerTypeUser, User: &openaiorgs.User{ID: "user_gone"}},
+		}},
+	})
+	h.mockResponse("DELETE", "/organization/projects/proj_1/api_keys/key_1", 200, map[string]any{"deleted": true})
+	h.mockResponse("DELETE", "/organization/projects/proj_1/users/user_gone", 200, map[string]any{"deleted": true})
+	h.mockResponse("DELETE", "/organization/invites/invite_1", 200, map[string]any{"deleted": true})
+	h.mockResponse("DELETE", "/organization/users/user_gone", 200, map[string]any{"deleted": true})
+}
+
+func TestOffboardUserCommand(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         []string
+		input        string
+		wantDeletes  int
+		wantContains []string
+	}{
+		{
+			name:        "dry run",
+			args:        []string{"users", "offboard", "--email", "gone@example.com", "--dry-run"},
+			wantDeletes: 0,
+			wantContains: []string{
+				"Offboarding gone@example.com (user_gone, role reader)",
+				"delete API key | Alpha | key_1 | laptop",
+				"remove from project | Alpha | proj_1 | role owner",
+				"delete invite |  | invite_1 | gone@example.com",
+				"delete user |  | user_gone | gone@example.com",
+			},
+		},
+		{
+			name:         "confirmed",
+			args:         []string{"users", "offboard", "--email", "gone@example.com"},
+			input:        "y\n",
+			wantDeletes:  1,
+			wantContains: []string{"delete_user |  | user_gone | done"},
+		},
+		{
+			name:         "declined",
+			args:         []string{"users", "offboard", "--email", "gone@example.com"},
+			input:        "n\n",
+			wantDeletes:  0,
+			wantContains: []string{"Aborted."},
+		},
+		{
+			name:         "yes flag with json report",
+			args:         []string{"--output", "json", "users", "offboard", "--email", "gone@example.com", "--yes"},
+			wantDeletes:  1,
+			wantContains: []string{`"report": {`, `"action": "delete_api_key"`, `"status": "done"`, `"failed": 0`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newCmdTestHelper(t)
+			defer h.cleanup()
+			defer setConfirmInput(tt.input)()
+			mockOffboardingResponses(h)
+
+			var err error
+			output := captureOutput(func() {
+				err = h.runCmd(UsersCommand(), tt.args)
+			})
+			if err != nil {
+				t.Fatalf("runCmd() error = %v", err)
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(output, want) {
+					t.Errorf("expected output to contain %q, got: %s", want, output)
+				}
+			}
+			h.assertRequest("DELETE", "/organization/users/user_gone", tt.wantDeletes)
+			h.assertRequest("DELETE", "/organization/projects/proj_1/api_keys/key_1", tt.wantDeletes)
+		})
+	}
+}
+
+func TestOffboardUserCommand_JSONRequiresYes(t *testing.T) {
+	h := newCmdTestHelper(t)
+	defer h.cleanup()
+	defer setConfirmInput("y\n")()
+	mockOffboardingResponses(h)
+
+	var err error
+	output := captureOutput(func() {
+		err = h.runCmd(UsersCommand(), []string{"--output", "json", "users", "offboard", "--email", "gone@example.com"})
+	})
+	if err == nil || !strings.Contains(err.Error(), "--yes or --dry-run is required") {
+		t.Fatalf("expected --yes error, got %v", err)
+	}
+	if output != "" {
+		t.Errorf("expected no output, got: %s", output)
+	}
+	h.assertRequest("GET", "/organization/users", 0)
+}
+
+func TestOffboardUserCommand_PartialFailure(t *testing.T) {
+	h := newCmdTestHelper(t)
+	defer h.cleanup()
+	mockOffboardingResponses(h)
+	h.mockResponse("DELETE", "/organization/invites/invite_1", 500, map[string]string{"error": "boom"})
+
+	var err error
+	output := captureOutput(func() {
+		err = h.runCmd(UsersCommand(), []string{"users", "offboard", "--email", "gone@example.com", "--yes"})
+	})
+	if err == nil || !strings.Contains(err.Error(), "2 step(s) failed") {
+		t.Fatalf("expected partial failure error, got %v", err)
+	}
+	if !strings.Contains(output, "delete_invite |  | invite_1 | failed") {
+		t.Errorf("expected failed invite step in output, got: %s", output)
+	}
+	h.assertRequest("DELETE", "/organization/users/user_gone", 0)
+}
+
+func writeOnboardingFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "people.csv")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write onboarding file: %v", err)
+	}
+	return path
+}
+
+func mockOnboardingResponses(h *cmdTestHelper) {
+	h.mockResponse("GET", "/organization/users", 200, openaiorgs.ListResponse[openaiorgs.User]{
+		Object: "list",
+		Data:   []openaiorgs.User{createMockUser("user_1", "existing@example.com", "Existing", "reader")},
+	})
+	h.mockResponse("GET", "/organization/invites", 200, openaiorgs.ListResponse[openaiorgs.Invite]{Object: "list"})
+	h.mockResponse("GET", "/organization/projects/proj_a/users", 200, openaiorgs.ListResponse[openaiorgs.ProjectUser]{
+		Object: "list",
+		Data:   []openaiorgs.ProjectUser{{ID: "user_1", Role: "member"}},
+	})
+	h.mockResponse("POST", "/organization/invites", 200, openaiorgs.Invite{ID: "invite_1", Email: "new@example.com"})
+	h.mockResponse("POST", "/organization/projects/proj_a/users/user_1", 200, openaiorgs.ProjectUser{ID: "user_1", Role: "owner"})
+}
+
+func TestOnboardUsersCommand(t *testing.T) {
+	csvContent := "email,role,projects\nnew@example.com,reader,\nexisting@example.com,reader,proj_a:owner\n"
+
+	tests := []struct {
+		name         string
+		args         []string
+		wantPosts    int
+		wantContains []string
+	}{
+		{
+			name:      "apply",
+			args:      []string{"users", "onboard"},
+			wantPosts: 1,
+			wantContains: []string{
+				"Line | Email | Status | Actions | Errors",
+				"2 | new@example.com | created | invite as reader |",
+				"3 | existing@example.com | updated | change role in proj_a from member to owner |",
+			},
+		},
+		{
+			name:         "dry run",
+			args:         []string{"users", "onboard", "--dry-run"},
+			wantPosts:    0,
+			wantContains: []string{"2 | new@example.com | planned | would invite as reader |"},
+		},
+		{
+			name:         "json",
+			args:         []string{"--output", "json", "users", "onboard"},
+			wantPosts:    1,
+			wantContains: []string{`"status": "created"`, `"line": 3`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newCmdTestHelper(t)
+			defer h.cleanup()
+			mockOnboardingResponses(h)
+
+			args := append(tt.args, "--file", writeOnboardingFile(t, csvContent))
+			var err error
+			output := captureOutput(func() {
+				err = h.runCmd(UsersCommand(), args)
+			})
+			if err != nil {
+				t.Fatalf("runCmd() error = %v", err)
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(output, want) {
+					t.Errorf("expected output to contain %q, got: %s", want, output)
+				}
+			}
+			h.assertRequest("POST", "/organization/invites", tt.wantPosts)
+		})
+	}
+}
+
+func TestOnboardUsersCommand_Errors(t *testing.T) {
+	t.Run("invalid csv", func(t *testing.T) {
+		h := newCmdTestHelper(t)
+		defer h.cleanup()
+
+		path := writeOnboardingFile(t, "new@example.com\n")
+		err := h.runCmd(UsersCommand(), []string{"users", "onboard", "-f", path})
+		if err == nil || !strings.Contains(err.Error(), "line 1") {
+			t.Errorf("expected CSV error, got %v", err)
+		}
+	})
+
+	t.Run("row failure", func(t *testing.T) {
+		h := newCmdTestHelper(t)
+		defer h.cleanup()
+		mockOnboardingResponses(h)
+		h.mockResponse("POST", "/organization/invites", 400, map[string]string{"error": "bad role"})
+
+		path := writeOnboardingFile(t, "new@example.com,reader\nexisting@example.com,reader,proj_a:owner\n")
+		var err error
+		output := captureOutput(func() {
+			err = h.runCmd(UsersCommand(), []string{"users", "onboard", "-f", path})
+		})
+		if err == nil || !strings.Contains(err.Error(), "onboarding failed for 1 of 2 row(s)") {
+			t.Fatalf("expected row failure error, got %v", err)
+		}
+		if !strings.Contains(output, "existing@example.com | updated") {
+			t.Errorf("expected remaining rows to be processed, got: %s", output)
+		}
+	})
+}
+
+func TestDescribeUserCommand(t *testing.T) {
+	setup := func(h *cmdTestHelper) {
+		mockOffboardingResponses(h)
+		h.mockResponse("GET", "/organization/users/user_gone", 200, createMockUser("user_gone", "gone@example.com", "Gone", "reader"))
+		h.mockResponse("GET", "/organization/audit_logs", 200, openaiorgs.ListResponse[openaiorgs.AuditLog]{Object: "list", Data: []openaiorgs.AuditLog{
+			{ID: "log_1", Type: "api_key.created", Project: &openaiorgs.AuditProject{ID: "proj_1"}},
+		}})
+		h.mockResponse("GET", "/organization/usage/completions", 200, openaiorgs.CompletionsUsageResponse{Data: []openaiorgs.CompletionsUsageBucket{
+			{Results: []openaiorgs.CompletionsUsageResult{{ProjectID: "proj_1", NumModelRequests: 4, InputTokens: 200, OutputTokens: 50}}},
+		}})
+		h.mockResponse("GET", "/organization/usage/embeddings", 200, openaiorgs.EmbeddingsUsageResponse{})
+	}
+
+	tests := []struct {
+		name         string
+		args         []string
+		wantContains []string
+	}{
+		{
+			name: "by email",
+			args: []string{"users", "describe", "--email", "gone@example.com"},
+			wantContains: []string{
+				"User: gone@example.com (user_gone)",
+				"Organization Role: reader",
+				"proj_1 | Alpha | owner |",
+				"Alpha | key_1 | laptop |",
+				"| api_key.created | proj_1 |",
+				"proj_1 | Alpha | 4 | 200 | 50",
+			},
+		},
+		{
+			name:         "by id as json",
+			args:         []string{"--output", "json", "users", "describe", "--id", "user_gone"},
+			wantContains: []string{`"email": "gone@example.com"`, `"key_id": "key_1"`, `"requests": 4`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newCmdTestHelper(t)
+			defer h.cleanup()
+			setup(h)
+
+			var err error
+			output := captureOutput(func() {
+				err = h.runCmd(UsersCommand(), tt.args)
+			})
+			if err != nil {
+				t.Fatalf("runCmd() error = %v", err)
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(output, want) {
+					t.Errorf("Expected output to contain %q, got: %s", want, output)
+				}
+			}
+		})
+	}
+
+	t.Run("requires one identifier", func(t *testing.T) {
+		h := newCmdTestHelper(t)
+		defer h.cleanup()
+		err := h.runCmd(UsersCommand(), []string{"users", "describe", "--email", "a@example.com", "--id", "user_1"})
+		if err == nil || !strings.Contains(err.Error(), "exactly one of --email or --id") {
+			t.Errorf("expected identifier error, got %v", err)
+		}
+	})
+}
@@ -1,10 +1,15 @@
 package cmd
 
 import (
+	"bufio"
 	"context"
 	"fmt"
+	"io"
+	"os"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	openaiorgs "github.com/klauern/openai-orgs"
 	"github.com/urfave/cli/v3"
@@ -148,6 +153,46 @@ func printTableData(data TableData) {
 	}
 }
 
+// parseDuration extends time.ParseDuration with a "d" (day) unit, e.g. "14d" or "1d12h".
+func parseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	days := 0
+	if i := strings.Index(s, "d"); i >= 0 {
+		n, err := strconv.Atoi(s[:i])
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		days = n
+		s = s[i+1:]
+	}
+
+	var rest time.Duration
+	if s != "" {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: %w", s, err)
+		}
+		rest = d
+	}
+	return time.Duration(days)*24*time.Hour + rest, nil
+}
+
+// confirmInput is where confirmation prompts read answers from. Tests replace it.
+var confirmInput io.Reader = os.Stdin
+
+// confirmOutput is where confirmation prompts are written. It is stderr so that a
+// prompt never ends up in JSON or CSV written to stdout. Tests replace it.
+var confirmOutput io.Writer = os.Stderr
+
+// confirm prints prompt and reports whether the user answered yes.
+// Anything other than "y" or "yes" (case-insensitive), including EOF, is a no.
+func confirm(prompt string) bool {
+	fmt.Fprintf(confirmOutput, "%s [y/N]: ", prompt)
+	answer, _ := bufio.NewReader(confirmInput).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
 func wrapError(operation string, err error) error {
 	if err == nil {
 		return nil
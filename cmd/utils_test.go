@@ -1,10 +1,12 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/urfave/cli/v3"
 )
@@ -271,3 +273,69 @@ func TestBaseCommand(t *testing.T) {
 		}
 	})
 }
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"y\n", true},
+		{"YES\n", true},
+		{" yes \n", true},
+		{"n\n", false},
+		{"\n", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%q", tt.input), func(t *testing.T) {
+			defer setConfirmInput(tt.input)()
+			var prompt bytes.Buffer
+			oldOutput := confirmOutput
+			confirmOutput = &prompt
+			defer func() { confirmOutput = oldOutput }()
+
+			var got bool
+			output := captureOutput(func() {
+				got = confirm("Proceed?")
+			})
+			if got != tt.want {
+				t.Errorf("confirm() = %v, want %v", got, tt.want)
+			}
+			if prompt.String() != "Proceed? [y/N]: " {
+				t.Errorf("expected prompt on confirmOutput, got %q", prompt.String())
+			}
+			if output != "" {
+				t.Errorf("expected nothing on stdout, got %q", output)
+			}
+		})
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{input: "48h", want: 48 * time.Hour},
+		{input: "14d", want: 14 * 24 * time.Hour},
+		{input: "1d12h", want: 36 * time.Hour},
+		{input: "30m", want: 30 * time.Minute},
+		{input: "d", wantErr: true},
+		{input: "-2d", wantErr: true},
+		{input: "soon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseDuration(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseDuration(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("parseDuration(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
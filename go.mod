@@ -8,6 +8,7 @@ require (
 	github.com/mark3labs/mcp-go v0.56.0
 	github.com/urfave/cli/v3 v3.10.1
 	go.uber.org/mock v0.6.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -0,0 +1,265 @@
+package openaiorgs
+
+import (
+	"fmt"
+	"maps"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// DefaultIdleProjectWindow is how long a project must go without requests, spend or
+// audit activity to be reported as idle, when IdleProjectOptions.Window is not set.
+const DefaultIdleProjectWindow = 60 * 24 * time.Hour
+
+// IdleProjectOptions configures FindIdleProjects.
+type IdleProjectOptions struct {
+	// Window is the period without activity. Defaults to DefaultIdleProjectWindow.
+	Window time.Duration
+	// Concurrency is the number of idle projects whose members and keys are fetched in parallel.
+	Concurrency int
+	// Now overrides the current time, mainly for tests. Defaults to time.Now.
+	Now time.Time
+}
+
+// IdleProject is an active project with no activity in the window, together with
+// everything that still grants access to it.
+type IdleProject struct {
+	ProjectInventory
+	// LastAuditEvent is the time of the project's most recent audit event, if any.
+	LastAuditEvent *time.Time `json:"last_audit_event,omitempty"`
+	// Archived and ArchiveError are set by ArchiveIdleProjects.
+	Archived     bool   `json:"archived,omitempty"`
+	ArchiveError string `json:"archive_error,omitempty"`
+}
+
+// Owners returns the emails of the project's owners.
+func (p IdleProject) Owners() []string {
+	var owners []string
+	for _, user := range p.Users {
+		if user.Role == string(RoleTypeOwner) {
+			owners = append(owners, user.Email)
+		}
+	}
+	return owners
+}
+
+// IdleProjectReport lists the idle projects found by FindIdleProjects.
+type IdleProjectReport struct {
+	GeneratedAt time.Time `json:"generated_at"`
+	Since       time.Time `json:"since"`
+	// Checked is the number of active projects that were old enough to be considered.
+	Checked  int           `json:"checked"`
+	Projects []IdleProject `json:"projects"`
+}
+
+// FindIdleProjects returns the active projects created before the window that had no
+// completions, embeddings or image requests, no costs and no audit log events within
+// it. Usage and costs are fetched once for the whole organization, grouped by project.
+// Idle projects are returned sorted by name with their users, service accounts and API
+// keys, so that owners can be notified before the projects are archived.
+func FindIdleProjects(c OpenAIOrgsClient, opts IdleProjectOptions) (*IdleProjectReport, error) {
+	now := lifecycleNow(opts.Now)
+	window := opts.Window
+	if window <= 0 {
+		window = DefaultIdleProjectWindow
+	}
+	since := now.Add(-window)
+
+	projects, err := ListAll(func(after string) (*ListResponse[Project], error) {
+		return c.ListProjects(100, after, false)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+
+	active, err := activeProjectIDs(c, since, now)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &IdleProjectReport{GeneratedAt: now, Since: since}
+	var candidates []Project
+	for _, project := range projects {
+		if project.Status == "archived" || !project.CreatedAt.Time().Before(since) {
+			continue
+		}
+		report.Checked++
+		if !active[project.ID] {
+			candidates = append(candidates, project)
+		}
+	}
+	if len(candidates) == 0 {
+		return report, nil
+	}
+
+	// Any audit event in the window, such as a membership or key change, counts as activity.
+	ids := make([]string, len(candidates))
+	for i, project := range candidates {
+		ids[i] = project.ID
+	}
+	logs, err := ListAllAuditLogs(c, AuditLogListParams{
+		ProjectIDs:  ids,
+		EffectiveAt: &EffectiveAt{Gte: since.Unix()},
+		Limit:       100,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit logs: %w", err)
+	}
+	for _, log := range logs {
+		if log.Project != nil {
+			active[log.Project.ID] = true
+		}
+	}
+
+	var idle []Project
+	for _, project := range candidates {
+		if !active[project.ID] {
+			idle = append(idle, project)
+		}
+	}
+	sort.SliceStable(idle, func(i, j int) bool { return idle[i].Name < idle[j].Name })
+
+	report.Projects = make([]IdleProject, len(idle))
+	err = forEachConcurrently(len(idle), opts.Concurrency, func(i int) error {
+		inventory, err := collectProject(c, idle[i])
+		if err != nil {
+			return err
+		}
+		report.Projects[i] = IdleProject{ProjectInventory: *inventory}
+
+		// Audit logs are returned newest first, so the first one is the latest.
+		logs, err := c.ListAuditLogs(&AuditLogListParams{ProjectIDs: []string{idle[i].ID}, Limit: 1})
+		if err != nil {
+			return fmt.Errorf("failed to list audit logs of project %s: %w", idle[i].ID, err)
+		}
+		if len(logs.Data) > 0 {
+			at := logs.Data[0].EffectiveAt.Time()
+			report.Projects[i].LastAuditEvent = &at
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// ArchiveIdleProjects archives every project in the report, continuing past failures,
+// and records the outcome in each project. It returns the number archived.
+func ArchiveIdleProjects(c OpenAIOrgsClient, report *IdleProjectReport) int {
+	archived := 0
+	for i := range report.Projects {
+		project := &report.Projects[i]
+		if _, err := c.ArchiveProject(project.Project.ID); err != nil {
+			project.ArchiveError = err.Error()
+			continue
+		}
+		project.Archived = true
+		archived++
+	}
+	return archived
+}
+
+// activeProjectIDs returns the projects with completions, embeddings or image requests,
+// or with costs, between start and end.
+func activeProjectIDs(c OpenAIOrgsClient, start, end time.Time) (map[string]bool, error) {
+	base := map[string]string{
+		"start_time":   strconv.FormatInt(start.Unix(), 10),
+		"end_time":     strconv.FormatInt(end.Unix(), 10),
+		"bucket_width": "1d",
+		"group_by":     "project_id",
+		"limit":        "31",
+	}
+	active := make(map[string]bool)
+
+	err := forEachUsagePage(base, func(params map[string]string) (bool, string, error) {
+		resp, err := c.GetCompletionsUsage(params)
+		if err != nil {
+			return false, "", fmt.Errorf("failed to get completions usage: %w", err)
+		}
+		for _, bucket := range resp.Data {
+			for _, result := range bucket.Results {
+				if result.NumModelRequests > 0 {
+					active[result.ProjectID] = true
+				}
+			}
+		}
+		return resp.HasMore, resp.NextPage, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = forEachUsagePage(base, func(params map[string]string) (bool, string, error) {
+		resp, err := c.GetEmbeddingsUsage(params)
+		if err != nil {
+			return false, "", fmt.Errorf("failed to get embeddings usage: %w", err)
+		}
+		for _, bucket := range resp.Data {
+			for _, result := range bucket.Results {
+				if result.NumModelRequests > 0 {
+					active[result.ProjectID] = true
+				}
+			}
+		}
+		return resp.HasMore, resp.NextPage, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = forEachUsagePage(base, func(params map[string]string) (bool, string, error) {
+		resp, err := c.GetImagesUsage(params)
+		if err != nil {
+			return false, "", fmt.Errorf("failed to get images usage: %w", err)
+		}
+		for _, bucket := range resp.Data {
+			for _, result := range bucket.Results {
+				if result.NumModelRequests > 0 || result.Images > 0 {
+					active[result.ProjectID] = true
+				}
+			}
+		}
+		return resp.HasMore, resp.NextPage, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = forEachUsagePage(base, func(params map[string]string) (bool, string, error) {
+		resp, err := c.GetCostsUsage(params)
+		if err != nil {
+			return false, "", fmt.Errorf("failed to get costs: %w", err)
+		}
+		for _, bucket := range resp.Data {
+			for _, result := range bucket.Results {
+				if result.Amount.Value > 0 {
+					active[result.ProjectID] = true
+				}
+			}
+		}
+		return resp.HasMore, resp.NextPage, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return active, nil
+}
+
+// forEachUsagePage calls fetch with base and then with the page cursor it returns,
+// until there are no more pages. The usage endpoints page with next_page rather than
+// the after cursor that ListAll follows.
+func forEachUsagePage(base map[string]string, fetch func(params map[string]string) (hasMore bool, nextPage string, err error)) error {
+	params := maps.Clone(base)
+	for {
+		hasMore, next, err := fetch(params)
+		if err != nil {
+			return err
+		}
+		if !hasMore || next == "" || next == params["page"] {
+			return nil
+		}
+		params["page"] = next
+	}
+}
@@ -0,0 +1,98 @@
+package openaiorgs
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+// mockIdleProjects sets up five projects: proj_idle has no activity, proj_used has
+// requests, proj_paid has costs, proj_audit has an audit event in the window and
+// proj_new was created inside the window.
+func mockIdleProjects(h *testHelper, now time.Time) {
+	old := UnixSeconds(now.AddDate(0, -6, 0))
+	h.mockResponse("GET", ProjectsListEndpoint, 200, ListResponse[Project]{Object: "list", Data: []Project{
+		{ID: "proj_used", Name: "Used", CreatedAt: old, Status: "active"},
+		{ID: "proj_idle", Name: "Idle", CreatedAt: old, Status: "active"},
+		{ID: "proj_paid", Name: "Paid", CreatedAt: old, Status: "active"},
+		{ID: "proj_audit", Name: "Audit", CreatedAt: old, Status: "active"},
+		{ID: "proj_new", Name: "New", CreatedAt: UnixSeconds(now.AddDate(0, 0, -5)), Status: "active"},
+	}})
+	h.mockResponse("GET", usageCompletionsEndpoint, 200, CompletionsUsageResponse{Data: []CompletionsUsageBucket{
+		{Results: []CompletionsUsageResult{{ProjectID: "proj_used", NumModelRequests: 3}, {ProjectID: "proj_idle"}}},
+	}})
+	h.mockResponse("GET", usageEmbeddingsEndpoint, 200, EmbeddingsUsageResponse{})
+	h.mockResponse("GET", usageImagesEndpoint, 200, ImagesUsageResponse{})
+	h.mockResponse("GET", usageCostsEndpoint, 200, CostsUsageResponse{Data: []CostsUsageBucket{
+		{Results: []CostsUsageResult{{ProjectID: "proj_paid", Amount: CostAmount{Value: 1.5, Currency: "usd"}}}},
+	}})
+
+	lastEvent := UnixSeconds(now.AddDate(0, -3, 0))
+	httpmock.RegisterResponder("GET", testBaseURL+AuditLogsListEndpoint, func(req *http.Request) (*http.Response, error) {
+		query := req.URL.Query()
+		var logs []AuditLog
+		switch {
+		case query.Get("effective_at[gte]") != "":
+			// Activity in the window.
+			logs = []AuditLog{{ID: "log_1", Type: "api_key.created", Project: &AuditProject{ID: "proj_audit"}}}
+		case query.Get("project_ids") == "proj_idle":
+			logs = []AuditLog{{ID: "log_0", Type: "project.created", EffectiveAt: lastEvent, Project: &AuditProject{ID: "proj_idle"}}}
+		}
+		return httpmock.NewJsonResponse(200, ListResponse[AuditLog]{Object: "list", Data: logs})
+	})
+
+	mockProjectInventory(h, "proj_idle",
+		[]ProjectUser{{ID: "user_1", Email: "owner@example.com", Role: "owner"}, {ID: "user_2", Email: "dev@example.com", Role: "member"}},
+		[]ProjectServiceAccount{{ID: "svc_1", Name: "bot"}},
+		[]ProjectApiKey{{ID: "key_1", Name: "old-key"}})
+	h.mockResponse("POST", ProjectsListEndpoint+"/proj_idle/archive", 200, Project{ID: "proj_idle", Status: "archived"})
+}
+
+func TestFindIdleProjects(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	mockIdleProjects(h, now)
+
+	report, err := FindIdleProjects(h.client, IdleProjectOptions{Window: 60 * 24 * time.Hour, Now: now})
+	if err != nil {
+		t.Fatalf("FindIdleProjects() error = %v", err)
+	}
+	if report.Checked != 4 || !report.Since.Equal(now.AddDate(0, 0, -60)) {
+		t.Errorf("unexpected report: %+v", report)
+	}
+	if len(report.Projects) != 1 || report.Projects[0].Project.ID != "proj_idle" {
+		t.Fatalf("expected only proj_idle, got %+v", report.Projects)
+	}
+	idle := report.Projects[0]
+	if len(idle.Users) != 2 || len(idle.ServiceAccounts) != 1 || len(idle.APIKeys) != 1 {
+		t.Errorf("expected members and keys, got %+v", idle.ProjectInventory)
+	}
+	if got := strings.Join(idle.Owners(), ","); got != "owner@example.com" {
+		t.Errorf("Owners() = %s", got)
+	}
+	if idle.LastAuditEvent == nil || !idle.LastAuditEvent.Equal(now.AddDate(0, -3, 0)) {
+		t.Errorf("LastAuditEvent = %v", idle.LastAuditEvent)
+	}
+
+	if archived := ArchiveIdleProjects(h.client, report); archived != 1 || !report.Projects[0].Archived {
+		t.Errorf("ArchiveIdleProjects() = %d, %+v", archived, report.Projects[0])
+	}
+	h.assertRequest("POST", ProjectsListEndpoint+"/proj_idle/archive", 1)
+}
+
+func TestFindIdleProjects_UsageError(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+
+	h.mockResponse("GET", ProjectsListEndpoint, 200, ListResponse[Project]{Object: "list"})
+	h.mockResponse("GET", usageCompletionsEndpoint, 403, map[string]string{"error": "forbidden"})
+
+	if _, err := FindIdleProjects(h.client, IdleProjectOptions{}); err == nil || !strings.Contains(err.Error(), "completions usage") {
+		t.Errorf("expected usage error, got %v", err)
+	}
+}
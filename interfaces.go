@@ -77,3 +77,17 @@ type OpenAIOrgsClient interface {
 	ActivateProjectCertificates(projectID string, certificateIDs []string) (*CertificateActivationResponse, error)
 	DeactivateProjectCertificates(projectID string, certificateIDs []string) (*CertificateActivationResponse, error)
 }
+
+// ProjectInviteCreator is implemented by clients that can attach project assignments
+// to a new invite. It is separate from OpenAIOrgsClient so that existing
+// implementations keep compiling; use InviteWithProjects to call it.
+type ProjectInviteCreator interface {
+	CreateInviteWithProjects(email string, role string, projects []ProjectAssignment) (*Invite, error)
+}
+
+// CertificateOptionsUploader is implemented by clients that can upload a certificate
+// with control over the local validation, such as allowing leaf certificates.
+// RotateCertificate uses it when CertificateRotationOptions.AllowLeaf is set.
+type CertificateOptionsUploader interface {
+	UploadCertificateWithOptions(content string, name string, opts CertificateValidationOptions) (*Certificate, error)
+}
@@ -0,0 +1,125 @@
+package openaiorgs
+
+import (
+	"fmt"
+	"sync"
+)
+
+// DefaultInventoryConcurrency is the number of projects fetched in parallel
+// when InventoryOptions.Concurrency is not set.
+const DefaultInventoryConcurrency = 4
+
+// ProjectInventory holds everything that grants access to a single project.
+type ProjectInventory struct {
+	Project         Project                 `json:"project"`
+	Users           []ProjectUser           `json:"users"`
+	ServiceAccounts []ProjectServiceAccount `json:"service_accounts"`
+	APIKeys         []ProjectApiKey         `json:"api_keys"`
+}
+
+// InventoryOptions controls how CollectProjectInventory fans out across projects.
+type InventoryOptions struct {
+	// IncludeArchived also collects archived projects.
+	IncludeArchived bool
+	// Concurrency is the maximum number of projects fetched at once.
+	// Defaults to DefaultInventoryConcurrency.
+	Concurrency int
+}
+
+// CollectProjectInventory lists every project in the organization and fetches its
+// users, service accounts and API keys. Projects are fetched concurrently and the
+// result keeps the order returned by ListProjects. The first error aborts the collection.
+func CollectProjectInventory(c OpenAIOrgsClient, opts InventoryOptions) ([]ProjectInventory, error) {
+	projects, err := ListAll(func(after string) (*ListResponse[Project], error) {
+		return c.ListProjects(100, after, opts.IncludeArchived)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+
+	inventory := make([]ProjectInventory, len(projects))
+	err = forEachConcurrently(len(projects), opts.Concurrency, func(i int) error {
+		item, err := collectProject(c, projects[i])
+		if err != nil {
+			return err
+		}
+		inventory[i] = *item
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return inventory, nil
+}
+
+func collectProject(c OpenAIOrgsClient, project Project) (*ProjectInventory, error) {
+	users, err := ListAll(func(after string) (*ListResponse[ProjectUser], error) {
+		return c.ListProjectUsers(project.ID, 100, after)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users of project %s: %w", project.ID, err)
+	}
+
+	serviceAccounts, err := ListAll(func(after string) (*ListResponse[ProjectServiceAccount], error) {
+		return c.ListProjectServiceAccounts(project.ID, 100, after)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service accounts of project %s: %w", project.ID, err)
+	}
+
+	keys, err := ListAll(func(after string) (*ListResponse[ProjectApiKey], error) {
+		return c.ListProjectApiKeys(project.ID, 100, after)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API keys of project %s: %w", project.ID, err)
+	}
+
+	return &ProjectInventory{
+		Project:         project,
+		Users:           users,
+		ServiceAccounts: serviceAccounts,
+		APIKeys:         keys,
+	}, nil
+}
+
+// forEachConcurrently calls fn for every index in [0, n) with at most limit calls
+// in flight, and returns the first error encountered. Once an error occurs no new
+// calls are started.
+func forEachConcurrently(n, limit int, fn func(i int) error) error {
+	if limit <= 0 {
+		limit = DefaultInventoryConcurrency
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	sem := make(chan struct{}, limit)
+
+	for i := 0; i < n; i++ {
+		mu.Lock()
+		failed := firstErr != nil
+		mu.Unlock()
+		if failed {
+			break
+		}
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := fn(i); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	return firstErr
+}
@@ -0,0 +1,94 @@
+package openaiorgs
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+func mockProjectInventory(h *testHelper, projectID string, users []ProjectUser, sas []ProjectServiceAccount, keys []ProjectApiKey) {
+	h.mockResponse("GET", "/organization/projects/"+projectID+"/users", 200, ListResponse[ProjectUser]{Object: "list", Data: users})
+	h.mockResponse("GET", "/organization/projects/"+projectID+"/service_accounts", 200, ListResponse[ProjectServiceAccount]{Object: "list", Data: sas})
+	h.mockResponse("GET", "/organization/projects/"+projectID+"/api_keys", 200, ListResponse[ProjectApiKey]{Object: "list", Data: keys})
+}
+
+func TestCollectProjectInventory(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+
+	h.mockResponse("GET", ProjectsListEndpoint, 200, ListResponse[Project]{
+		Object: "list",
+		Data:   []Project{{ID: "proj_1", Name: "One"}, {ID: "proj_2", Name: "Two"}},
+	})
+	mockProjectInventory(h, "proj_1",
+		[]ProjectUser{{ID: "user_1", Role: "owner"}},
+		[]ProjectServiceAccount{{ID: "svc_1", Name: "bot"}},
+		[]ProjectApiKey{{ID: "key_1"}, {ID: "key_2"}})
+	mockProjectInventory(h, "proj_2", nil, nil, nil)
+
+	inventory, err := CollectProjectInventory(h.client, InventoryOptions{Concurrency: 2})
+	if err != nil {
+		t.Fatalf("CollectProjectInventory() error = %v", err)
+	}
+	if len(inventory) != 2 {
+		t.Fatalf("expected 2 projects, got %d", len(inventory))
+	}
+	if inventory[0].Project.ID != "proj_1" || len(inventory[0].Users) != 1 || len(inventory[0].ServiceAccounts) != 1 || len(inventory[0].APIKeys) != 2 {
+		t.Errorf("unexpected inventory for proj_1: %+v", inventory[0])
+	}
+	if inventory[1].Project.ID != "proj_2" || len(inventory[1].APIKeys) != 0 {
+		t.Errorf("unexpected inventory for proj_2: %+v", inventory[1])
+	}
+}
+
+func TestCollectProjectInventory_Error(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+
+	h.mockResponse("GET", ProjectsListEndpoint, 200, ListResponse[Project]{
+		Object: "list",
+		Data:   []Project{{ID: "proj_1"}},
+	})
+	h.mockResponse("GET", "/organization/projects/proj_1/users", 500, map[string]string{"error": "boom"})
+
+	_, err := CollectProjectInventory(h.client, InventoryOptions{})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestForEachConcurrently(t *testing.T) {
+	var inFlight, maxInFlight, calls int32
+	err := forEachConcurrently(20, 3, func(i int) error {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		atomic.AddInt32(&calls, 1)
+		atomic.AddInt32(&inFlight, -1)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 20 {
+		t.Errorf("expected 20 calls, got %d", calls)
+	}
+	if maxInFlight > 3 {
+		t.Errorf("expected at most 3 calls in flight, got %d", maxInFlight)
+	}
+
+	want := errors.New("boom")
+	err = forEachConcurrently(5, 1, func(i int) error {
+		if i == 1 {
+			return want
+		}
+		return nil
+	})
+	if !errors.Is(err, want) {
+		t.Errorf("expected boom, got %v", err)
+	}
+}
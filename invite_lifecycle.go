@@ -0,0 +1,178 @@
+package openaiorgs
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Invite lifecycle action kinds.
+const (
+	InviteActionDelete = "delete"
+	InviteActionResend = "resend"
+)
+
+// Invite lifecycle action statuses.
+const (
+	InviteActionPlanned = "planned"
+	InviteActionDone    = "done"
+	InviteActionFailed  = "failed"
+)
+
+// InviteAction records what PruneInvites or ResendExpiredInvites did (or would do) to one invite.
+type InviteAction struct {
+	InviteID    string    `json:"invite_id"`
+	Email       string    `json:"email"`
+	Role        string    `json:"role"`
+	CreatedAt   time.Time `json:"created_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	Action      string    `json:"action"`
+	Reason      string    `json:"reason"`
+	Status      string    `json:"status"`
+	NewInviteID string    `json:"new_invite_id,omitempty"`
+	Error       string    `json:"error,omitempty"`
+}
+
+// InviteLifecycleOptions controls PruneInvites and ResendExpiredInvites.
+type InviteLifecycleOptions struct {
+	// OlderThan additionally selects pending invites created more than this long ago.
+	// Zero selects only expired invites. Only used by PruneInvites.
+	OlderThan time.Duration
+	// DryRun reports the planned actions without making changes.
+	DryRun bool
+	// Now overrides the current time, mainly for tests. Defaults to time.Now.
+	Now time.Time
+}
+
+// IsExpired reports whether the invite can no longer be accepted at the given time:
+// either the API already marks it expired, or it is still pending past its expiry.
+func (i *Invite) IsExpired(now time.Time) bool {
+	if i.Status == "expired" {
+		return true
+	}
+	return i.Status == "pending" && !i.ExpiresAt.Time().IsZero() && !i.ExpiresAt.Time().After(now)
+}
+
+// PruneInvites deletes expired invites and, if opts.OlderThan is set, pending invites
+// created before that age.
+func PruneInvites(c OpenAIOrgsClient, opts InviteLifecycleOptions) ([]InviteAction, error) {
+	now := lifecycleNow(opts.Now)
+	invites, err := listAllInvites(c)
+	if err != nil {
+		return nil, err
+	}
+
+	var actions []InviteAction
+	for _, invite := range invites {
+		reason := ""
+		switch {
+		case invite.IsExpired(now):
+			reason = "expired"
+		case opts.OlderThan > 0 && invite.Status == "pending" && invite.CreatedAt.Time().Before(now.Add(-opts.OlderThan)):
+			reason = fmt.Sprintf("pending for more than %s", opts.OlderThan)
+		default:
+			continue
+		}
+
+		action := newInviteAction(invite, InviteActionDelete, reason)
+		if !opts.DryRun {
+			if err := c.DeleteInvite(invite.ID); err != nil {
+				action.fail(err)
+			} else {
+				action.Status = InviteActionDone
+			}
+		}
+		actions = append(actions, action)
+	}
+	return actions, nil
+}
+
+// ResendExpiredInvites deletes every expired invite and creates a new one with the same
+// email, role and project assignments. If recreating fails after the delete succeeded,
+// the error is recorded and the email needs to be invited again by hand.
+func ResendExpiredInvites(c OpenAIOrgsClient, opts InviteLifecycleOptions) ([]InviteAction, error) {
+	now := lifecycleNow(opts.Now)
+	invites, err := listAllInvites(c)
+	if err != nil {
+		return nil, err
+	}
+
+	var actions []InviteAction
+	for _, invite := range invites {
+		if !invite.IsExpired(now) {
+			continue
+		}
+
+		action := newInviteAction(invite, InviteActionResend, "expired")
+		if !opts.DryRun {
+			if err := c.DeleteInvite(invite.ID); err != nil {
+				action.fail(fmt.Errorf("failed to delete expired invite: %w", err))
+			} else if created, err := InviteWithProjects(c, invite.Email, invite.Role, invite.Projects); err != nil {
+				action.fail(err)
+			} else {
+				action.Status = InviteActionDone
+				action.NewInviteID = created.ID
+			}
+		}
+		actions = append(actions, action)
+	}
+	return actions, nil
+}
+
+// ExpiringInvites returns pending invites that expire within the given duration of now,
+// soonest first. Invites that have already expired are not included.
+func ExpiringInvites(c OpenAIOrgsClient, within time.Duration, now time.Time) ([]Invite, error) {
+	now = lifecycleNow(now)
+	invites, err := listAllInvites(c)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := now.Add(within)
+	var expiring []Invite
+	for _, invite := range invites {
+		expiresAt := invite.ExpiresAt.Time()
+		if invite.Status == "pending" && expiresAt.After(now) && !expiresAt.After(deadline) {
+			expiring = append(expiring, invite)
+		}
+	}
+	sort.SliceStable(expiring, func(i, j int) bool {
+		return expiring[i].ExpiresAt.Time().Before(expiring[j].ExpiresAt.Time())
+	})
+	return expiring, nil
+}
+
+func listAllInvites(c OpenAIOrgsClient) ([]Invite, error) {
+	invites, err := ListAll(func(after string) (*ListResponse[Invite], error) {
+		return c.ListInvites(100, after)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invites: %w", err)
+	}
+	return invites, nil
+}
+
+func lifecycleNow(now time.Time) time.Time {
+	if now.IsZero() {
+		return time.Now()
+	}
+	return now
+}
+
+func newInviteAction(invite Invite, action, reason string) InviteAction {
+	return InviteAction{
+		InviteID:  invite.ID,
+		Email:     invite.Email,
+		Role:      invite.Role,
+		CreatedAt: invite.CreatedAt.Time(),
+		ExpiresAt: invite.ExpiresAt.Time(),
+		Action:    action,
+		Reason:    reason,
+		Status:    InviteActionPlanned,
+	}
+}
+
+func (a *InviteAction) fail(err error) {
+	a.Status = InviteActionFailed
+	a.Error = err.Error()
+}
@@ -0,0 +1,158 @@
+package openaiorgs
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+var lifecycleNowTime = time.Unix(1700000000, 0)
+
+func lifecycleInvites() []Invite {
+	at := func(d time.Duration) UnixSeconds { return UnixSeconds(lifecycleNowTime.Add(d)) }
+	return []Invite{
+		{ID: "inv_expired", Email: "expired@example.com", Role: "reader", Status: "expired", CreatedAt: at(-10 * 24 * time.Hour), ExpiresAt: at(-3 * 24 * time.Hour)},
+		{ID: "inv_lapsed", Email: "lapsed@example.com", Role: "owner", Status: "pending", CreatedAt: at(-8 * 24 * time.Hour), ExpiresAt: at(-time.Hour)},
+		{ID: "inv_old", Email: "old@example.com", Role: "reader", Status: "pending", CreatedAt: at(-5 * 24 * time.Hour), ExpiresAt: at(2 * 24 * time.Hour)},
+		{ID: "inv_soon", Email: "soon@example.com", Role: "reader", Status: "pending", CreatedAt: at(-6 * 24 * time.Hour), ExpiresAt: at(12 * time.Hour)},
+		{ID: "inv_fresh", Email: "fresh@example.com", Role: "reader", Status: "pending", CreatedAt: at(-time.Hour), ExpiresAt: at(7 * 24 * time.Hour)},
+		{ID: "inv_accepted", Email: "accepted@example.com", Role: "reader", Status: "accepted", CreatedAt: at(-20 * 24 * time.Hour), ExpiresAt: at(-13 * 24 * time.Hour)},
+	}
+}
+
+func mockLifecycleInvites(h *testHelper) {
+	h.mockResponse("GET", InviteListEndpoint, 200, ListResponse[Invite]{Object: "list", Data: lifecycleInvites()})
+}
+
+func inviteIDs(actions []InviteAction) []string {
+	ids := make([]string, len(actions))
+	for i, a := range actions {
+		ids[i] = a.InviteID
+	}
+	return ids
+}
+
+func TestInviteIsExpired(t *testing.T) {
+	want := map[string]bool{"inv_expired": true, "inv_lapsed": true}
+	for _, invite := range lifecycleInvites() {
+		if got := invite.IsExpired(lifecycleNowTime); got != want[invite.ID] {
+			t.Errorf("%s: IsExpired() = %v, want %v", invite.ID, got, want[invite.ID])
+		}
+	}
+}
+
+func TestPruneInvites(t *testing.T) {
+	tests := []struct {
+		name      string
+		olderThan time.Duration
+		wantIDs   string
+	}{
+		{name: "expired only", wantIDs: "inv_expired,inv_lapsed"},
+		{name: "older than four days", olderThan: 4 * 24 * time.Hour, wantIDs: "inv_expired,inv_lapsed,inv_old,inv_soon"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestHelper(t)
+			defer h.cleanup()
+			mockLifecycleInvites(h)
+			for _, id := range strings.Split(tt.wantIDs, ",") {
+				h.mockResponse("DELETE", InviteListEndpoint+"/"+id, 200, map[string]any{"deleted": true})
+			}
+
+			actions, err := PruneInvites(h.client, InviteLifecycleOptions{OlderThan: tt.olderThan, Now: lifecycleNowTime})
+			if err != nil {
+				t.Fatalf("PruneInvites() error = %v", err)
+			}
+			if got := strings.Join(inviteIDs(actions), ","); got != tt.wantIDs {
+				t.Errorf("pruned %s, want %s", got, tt.wantIDs)
+			}
+			for _, action := range actions {
+				if action.Status != InviteActionDone || action.Action != InviteActionDelete {
+					t.Errorf("unexpected action: %+v", action)
+				}
+			}
+		})
+	}
+}
+
+func TestPruneInvites_DryRunAndFailure(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+	mockLifecycleInvites(h)
+
+	actions, err := PruneInvites(h.client, InviteLifecycleOptions{DryRun: true, Now: lifecycleNowTime})
+	if err != nil {
+		t.Fatalf("PruneInvites() error = %v", err)
+	}
+	if len(actions) != 2 || actions[0].Status != InviteActionPlanned {
+		t.Errorf("unexpected dry-run actions: %+v", actions)
+	}
+	h.assertRequest("DELETE", InviteListEndpoint+"/inv_expired", 0)
+
+	h.mockResponse("DELETE", InviteListEndpoint+"/inv_expired", 500, map[string]string{"error": "boom"})
+	h.mockResponse("DELETE", InviteListEndpoint+"/inv_lapsed", 200, map[string]any{"deleted": true})
+	actions, err = PruneInvites(h.client, InviteLifecycleOptions{Now: lifecycleNowTime})
+	if err != nil {
+		t.Fatalf("PruneInvites() error = %v", err)
+	}
+	if actions[0].Status != InviteActionFailed || actions[1].Status != InviteActionDone {
+		t.Errorf("expected failure to be recorded and processing to continue, got %+v", actions)
+	}
+}
+
+func TestResendExpiredInvites(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+	mockLifecycleInvites(h)
+	h.mockResponse("DELETE", InviteListEndpoint+"/inv_expired", 200, map[string]any{"deleted": true})
+	h.mockResponse("DELETE", InviteListEndpoint+"/inv_lapsed", 200, map[string]any{"deleted": true})
+	h.mockResponse("POST", InviteListEndpoint, 200, Invite{ID: "inv_new"})
+
+	actions, err := ResendExpiredInvites(h.client, InviteLifecycleOptions{Now: lifecycleNowTime})
+	if err != nil {
+		t.Fatalf("ResendExpiredInvites() error = %v", err)
+	}
+	if got := strings.Join(inviteIDs(actions), ","); got != "inv_expired,inv_lapsed" {
+		t.Errorf("resent %s", got)
+	}
+	for _, action := range actions {
+		if action.Status != InviteActionDone || action.NewInviteID != "inv_new" || action.Action != InviteActionResend {
+			t.Errorf("unexpected action: %+v", action)
+		}
+	}
+	h.assertRequest("POST", InviteListEndpoint, 2)
+}
+
+func TestResendExpiredInvites_DeleteFailure(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+	mockLifecycleInvites(h)
+	h.mockResponse("DELETE", InviteListEndpoint+"/inv_expired", 500, map[string]string{"error": "boom"})
+	h.mockResponse("DELETE", InviteListEndpoint+"/inv_lapsed", 200, map[string]any{"deleted": true})
+	h.mockResponse("POST", InviteListEndpoint, 200, Invite{ID: "inv_new"})
+
+	actions, err := ResendExpiredInvites(h.client, InviteLifecycleOptions{Now: lifecycleNowTime})
+	if err != nil {
+		t.Fatalf("ResendExpiredInvites() error = %v", err)
+	}
+	if actions[0].Status != InviteActionFailed || !strings.Contains(actions[0].Error, "failed to delete expired invite") {
+		t.Errorf("expected delete failure, got %+v", actions[0])
+	}
+	// The invite is only recreated for the email whose old invite was deleted.
+	h.assertRequest("POST", InviteListEndpoint, 1)
+}
+
+func TestExpiringInvites(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+	mockLifecycleInvites(h)
+
+	invites, err := ExpiringInvites(h.client, 48*time.Hour, lifecycleNowTime)
+	if err != nil {
+		t.Fatalf("ExpiringInvites() error = %v", err)
+	}
+	if len(invites) != 2 || invites[0].ID != "inv_soon" || invites[1].ID != "inv_old" {
+		t.Errorf("unexpected expiring invites: %+v", invites)
+	}
+}
@@ -23,6 +23,8 @@ type Invite struct {
 	ExpiresAt UnixSeconds `json:"expires_at"`
 	// AcceptedAt is the timestamp when the invitation was accepted, if applicable.
 	AcceptedAt *UnixSeconds `json:"accepted_at,omitempty"`
+	// Projects lists the projects the user will be added to upon accepting, with their project roles.
+	Projects []ProjectAssignment `json:"projects,omitempty"`
 }
 
 // InviteListEndpoint is the base endpoint for invitation management operations.
@@ -54,10 +56,26 @@ func (c *Client) ListInvites(limit int, after string) (*ListResponse[Invite], er
 //
 // Returns the created Invite object or an error if creation fails.
 func (c *Client) CreateInvite(email string, role string) (*Invite, error) {
-	body := map[string]string{
+	return c.CreateInviteWithProjects(email, role, nil)
+}
+
+// CreateInviteWithProjects sends a new invitation that also adds the user to the
+// given projects, with the given project roles, when they accept.
+//
+// Parameters:
+//   - email: The email address of the user to invite
+//   - role: The organization role to assign to the user upon acceptance
+//   - projects: Project memberships to grant upon acceptance (may be empty)
+//
+// Returns the created Invite object or an error if creation fails.
+func (c *Client) CreateInviteWithProjects(email string, role string, projects []ProjectAssignment) (*Invite, error) {
+	body := map[string]any{
 		"email": email,
 		"role":  role,
 	}
+	if len(projects) > 0 {
+		body["projects"] = projects
+	}
 
 	invite, err := Post[Invite](c.client, InviteListEndpoint, body)
 	if err != nil {
@@ -67,6 +85,20 @@ func (c *Client) CreateInvite(email string, role string) (*Invite, error) {
 	return invite, nil
 }
 
+// InviteWithProjects creates an invite through c, attaching the project assignments
+// if there are any. Assignments require c to implement ProjectInviteCreator; without
+// them the invite is created with CreateInvite.
+func InviteWithProjects(c OpenAIOrgsClient, email string, role string, projects []ProjectAssignment) (*Invite, error) {
+	if len(projects) == 0 {
+		return c.CreateInvite(email, role)
+	}
+	creator, ok := c.(ProjectInviteCreator)
+	if !ok {
+		return nil, fmt.Errorf("failed to create invite: client does not support project assignments")
+	}
+	return creator.CreateInviteWithProjects(email, role, projects)
+}
+
 // RetrieveInvite fetches details of a specific invitation.
 //
 // Parameters:
@@ -104,6 +136,10 @@ func (i *Invite) String() string {
 	if i.AcceptedAt != nil {
 		acceptedInfo = fmt.Sprintf(", Accepted: %s", i.AcceptedAt.String())
 	}
-	return fmt.Sprintf("Invite{ID: %s, Email: %s, Role: %s, Status: %s%s}",
-		i.ID, i.Email, i.Role, i.Status, acceptedInfo)
+	projectInfo := ""
+	if len(i.Projects) > 0 {
+		projectInfo = fmt.Sprintf(", Projects: %s", FormatProjectAssignments(i.Projects))
+	}
+	return fmt.Sprintf("Invite{ID: %s, Email: %s, Role: %s, Status: %s%s%s}",
+		i.ID, i.Email, i.Role, i.Status, acceptedInfo, projectInfo)
 }
@@ -1,8 +1,14 @@
 package openaiorgs
 
 import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/jarcoal/httpmock"
 )
 
 func TestListInvites(t *testing.T) {
@@ -142,6 +148,91 @@ func TestCreateInvite(t *testing.T) {
 	h.assertRequest("POST", InviteListEndpoint, 1)
 }
 
+func TestCreateInviteWithProjects(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+
+	var body map[string]any
+	httpmock.RegisterResponder("POST", testBaseURL+InviteListEndpoint, func(req *http.Request) (*http.Response, error) {
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		return httpmock.NewStringResponse(200, `{
+			"object": "organization.invite",
+			"id": "inv_123",
+			"email": "new@example.com",
+			"role": "reader",
+			"status": "pending",
+			"projects": [{"id": "proj_a", "role": "member"}, {"id": "proj_b", "role": "owner"}]
+		}`), nil
+	})
+
+	projects := []ProjectAssignment{{ProjectID: "proj_a", Role: "member"}, {ProjectID: "proj_b", Role: "owner"}}
+	invite, err := h.client.CreateInviteWithProjects("new@example.com", "reader", projects)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	wantBody := map[string]any{
+		"email": "new@example.com",
+		"role":  "reader",
+		"projects": []any{
+			map[string]any{"id": "proj_a", "role": "member"},
+			map[string]any{"id": "proj_b", "role": "owner"},
+		},
+	}
+	if !reflect.DeepEqual(body, wantBody) {
+		t.Errorf("unexpected request body: %v", body)
+	}
+	if !reflect.DeepEqual(invite.Projects, projects) {
+		t.Errorf("Expected projects %v, got %v", projects, invite.Projects)
+	}
+	if !strings.Contains(invite.String(), "Projects: proj_a:member;proj_b:owner") {
+		t.Errorf("Expected projects in String(), got %s", invite.String())
+	}
+}
+
+func TestCreateInvite_OmitsEmptyProjects(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+
+	var body map[string]any
+	httpmock.RegisterResponder("POST", testBaseURL+InviteListEndpoint, func(req *http.Request) (*http.Response, error) {
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		return httpmock.NewJsonResponse(200, Invite{ID: "inv_123"})
+	})
+
+	if _, err := h.client.CreateInvite("new@example.com", "reader"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, ok := body["projects"]; ok {
+		t.Errorf("Expected no projects in request body, got %v", body)
+	}
+}
+
+func TestInviteWithProjects(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+	h.mockResponse("POST", InviteListEndpoint, 200, Invite{ID: "inv_123"})
+
+	projects := []ProjectAssignment{{ProjectID: "proj_a", Role: "member"}}
+	if _, err := InviteWithProjects(h.client, "new@example.com", "reader", projects); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Embedding the interface hides CreateInviteWithProjects, like an external implementation.
+	basic := struct{ OpenAIOrgsClient }{h.client}
+	if _, err := InviteWithProjects(basic, "new@example.com", "reader", nil); err != nil {
+		t.Fatalf("Expected no error without projects, got %v", err)
+	}
+	if _, err := InviteWithProjects(basic, "new@example.com", "reader", projects); err == nil || !strings.Contains(err.Error(), "does not support project assignments") {
+		t.Errorf("Expected unsupported error, got %v", err)
+	}
+	h.assertRequest("POST", InviteListEndpoint, 2)
+}
+
 func TestRetrieveInvite(t *testing.T) {
 	h := newTestHelper(t)
 	defer h.cleanup()
@@ -0,0 +1,198 @@
+package openaiorgs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Offboarding action kinds, in the order ExecuteOffboarding performs them.
+const (
+	OffboardingDeleteAPIKey     = "delete_api_key"
+	OffboardingRemoveMembership = "remove_project_membership"
+	OffboardingDeleteInvite     = "delete_invite"
+	OffboardingDeleteUser       = "delete_user"
+)
+
+// Offboarding action statuses.
+const (
+	OffboardingStatusPlanned = "planned"
+	OffboardingStatusDone    = "done"
+	OffboardingStatusFailed  = "failed"
+)
+
+// OffboardingMembership is a project the departing user belongs to.
+type OffboardingMembership struct {
+	ProjectID   string `json:"project_id"`
+	ProjectName string `json:"project_name"`
+	Role        string `json:"role"`
+}
+
+// OffboardingAPIKey is a project API key owned by the departing user.
+type OffboardingAPIKey struct {
+	ProjectID   string `json:"project_id"`
+	ProjectName string `json:"project_name"`
+	KeyID       string `json:"key_id"`
+	KeyName     string `json:"key_name"`
+}
+
+// OffboardingPlan lists everything that will be removed for a departing user.
+// User is nil when the email only has pending invites.
+type OffboardingPlan struct {
+	Email       string                  `json:"email"`
+	User        *User                   `json:"user,omitempty"`
+	Memberships []OffboardingMembership `json:"memberships"`
+	APIKeys     []OffboardingAPIKey     `json:"api_keys"`
+	Invites     []Invite                `json:"invites"`
+}
+
+// OffboardingAction records one step of an offboarding run.
+type OffboardingAction struct {
+	Action     string `json:"action"`
+	ProjectID  string `json:"project_id,omitempty"`
+	ResourceID string `json:"resource_id"`
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+}
+
+// OffboardingReport is the outcome of ExecuteOffboarding.
+type OffboardingReport struct {
+	Email   string              `json:"email"`
+	UserID  string              `json:"user_id,omitempty"`
+	DryRun  bool                `json:"dry_run"`
+	Actions []OffboardingAction `json:"actions"`
+	Failed  int                 `json:"failed"`
+}
+
+// PlanOffboarding finds the organization user with the given email (case-insensitive),
+// every project they belong to, every project API key they own, and any pending
+// invites sent to the email. It makes no changes.
+func PlanOffboarding(c OpenAIOrgsClient, email string, opts InventoryOptions) (*OffboardingPlan, error) {
+	plan := &OffboardingPlan{Email: email}
+
+	users, err := ListAll(func(after string) (*ListResponse[User], error) {
+		return c.ListUsers(100, after)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	for i := range users {
+		if strings.EqualFold(users[i].Email, email) {
+			plan.User = &users[i]
+			break
+		}
+	}
+
+	invites, err := ListAll(func(after string) (*ListResponse[Invite], error) {
+		return c.ListInvites(100, after)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invites: %w", err)
+	}
+	for _, invite := range invites {
+		if strings.EqualFold(invite.Email, email) && invite.Status == "pending" {
+			plan.Invites = append(plan.Invites, invite)
+		}
+	}
+
+	if plan.User == nil {
+		if len(plan.Invites) == 0 {
+			return nil, fmt.Errorf("no user or pending invite found for %s", email)
+		}
+		return plan, nil
+	}
+
+	inventory, err := CollectProjectInventory(c, opts)
+	if err != nil {
+		return nil, err
+	}
+	for _, project := range inventory {
+		for _, member := range project.Users {
+			if member.ID == plan.User.ID {
+				plan.Memberships = append(plan.Memberships, OffboardingMembership{
+					ProjectID:   project.Project.ID,
+					ProjectName: project.Project.Name,
+					Role:        member.Role,
+				})
+			}
+		}
+		for _, key := range project.APIKeys {
+			if key.Owner.User != nil && key.Owner.User.ID == plan.User.ID {
+				plan.APIKeys = append(plan.APIKeys, OffboardingAPIKey{
+					ProjectID:   project.Project.ID,
+					ProjectName: project.Project.Name,
+					KeyID:       key.ID,
+					KeyName:     key.Name,
+				})
+			}
+		}
+	}
+	return plan, nil
+}
+
+// ExecuteOffboarding carries out plan. API keys are deleted before memberships are
+// removed so that each key is revoked explicitly rather than relying on the membership
+// removal to cascade. Pending invites are deleted next and the organization user last.
+//
+// Each step is attempted even if an earlier one fails; failures are recorded in the
+// report. The user is only deleted if every earlier step succeeded, so a partial run
+// can be retried. With dryRun set, every step is reported as planned and nothing is changed.
+func ExecuteOffboarding(c OpenAIOrgsClient, plan *OffboardingPlan, dryRun bool) *OffboardingReport {
+	report := &OffboardingReport{
+		Email:  plan.Email,
+		DryRun: dryRun,
+	}
+	if plan.User != nil {
+		report.UserID = plan.User.ID
+	}
+
+	run := func(action, projectID, resourceID string, fn func() error) {
+		step := OffboardingAction{
+			Action:     action,
+			ProjectID:  projectID,
+			ResourceID: resourceID,
+			Status:     OffboardingStatusPlanned,
+		}
+		if !dryRun {
+			if err := fn(); err != nil {
+				step.Status = OffboardingStatusFailed
+				step.Error = err.Error()
+				report.Failed++
+			} else {
+				step.Status = OffboardingStatusDone
+			}
+		}
+		report.Actions = append(report.Actions, step)
+	}
+
+	for _, key := range plan.APIKeys {
+		run(OffboardingDeleteAPIKey, key.ProjectID, key.KeyID, func() error {
+			return c.DeleteProjectApiKey(key.ProjectID, key.KeyID)
+		})
+	}
+	for _, membership := range plan.Memberships {
+		run(OffboardingRemoveMembership, membership.ProjectID, plan.User.ID, func() error {
+			return c.DeleteProjectUser(membership.ProjectID, plan.User.ID)
+		})
+	}
+	for _, invite := range plan.Invites {
+		run(OffboardingDeleteInvite, "", invite.ID, func() error {
+			return c.DeleteInvite(invite.ID)
+		})
+	}
+	if plan.User != nil {
+		if report.Failed > 0 {
+			report.Actions = append(report.Actions, OffboardingAction{
+				Action:     OffboardingDeleteUser,
+				ResourceID: plan.User.ID,
+				Status:     OffboardingStatusFailed,
+				Error:      "skipped because earlier steps failed",
+			})
+			report.Failed++
+		} else {
+			run(OffboardingDeleteUser, "", plan.User.ID, func() error {
+				return c.DeleteUser(plan.User.ID)
+			})
+		}
+	}
+	return report
+}
@@ -0,0 +1,159 @@
+package openaiorgs
+
+import (
+	"strings"
+	"testing"
+)
+
+func mockOffboardingOrg(h *testHelper) {
+	h.mockResponse("GET", UsersListEndpoint, 200, ListResponse[User]{
+		Object: "list",
+		Data: []User{
+			{ID: "user_keep", Email: "keep@example.com"},
+			{ID: "user_gone", Email: "Gone@Example.com", Role: "reader"},
+		},
+	})
+	h.mockResponse("GET", InviteListEndpoint, 200, ListResponse[Invite]{
+		Object: "list",
+		Data: []Invite{
+			{ID: "invite_pending", Email: "gone@example.com", Status: "pending"},
+			{ID: "invite_accepted", Email: "gone@example.com", Status: "accepted"},
+			{ID: "invite_other", Email: "other@example.com", Status: "pending"},
+		},
+	})
+	h.mockResponse("GET", ProjectsListEndpoint, 200, ListResponse[Project]{
+		Object: "list",
+		Data:   []Project{{ID: "proj_1", Name: "One"}, {ID: "proj_2", Name: "Two"}},
+	})
+	mockProjectInventory(h, "proj_1",
+		[]ProjectUser{{ID: "user_gone", Role: "owner"}, {ID: "user_keep", Role: "member"}},
+		nil,
+		[]ProjectApiKey{
+			{ID: "key_gone", Name: "gone-key", Owner: Owner{Type: OwnerTypeUser, User: &User{ID: "user_gone"}}},
+			{ID: "key_keep", Name: "keep-key", Owner: Owner{Type: OwnerTypeUser, User: &User{ID: "user_keep"}}},
+		})
+	mockProjectInventory(h, "proj_2", []ProjectUser{{ID: "user_keep", Role: "owner"}}, nil, nil)
+}
+
+func TestPlanOffboarding(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+	mockOffboardingOrg(h)
+
+	plan, err := PlanOffboarding(h.client, "gone@example.com", InventoryOptions{})
+	if err != nil {
+		t.Fatalf("PlanOffboarding() error = %v", err)
+	}
+	if plan.User == nil || plan.User.ID != "user_gone" {
+		t.Fatalf("expected user_gone, got %+v", plan.User)
+	}
+	if len(plan.Memberships) != 1 || plan.Memberships[0].ProjectID != "proj_1" || plan.Memberships[0].Role != "owner" {
+		t.Errorf("unexpected memberships: %+v", plan.Memberships)
+	}
+	if len(plan.APIKeys) != 1 || plan.APIKeys[0].KeyID != "key_gone" {
+		t.Errorf("unexpected API keys: %+v", plan.APIKeys)
+	}
+	if len(plan.Invites) != 1 || plan.Invites[0].ID != "invite_pending" {
+		t.Errorf("unexpected invites: %+v", plan.Invites)
+	}
+}
+
+func TestPlanOffboarding_InviteOnly(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+	mockOffboardingOrg(h)
+
+	plan, err := PlanOffboarding(h.client, "other@example.com", InventoryOptions{})
+	if err != nil {
+		t.Fatalf("PlanOffboarding() error = %v", err)
+	}
+	if plan.User != nil || len(plan.Invites) != 1 {
+		t.Errorf("expected invite-only plan, got %+v", plan)
+	}
+	h.assertRequest("GET", ProjectsListEndpoint, 0)
+}
+
+func TestPlanOffboarding_NotFound(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+	mockOffboardingOrg(h)
+
+	_, err := PlanOffboarding(h.client, "nobody@example.com", InventoryOptions{})
+	if err == nil || !strings.Contains(err.Error(), "no user or pending invite") {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func testOffboardingPlan() *OffboardingPlan {
+	return &OffboardingPlan{
+		Email:       "gone@example.com",
+		User:        &User{ID: "user_gone"},
+		Memberships: []OffboardingMembership{{ProjectID: "proj_1"}},
+		APIKeys:     []OffboardingAPIKey{{ProjectID: "proj_1", KeyID: "key_gone"}},
+		Invites:     []Invite{{ID: "invite_pending"}},
+	}
+}
+
+func TestExecuteOffboarding(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+
+	h.mockResponse("DELETE", "/organization/projects/proj_1/api_keys/key_gone", 200, map[string]any{"deleted": true})
+	h.mockResponse("DELETE", "/organization/projects/proj_1/users/user_gone", 200, map[string]any{"deleted": true})
+	h.mockResponse("DELETE", InviteListEndpoint+"/invite_pending", 200, map[string]any{"deleted": true})
+	h.mockResponse("DELETE", UsersListEndpoint+"/user_gone", 200, map[string]any{"deleted": true})
+
+	report := ExecuteOffboarding(h.client, testOffboardingPlan(), false)
+	if report.Failed != 0 {
+		t.Fatalf("expected no failures, got %+v", report.Actions)
+	}
+
+	wantOrder := []string{OffboardingDeleteAPIKey, OffboardingRemoveMembership, OffboardingDeleteInvite, OffboardingDeleteUser}
+	if len(report.Actions) != len(wantOrder) {
+		t.Fatalf("expected %d actions, got %d", len(wantOrder), len(report.Actions))
+	}
+	for i, action := range report.Actions {
+		if action.Action != wantOrder[i] || action.Status != OffboardingStatusDone {
+			t.Errorf("action %d: expected %s done, got %+v", i, wantOrder[i], action)
+		}
+	}
+	h.assertRequest("DELETE", UsersListEndpoint+"/user_gone", 1)
+}
+
+func TestExecuteOffboarding_DryRun(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+
+	report := ExecuteOffboarding(h.client, testOffboardingPlan(), true)
+	if !report.DryRun || len(report.Actions) != 4 {
+		t.Fatalf("unexpected dry-run report: %+v", report)
+	}
+	for _, action := range report.Actions {
+		if action.Status != OffboardingStatusPlanned {
+			t.Errorf("expected planned status, got %+v", action)
+		}
+	}
+	h.assertRequest("DELETE", UsersListEndpoint+"/user_gone", 0)
+}
+
+func TestExecuteOffboarding_ContinuesOnError(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+
+	h.mockResponse("DELETE", "/organization/projects/proj_1/api_keys/key_gone", 500, map[string]string{"error": "boom"})
+	h.mockResponse("DELETE", "/organization/projects/proj_1/users/user_gone", 200, map[string]any{"deleted": true})
+	h.mockResponse("DELETE", InviteListEndpoint+"/invite_pending", 200, map[string]any{"deleted": true})
+
+	report := ExecuteOffboarding(h.client, testOffboardingPlan(), false)
+	if report.Failed != 2 {
+		t.Errorf("expected 2 failures (key and skipped user), got %d: %+v", report.Failed, report.Actions)
+	}
+	if report.Actions[1].Status != OffboardingStatusDone || report.Actions[2].Status != OffboardingStatusDone {
+		t.Errorf("expected later steps to run, got %+v", report.Actions)
+	}
+	last := report.Actions[len(report.Actions)-1]
+	if last.Action != OffboardingDeleteUser || last.Status != OffboardingStatusFailed {
+		t.Errorf("expected user deletion to be skipped, got %+v", last)
+	}
+	h.assertRequest("DELETE", UsersListEndpoint+"/user_gone", 0)
+}
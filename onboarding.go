@@ -0,0 +1,299 @@
+package openaiorgs
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// Onboarding row statuses.
+const (
+	OnboardingStatusCreated   = "created"
+	OnboardingStatusUpdated   = "updated"
+	OnboardingStatusUnchanged = "unchanged"
+	OnboardingStatusPlanned   = "planned"
+	OnboardingStatusFailed    = "failed"
+)
+
+// OnboardingRow is one person to onboard, as read from an onboarding CSV.
+type OnboardingRow struct {
+	// Line is the line number in the source file, for error reporting.
+	Line     int                 `json:"line"`
+	Email    string              `json:"email"`
+	Role     string              `json:"role"`
+	Projects []ProjectAssignment `json:"projects,omitempty"`
+}
+
+// OnboardingOptions controls Onboard.
+type OnboardingOptions struct {
+	// Concurrency is the maximum number of rows processed at once.
+	// Defaults to DefaultInventoryConcurrency.
+	Concurrency int
+	// DryRun reports the changes that would be made without making them.
+	DryRun bool
+}
+
+// OnboardingResult is the outcome of onboarding one row.
+type OnboardingResult struct {
+	Line    int      `json:"line"`
+	Email   string   `json:"email"`
+	Status  string   `json:"status"`
+	Actions []string `json:"actions,omitempty"`
+	Errors  []string `json:"errors,omitempty"`
+}
+
+// ParseOnboardingCSV reads onboarding rows from CSV with the columns
+// email, org role, and project assignments. Assignments are "project_id:role" pairs,
+// either in a single column separated by semicolons or spread over extra columns.
+// A first row starting with "email" is treated as a header and skipped.
+func ParseOnboardingCSV(r io.Reader) ([]OnboardingRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	var rows []OnboardingRow
+	seen := make(map[string]int)
+	first := true
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		isHeader := first && strings.EqualFold(strings.TrimSpace(record[0]), "email")
+		first = false
+		if isHeader {
+			continue
+		}
+		if len(record) < 2 {
+			return nil, fmt.Errorf("line %d: expected at least email and role columns", line)
+		}
+
+		row := OnboardingRow{
+			Line:  line,
+			Email: strings.TrimSpace(record[0]),
+			Role:  strings.TrimSpace(record[1]),
+		}
+		if row.Email == "" || row.Role == "" {
+			return nil, fmt.Errorf("line %d: email and role are required", line)
+		}
+		key := strings.ToLower(row.Email)
+		if prev, ok := seen[key]; ok {
+			return nil, fmt.Errorf("line %d: %s is already listed on line %d", line, row.Email, prev)
+		}
+		seen[key] = line
+
+		for _, column := range record[2:] {
+			assignments, err := ParseProjectAssignments(column)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			row.Projects = append(row.Projects, assignments...)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// Onboard brings every row's organization membership and project roles in line with
+// the file. People not yet in the organization are invited, with their project
+// assignments attached to the invite, unless an invite is already pending. Existing
+// members are added to missing projects or have their project role changed;
+// memberships that already have the right role are left alone, so running the same
+// file twice makes no further changes. Organization roles of existing members are
+// never changed, only reported.
+//
+// Rows are processed concurrently. A failure in one row is recorded in its result and
+// does not stop the others. An error is only returned if the initial lookups fail.
+func Onboard(c OpenAIOrgsClient, rows []OnboardingRow, opts OnboardingOptions) ([]OnboardingResult, error) {
+	users, err := ListAll(func(after string) (*ListResponse[User], error) {
+		return c.ListUsers(100, after)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	usersByEmail := make(map[string]User, len(users))
+	for _, user := range users {
+		usersByEmail[strings.ToLower(user.Email)] = user
+	}
+
+	invites, err := ListAll(func(after string) (*ListResponse[Invite], error) {
+		return c.ListInvites(100, after)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invites: %w", err)
+	}
+	pendingInvites := make(map[string]Invite)
+	for _, invite := range invites {
+		if invite.Status == "pending" {
+			pendingInvites[strings.ToLower(invite.Email)] = invite
+		}
+	}
+
+	members := &projectMemberCache{client: c, projects: make(map[string]*projectMembers)}
+	results := make([]OnboardingResult, len(rows))
+	_ = forEachConcurrently(len(rows), opts.Concurrency, func(i int) error {
+		row := rows[i]
+		o := &rowOnboarder{
+			client: c,
+			dryRun: opts.DryRun,
+			result: OnboardingResult{Line: row.Line, Email: row.Email},
+		}
+		key := strings.ToLower(row.Email)
+		if user, ok := usersByEmail[key]; ok {
+			o.onboardMember(row, user, members)
+		} else {
+			invite, pending := pendingInvites[key]
+			o.onboardInvitee(row, invite, pending)
+		}
+		results[i] = o.finish()
+		return nil
+	})
+	return results, nil
+}
+
+// rowOnboarder accumulates the actions and errors for one onboarding row.
+type rowOnboarder struct {
+	client  OpenAIOrgsClient
+	dryRun  bool
+	result  OnboardingResult
+	changed bool
+	created bool
+}
+
+func (o *rowOnboarder) note(format string, args ...any) {
+	o.result.Actions = append(o.result.Actions, fmt.Sprintf(format, args...))
+}
+
+func (o *rowOnboarder) fail(err error) {
+	o.result.Errors = append(o.result.Errors, err.Error())
+}
+
+// apply records action and, unless this is a dry run, performs it.
+func (o *rowOnboarder) apply(action string, fn func() error) bool {
+	if o.dryRun {
+		o.note("would %s", action)
+		o.changed = true
+		return true
+	}
+	if err := fn(); err != nil {
+		o.fail(fmt.Errorf("%s: %w", action, err))
+		return false
+	}
+	o.note("%s", action)
+	o.changed = true
+	return true
+}
+
+func (o *rowOnboarder) onboardInvitee(row OnboardingRow, invite Invite, pending bool) {
+	if pending {
+		o.note("invite %s already pending", invite.ID)
+	} else {
+		action := fmt.Sprintf("invite as %s", row.Role)
+		if len(row.Projects) > 0 {
+			action += " with projects " + FormatProjectAssignments(row.Projects)
+		}
+		if o.apply(action, func() error {
+			_, err := InviteWithProjects(o.client, row.Email, row.Role, row.Projects)
+			return err
+		}) {
+			o.created = true
+		}
+	}
+}
+
+func (o *rowOnboarder) onboardMember(row OnboardingRow, user User, members *projectMemberCache) {
+	if user.Role != row.Role {
+		o.note("organization role is %s, not %s (not changed)", user.Role, row.Role)
+	}
+	for _, assignment := range row.Projects {
+		current, err := members.role(assignment.ProjectID, user.ID)
+		if err != nil {
+			o.fail(err)
+			continue
+		}
+		switch current {
+		case "":
+			o.apply(fmt.Sprintf("add to %s as %s", assignment.ProjectID, assignment.Role), func() error {
+				_, err := o.client.CreateProjectUser(assignment.ProjectID, user.ID, assignment.Role)
+				return err
+			})
+		case assignment.Role:
+			// Already a member with the requested role.
+		default:
+			o.apply(fmt.Sprintf("change role in %s from %s to %s", assignment.ProjectID, current, assignment.Role), func() error {
+				_, err := o.client.ModifyProjectUser(assignment.ProjectID, user.ID, assignment.Role)
+				return err
+			})
+		}
+	}
+}
+
+func (o *rowOnboarder) finish() OnboardingResult {
+	switch {
+	case len(o.result.Errors) > 0:
+		o.result.Status = OnboardingStatusFailed
+	case !o.changed:
+		o.result.Status = OnboardingStatusUnchanged
+	case o.dryRun:
+		o.result.Status = OnboardingStatusPlanned
+	case o.created:
+		o.result.Status = OnboardingStatusCreated
+	default:
+		o.result.Status = OnboardingStatusUpdated
+	}
+	return o.result
+}
+
+// projectMemberCache lists each project's users once and shares the result between rows.
+// Rows waiting on different projects fetch them concurrently; rows waiting on the same
+// project share a single fetch.
+type projectMemberCache struct {
+	client   OpenAIOrgsClient
+	mu       sync.Mutex
+	projects map[string]*projectMembers
+}
+
+// projectMembers is the cached result of listing one project's users.
+type projectMembers struct {
+	once  sync.Once
+	roles map[string]string
+	err   error
+}
+
+// role returns the user's role in the project, or "" if they are not a member.
+func (m *projectMemberCache) role(projectID, userID string) (string, error) {
+	m.mu.Lock()
+	members, ok := m.projects[projectID]
+	if !ok {
+		members = &projectMembers{}
+		m.projects[projectID] = members
+	}
+	m.mu.Unlock()
+
+	members.once.Do(func() {
+		users, err := ListAll(func(after string) (*ListResponse[ProjectUser], error) {
+			return m.client.ListProjectUsers(projectID, 100, after)
+		})
+		if err != nil {
+			members.err = fmt.Errorf("failed to list users of project %s: %w", projectID, err)
+			return
+		}
+		members.roles = make(map[string]string, len(users))
+		for _, user := range users {
+			members.roles[user.ID] = user.Role
+		}
+	})
+	if members.err != nil {
+		return "", members.err
+	}
+	return members.roles[userID], nil
+}
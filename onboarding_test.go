@@ -0,0 +1,152 @@
+package openaiorgs
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseOnboardingCSV(t *testing.T) {
+	input := `email,role,projects
+# contractors
+new@example.com, reader, proj_a:member;proj_b:owner
+existing@example.com,owner,proj_a:owner,proj_c:member
+plain@example.com,reader
+`
+	rows, err := ParseOnboardingCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseOnboardingCSV() error = %v", err)
+	}
+
+	want := []OnboardingRow{
+		{Line: 3, Email: "new@example.com", Role: "reader", Projects: []ProjectAssignment{{"proj_a", "member"}, {"proj_b", "owner"}}},
+		{Line: 4, Email: "existing@example.com", Role: "owner", Projects: []ProjectAssignment{{"proj_a", "owner"}, {"proj_c", "member"}}},
+		{Line: 5, Email: "plain@example.com", Role: "reader"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("ParseOnboardingCSV() = %+v, want %+v", rows, want)
+	}
+}
+
+func TestParseOnboardingCSV_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{name: "missing role", input: "a@example.com\n", wantErr: "line 1: expected at least email and role"},
+		{name: "empty role", input: "a@example.com,\n", wantErr: "line 1: email and role are required"},
+		{name: "duplicate", input: "a@example.com,reader\nA@example.com,owner\n", wantErr: "line 2: A@example.com is already listed on line 1"},
+		{name: "bad assignment", input: "a@example.com,reader,proj_a\n", wantErr: "line 1: invalid project assignment"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseOnboardingCSV(strings.NewReader(tt.input))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func mockOnboardingOrg(h *testHelper) {
+	h.mockResponse("GET", UsersListEndpoint, 200, ListResponse[User]{
+		Object: "list",
+		Data:   []User{{ID: "user_1", Email: "existing@example.com", Role: "reader"}},
+	})
+	h.mockResponse("GET", InviteListEndpoint, 200, ListResponse[Invite]{
+		Object: "list",
+		Data:   []Invite{{ID: "invite_1", Email: "pending@example.com", Status: "pending"}},
+	})
+	h.mockResponse("GET", "/organization/projects/proj_a/users", 200, ListResponse[ProjectUser]{
+		Object: "list",
+		Data:   []ProjectUser{{ID: "user_1", Role: "member"}},
+	})
+	h.mockResponse("GET", "/organization/projects/proj_b/users", 200, ListResponse[ProjectUser]{Object: "list"})
+	h.mockResponse("GET", "/organization/projects/proj_c/users", 200, ListResponse[ProjectUser]{
+		Object: "list",
+		Data:   []ProjectUser{{ID: "user_1", Role: "owner"}},
+	})
+	h.mockResponse("POST", InviteListEndpoint, 200, Invite{ID: "invite_new", Email: "new@example.com"})
+	h.mockResponse("POST", "/organization/projects/proj_b/users", 200, ProjectUser{ID: "user_1", Role: "member"})
+	h.mockResponse("POST", "/organization/projects/proj_a/users/user_1", 200, ProjectUser{ID: "user_1", Role: "owner"})
+}
+
+var onboardingRows = []OnboardingRow{
+	{Line: 1, Email: "new@example.com", Role: "reader", Projects: []ProjectAssignment{{"proj_a", "member"}}},
+	{Line: 2, Email: "pending@example.com", Role: "reader"},
+	{Line: 3, Email: "Existing@example.com", Role: "owner", Projects: []ProjectAssignment{{"proj_a", "owner"}, {"proj_b", "member"}, {"proj_c", "owner"}}},
+	{Line: 4, Email: "existing@example.com", Role: "reader", Projects: []ProjectAssignment{{"proj_c", "owner"}}},
+}
+
+func TestOnboard(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+	mockOnboardingOrg(h)
+
+	results, err := Onboard(h.client, onboardingRows, OnboardingOptions{Concurrency: 2})
+	if err != nil {
+		t.Fatalf("Onboard() error = %v", err)
+	}
+
+	wantStatus := []string{OnboardingStatusCreated, OnboardingStatusUnchanged, OnboardingStatusUpdated, OnboardingStatusUnchanged}
+	for i, result := range results {
+		if result.Status != wantStatus[i] {
+			t.Errorf("row %d: expected status %s, got %+v", i, wantStatus[i], result)
+		}
+	}
+
+	wantActions := []string{
+		"organization role is reader, not owner (not changed)",
+		"change role in proj_a from member to owner",
+		"add to proj_b as member",
+	}
+	if !reflect.DeepEqual(results[2].Actions, wantActions) {
+		t.Errorf("unexpected actions for existing member: %v", results[2].Actions)
+	}
+	if results[1].Actions[0] != "invite invite_1 already pending" {
+		t.Errorf("unexpected actions for pending invite: %v", results[1].Actions)
+	}
+
+	h.assertRequest("POST", InviteListEndpoint, 1)
+	h.assertRequest("POST", "/organization/projects/proj_b/users", 1)
+	h.assertRequest("POST", "/organization/projects/proj_a/users/user_1", 1)
+	// Each project's members are listed once even though several rows reference it.
+	h.assertRequest("GET", "/organization/projects/proj_c/users", 1)
+}
+
+func TestOnboard_DryRun(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+	mockOnboardingOrg(h)
+
+	results, err := Onboard(h.client, onboardingRows, OnboardingOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("Onboard() error = %v", err)
+	}
+	if results[0].Status != OnboardingStatusPlanned || results[0].Actions[0] != "would invite as reader with projects proj_a:member" {
+		t.Errorf("unexpected dry-run result: %+v", results[0])
+	}
+	h.assertRequest("POST", InviteListEndpoint, 0)
+	h.assertRequest("POST", "/organization/projects/proj_b/users", 0)
+}
+
+func TestOnboard_CollectsFailures(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+	mockOnboardingOrg(h)
+	h.mockResponse("POST", InviteListEndpoint, 400, map[string]string{"error": "invalid role"})
+	h.mockResponse("GET", "/organization/projects/proj_b/users", 404, map[string]string{"error": "no such project"})
+
+	results, err := Onboard(h.client, onboardingRows, OnboardingOptions{})
+	if err != nil {
+		t.Fatalf("Onboard() error = %v", err)
+	}
+	if results[0].Status != OnboardingStatusFailed || !strings.Contains(results[0].Errors[0], "invite as reader") {
+		t.Errorf("expected invite failure, got %+v", results[0])
+	}
+	if results[2].Status != OnboardingStatusFailed || len(results[2].Errors) != 1 || len(results[2].Actions) != 2 {
+		t.Errorf("expected one failure and the other changes applied, got %+v", results[2])
+	}
+}
@@ -0,0 +1,38 @@
+package openaiorgs
+
+// ListAll pages through a cursor-paginated list endpoint and returns every item.
+// The fetch function is called with the cursor of the previous page, starting with
+// an empty string for the first page, until the API reports that no more results
+// are available.
+//
+// Example:
+//
+//	users, err := openaiorgs.ListAll(func(after string) (*openaiorgs.ListResponse[openaiorgs.User], error) {
+//		return client.ListUsers(100, after)
+//	})
+func ListAll[T any](fetch func(after string) (*ListResponse[T], error)) ([]T, error) {
+	var all []T
+	after := ""
+	for {
+		page, err := fetch(after)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page.Data...)
+		// Stop on the last page, and guard against APIs that repeat the same cursor.
+		if !page.HasMore || page.LastID == "" || page.LastID == after {
+			return all, nil
+		}
+		after = page.LastID
+	}
+}
+
+// ListAllAuditLogs returns every audit log matching params, following pagination
+// cursors until the result set is exhausted. Any After cursor in params is ignored.
+func ListAllAuditLogs(c OpenAIOrgsClient, params AuditLogListParams) ([]AuditLog, error) {
+	return ListAll(func(after string) (*ListResponse[AuditLog], error) {
+		p := params
+		p.After = after
+		return c.ListAuditLogs(&p)
+	})
+}
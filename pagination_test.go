@@ -0,0 +1,85 @@
+package openaiorgs
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestListAll(t *testing.T) {
+	pages := map[string]*ListResponse[string]{
+		"":  {Data: []string{"a", "b"}, LastID: "b", HasMore: true},
+		"b": {Data: []string{"c"}, LastID: "c", HasMore: false},
+	}
+
+	var cursors []string
+	items, err := ListAll(func(after string) (*ListResponse[string], error) {
+		cursors = append(cursors, after)
+		return pages[after], nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 3 || items[2] != "c" {
+		t.Errorf("expected [a b c], got %v", items)
+	}
+	if len(cursors) != 2 || cursors[1] != "b" {
+		t.Errorf("expected cursors [\"\" b], got %v", cursors)
+	}
+}
+
+func TestListAll_StopsOnRepeatedCursor(t *testing.T) {
+	calls := 0
+	items, err := ListAll(func(after string) (*ListResponse[string], error) {
+		calls++
+		return &ListResponse[string]{Data: []string{"x"}, LastID: "x", HasMore: true}, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 2 || len(items) != 2 {
+		t.Errorf("expected 2 calls and 2 items, got %d calls and %v", calls, items)
+	}
+}
+
+func TestListAll_Error(t *testing.T) {
+	_, err := ListAll(func(after string) (*ListResponse[string], error) {
+		return nil, errors.New("boom")
+	})
+	if err == nil || err.Error() != "boom" {
+		t.Errorf("expected boom error, got %v", err)
+	}
+}
+
+func TestListAllAuditLogs(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+
+	var afters []string
+	httpmock.RegisterResponder("GET", testBaseURL+AuditLogsListEndpoint,
+		func(req *http.Request) (*http.Response, error) {
+			after := req.URL.Query().Get("after")
+			afters = append(afters, after)
+			if after == "" {
+				return httpmock.NewJsonResponse(200, ListResponse[AuditLog]{
+					Object: "list", Data: []AuditLog{{ID: "log_1", Type: "login.succeeded"}}, LastID: "log_1", HasMore: true,
+				})
+			}
+			return httpmock.NewJsonResponse(200, ListResponse[AuditLog]{
+				Object: "list", Data: []AuditLog{{ID: "log_2", Type: "logout.succeeded"}}, LastID: "log_2",
+			})
+		})
+
+	logs, err := ListAllAuditLogs(h.client, AuditLogListParams{ResourceIDs: []string{"proj_1"}, After: "ignored"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(logs) != 2 || logs[1].ID != "log_2" {
+		t.Errorf("expected two logs ending in log_2, got %+v", logs)
+	}
+	if len(afters) != 2 || afters[0] != "" || afters[1] != "log_1" {
+		t.Errorf("unexpected cursors: %v", afters)
+	}
+}
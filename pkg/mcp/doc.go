@@ -29,6 +29,16 @@ Resource data is returned in specialized MIME types for proper content handling:
 	application/vnd.openai-orgs.member-list+json
 	application/vnd.openai-orgs.usage+json
 
+Resource templates expose individual records:
+
+	openai-orgs://project/{id}                                      - A single project
+	openai-orgs://project/{id}/summary                              - A project with its users, service accounts,
+	                                                                  API keys, rate limits, active certificates,
+	                                                                  and 30-day costs and token usage
+	openai-orgs://project/{id}/service-account/{serviceAccountID}   - A project service account
+	openai-orgs://members/{id}                                      - An organization member
+	openai-orgs://usage/{id}                                        - Usage statistics for a project
+
 # Tools
 
 The package provides a comprehensive set of tools for direct organization management operations, including:
@@ -37,10 +47,18 @@ The package provides a comprehensive set of tools for direct organization manage
 - Project user management: list_project_users, add_project_user, remove_project_user, retrieve_project_user, modify_project_user
 - Project API key management: list_project_api_keys, retrieve_project_api_key, delete_project_api_key
 - Project service account management: list_project_service_accounts, create_project_service_account, retrieve_project_service_account, delete_project_service_account
-- User management: list_users, retrieve_user, delete_user, modify_user_role
-- Invite management: list_invites, create_invite, retrieve_invite, delete_invite
+- Project rate limit management: list_project_rate_limits, modify_project_rate_limit
+- User management: list_users, retrieve_user, delete_user, modify_user_role, describe_user
+- Invite management: list_invites, create_invite, retrieve_invite, delete_invite, prune_invites, resend_expired_invites, list_expiring_invites
+- Certificate management: list_expiring_certificates, rotate_certificate
+- Search: search
 - Usage and billing statistics: get_usage
 
+create_project_service_account accepts a secretSink parameter (file, dotenv, k8s-secret or vault) so the
+new API key is stored rather than returned; without one the key is discarded. The vault sink reads VAULT_ADDR,
+VAULT_TOKEN and VAULT_NAMESPACE from the server's environment. The file-based sinks are disabled unless
+OPENAI_ORGS_MCP_SECRET_DIR is set, and then only write to relative paths inside that directory.
+
 All tools are implemented using a generic handler and parameter schema pattern, ensuring consistent parameter validation, error handling, and testability. Parameters are registered with mcp.NewTool using type helpers (e.g., mcp.WithString, mcp.WithNumber, mcp.WithBoolean), making them visible and enforced in the MCP Inspector and compatible clients.
 
 # Tool Implementation Framework
@@ -16,11 +16,13 @@ const (
 	ResourceTemplateTypeMember                = "member"
 	ResourceTemplateTypeUsage                 = "usage"
 	ResourceTemplateTypeProjectServiceAccount = "project-service-account"
+	ResourceTemplateTypeProjectSummary        = "project-summary"
 
 	MIMETypeProjectTemplate               = "application/vnd.openai-orgs.project+json"
 	MIMETypeMemberTemplate                = "application/vnd.openai-orgs.member+json"
 	MIMETypeUsageTemplate                 = "application/vnd.openai-orgs.usage+json"
 	MIMETypeProjectServiceAccountTemplate = "application/vnd.openai-orgs.project-service-account+json"
+	MIMETypeProjectSummaryTemplate        = "application/vnd.openai-orgs.project-summary+json"
 )
 
 // templateHandler is a generic handler for resource templates
@@ -63,6 +65,13 @@ func AddResourceTemplates(s *server.MCPServer) {
 			mimeType: MIMETypeProjectServiceAccountTemplate,
 			handler:  handleProjectServiceAccount,
 		},
+		{
+			path:     "openai-orgs://project/{id}/summary",
+			name:     "Project Summary",
+			desc:     "OpenAI project with its users, service accounts, API keys, rate limits, active certificates, and 30-day costs and token usage",
+			mimeType: MIMETypeProjectSummaryTemplate,
+			handler:  handleProjectSummary,
+		},
 	}
 
 	for _, t := range templates {
@@ -127,6 +136,10 @@ func handleProjectServiceAccount(_ context.Context, client *openaiorgs.Client, u
 	return client.RetrieveProjectServiceAccount(uri.ProjectID, uri.ServiceAccount)
 }
 
+func handleProjectSummary(_ context.Context, client *openaiorgs.Client, uri *ResourceURI) (any, error) {
+	return openaiorgs.DescribeProject(client, uri.ProjectID, openaiorgs.ProjectSummaryOptions{})
+}
+
 func handleUsage(_ context.Context, client *openaiorgs.Client, uri *ResourceURI) (any, error) {
 	usageData := make(map[string]any)
 	params := map[string]string{
@@ -82,3 +82,27 @@ func optionalIntFromFloat(params map[string]any, key string) (int, bool, error)
 	}
 	return int(f), true, nil
 }
+
+// optionalInt64FromFloat safely extracts an optional non-negative int64 from a float64
+// parameter. Unlike optionalIntFromFloat it accepts values above math.MaxInt32, up to
+// the largest integer a float64 represents exactly.
+func optionalInt64FromFloat(params map[string]any, key string) (int64, bool, error) {
+	v, ok := params[key]
+	if !ok {
+		return 0, false, nil
+	}
+	f, ok := v.(float64)
+	if !ok {
+		return 0, false, fmt.Errorf("parameter '%s' must be a number, got %T", key, v)
+	}
+	if f < 0 {
+		return 0, false, fmt.Errorf("parameter '%s' must be non-negative, got %v", key, f)
+	}
+	if f != math.Trunc(f) {
+		return 0, false, fmt.Errorf("parameter '%s' must be a whole number, got %v", key, f)
+	}
+	if f > 1<<53 {
+		return 0, false, fmt.Errorf("parameter '%s' value %v exceeds maximum", key, f)
+	}
+	return int64(f), true, nil
+}
@@ -232,3 +232,22 @@ func TestOptionalIntFromFloat(t *testing.T) {
 		}
 	})
 }
+
+func TestOptionalInt64FromFloat(t *testing.T) {
+	params := map[string]any{"big": float64(5_000_000_000), "zero": float64(0), "neg": float64(-1), "frac": 1.5}
+
+	if val, ok, err := optionalInt64FromFloat(params, "big"); err != nil || !ok || val != 5_000_000_000 {
+		t.Errorf("big: got %d, %v, %v", val, ok, err)
+	}
+	if val, ok, err := optionalInt64FromFloat(params, "zero"); err != nil || !ok || val != 0 {
+		t.Errorf("zero: got %d, %v, %v", val, ok, err)
+	}
+	if _, ok, err := optionalInt64FromFloat(params, "missing"); err != nil || ok {
+		t.Errorf("missing: got %v, %v", ok, err)
+	}
+	for _, key := range []string{"neg", "frac"} {
+		if _, _, err := optionalInt64FromFloat(params, key); err == nil {
+			t.Errorf("%s: expected error", key)
+		}
+	}
+}
@@ -2,9 +2,14 @@ package mcp
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"reflect"
+	"slices"
+	"strings"
+	"time"
 
 	openaiorgs "github.com/klauern/openai-orgs"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -667,14 +672,26 @@ func AddTools(s *server.MCPServer) {
 			Fields: []ParamField{
 				{Name: "projectId", Required: true, Type: reflect.String, Description: "Project ID"},
 				{Name: "name", Required: true, Type: reflect.String, Description: "Service account name"},
+				{Name: "secretSink", Required: false, Type: reflect.String, Description: "Where to store the new API key (file, dotenv, k8s-secret, vault)"},
+				{Name: "secretPath", Required: false, Type: reflect.String, Description: "File path relative to the server's secret directory, or secret path within the Vault mount"},
+				{Name: "secretKey", Required: false, Type: reflect.String, Description: "Variable name, data key or Vault field for the key"},
+				{Name: "secretName", Required: false, Type: reflect.String, Description: "Kubernetes Secret name"},
+				{Name: "secretNamespace", Required: false, Type: reflect.String, Description: "Kubernetes namespace for the Secret"},
+				{Name: "vaultMount", Required: false, Type: reflect.String, Description: "Vault KV v2 mount path"},
 			},
 		}
 		s.AddTool(
 			mcp.NewTool(
 				"create_project_service_account",
-				mcp.WithDescription("Creates a new service account for a project"),
+				mcp.WithDescription("Creates a new service account for a project. The new API key is never returned: use secretSink to store it, otherwise it is discarded. The vault sink reads VAULT_ADDR, VAULT_TOKEN and VAULT_NAMESPACE from the server's environment; file, dotenv and k8s-secret only write inside the directory named by "+SecretDirEnv),
 				mcp.WithString("projectId", mcp.Required(), mcp.Description("Project ID")),
 				mcp.WithString("name", mcp.Required(), mcp.Description("Service account name")),
+				mcp.WithString("secretSink", mcp.Description("Where to store the new API key (file, dotenv, k8s-secret, vault)")),
+				mcp.WithString("secretPath", mcp.Description("File path relative to the server's secret directory, or secret path within the Vault mount")),
+				mcp.WithString("secretKey", mcp.Description("Variable name, data key or Vault field for the key (default OPENAI_API_KEY)")),
+				mcp.WithString("secretName", mcp.Description("Kubernetes Secret name (default: derived from the account name)")),
+				mcp.WithString("secretNamespace", mcp.Description("Kubernetes namespace for the Secret")),
+				mcp.WithString("vaultMount", mcp.Description("Vault KV v2 mount path (default secret)")),
 			),
 			GenericToolHandler(
 				func(ctx context.Context, client *openaiorgs.Client, params map[string]any) (any, error) {
@@ -686,11 +703,29 @@ func AddTools(s *server.MCPServer) {
 					if err != nil {
 						return nil, err
 					}
+					sink, err := secretSinkFromParams(params)
+					if err != nil {
+						return nil, err
+					}
 					account, err := client.CreateProjectServiceAccount(projectID, name)
 					if err != nil {
 						return nil, fmt.Errorf("failed to create project service account: %w", err)
 					}
-					return account.String(), nil
+					if account.APIKey == nil || account.APIKey.Value == "" {
+						return account.String(), nil
+					}
+					if sink == nil {
+						return account.String() + "\nAPI Key: discarded (no secretSink given)", nil
+					}
+					ref, err := sink.Store(openaiorgs.Secret{
+						Name:  account.Name,
+						ID:    account.APIKey.ID,
+						Value: account.APIKey.Value,
+					})
+					if err != nil {
+						return nil, fmt.Errorf("service account %s was created but its API key could not be stored: %w", account.ID, err)
+					}
+					return fmt.Sprintf("%s\nAPI Key: %s stored in %s", account.String(), openaiorgs.RedactSecret(account.APIKey.Value), ref), nil
 				},
 				schema,
 			),
@@ -732,6 +767,119 @@ func AddTools(s *server.MCPServer) {
 		)
 	}
 
+	// --- Project Rate Limits ---
+	{
+		schema := ParamSchema{
+			Fields: []ParamField{
+				{Name: "projectId", Required: true, Type: reflect.String, Description: "Project ID"},
+				{Name: "limit", Required: false, Type: reflect.Float64, Description: "Maximum number of rate limits to return"},
+				{Name: "after", Required: false, Type: reflect.String, Description: "Rate limit ID to start after (for pagination)"},
+			},
+		}
+		s.AddTool(
+			mcp.NewTool(
+				"list_project_rate_limits",
+				mcp.WithDescription("Lists the per-model rate limits of a project"),
+				mcp.WithString("projectId", mcp.Required(), mcp.Description("Project ID")),
+				mcp.WithNumber("limit", mcp.Description("Maximum number of rate limits to return")),
+				mcp.WithString("after", mcp.Description("Rate limit ID to start after (for pagination)")),
+			),
+			GenericToolHandler(
+				func(ctx context.Context, client *openaiorgs.Client, params map[string]any) (any, error) {
+					projectID, err := requireString(params, "projectId")
+					if err != nil {
+						return nil, err
+					}
+					limit := 0
+					if v, ok, err := optionalIntFromFloat(params, "limit"); err != nil {
+						return nil, err
+					} else if ok {
+						limit = v
+					}
+					after := ""
+					if v, ok, err := optionalString(params, "after"); err != nil {
+						return nil, err
+					} else if ok {
+						after = v
+					}
+					limits, err := client.ListProjectRateLimits(limit, after, projectID)
+					if err != nil {
+						return nil, fmt.Errorf("failed to list project rate limits: %w", err)
+					}
+					return limits.String(), nil
+				},
+				schema,
+			),
+		)
+	}
+
+	{
+		schema := ParamSchema{
+			Fields: []ParamField{
+				{Name: "projectId", Required: true, Type: reflect.String, Description: "Project ID"},
+				{Name: "rateLimitId", Required: true, Type: reflect.String, Description: "Rate limit ID"},
+				{Name: "maxRequestsPer1Minute", Required: false, Type: reflect.Float64, Description: "New maximum requests per minute"},
+				{Name: "maxTokensPer1Minute", Required: false, Type: reflect.Float64, Description: "New maximum tokens per minute"},
+				{Name: "maxImagesPer1Minute", Required: false, Type: reflect.Float64, Description: "New maximum images per minute"},
+				{Name: "maxAudioMegabytesPer1Minute", Required: false, Type: reflect.Float64, Description: "New maximum audio megabytes per minute"},
+				{Name: "maxRequestsPer1Day", Required: false, Type: reflect.Float64, Description: "New maximum requests per day"},
+				{Name: "batch1DayMaxInputTokens", Required: false, Type: reflect.Float64, Description: "New maximum batch input tokens per day"},
+			},
+		}
+		s.AddTool(
+			mcp.NewTool(
+				"modify_project_rate_limit",
+				mcp.WithDescription("Modifies a project rate limit. Only the limits that are given are changed; zero is a valid value"),
+				mcp.WithString("projectId", mcp.Required(), mcp.Description("Project ID")),
+				mcp.WithString("rateLimitId", mcp.Required(), mcp.Description("Rate limit ID")),
+				mcp.WithNumber("maxRequestsPer1Minute", mcp.Description("New maximum requests per minute")),
+				mcp.WithNumber("maxTokensPer1Minute", mcp.Description("New maximum tokens per minute")),
+				mcp.WithNumber("maxImagesPer1Minute", mcp.Description("New maximum images per minute")),
+				mcp.WithNumber("maxAudioMegabytesPer1Minute", mcp.Description("New maximum audio megabytes per minute")),
+				mcp.WithNumber("maxRequestsPer1Day", mcp.Description("New maximum requests per day")),
+				mcp.WithNumber("batch1DayMaxInputTokens", mcp.Description("New maximum batch input tokens per day")),
+			),
+			GenericToolHandler(
+				func(ctx context.Context, client *openaiorgs.Client, params map[string]any) (any, error) {
+					projectID, err := requireString(params, "projectId")
+					if err != nil {
+						return nil, err
+					}
+					rateLimitID, err := requireString(params, "rateLimitId")
+					if err != nil {
+						return nil, err
+					}
+
+					var fields openaiorgs.ProjectRateLimitRequestFields
+					for key, field := range map[string]**int64{
+						"maxRequestsPer1Minute":       &fields.MaxRequestsPer1Minute,
+						"maxTokensPer1Minute":         &fields.MaxTokensPer1Minute,
+						"maxImagesPer1Minute":         &fields.MaxImagesPer1Minute,
+						"maxAudioMegabytesPer1Minute": &fields.MaxAudioMegabytesPer1Minute,
+						"maxRequestsPer1Day":          &fields.MaxRequestsPer1Day,
+						"batch1DayMaxInputTokens":     &fields.Batch1DayMaxInputTokens,
+					} {
+						if v, ok, err := optionalInt64FromFloat(params, key); err != nil {
+							return nil, err
+						} else if ok {
+							*field = openaiorgs.Int64Ptr(v)
+						}
+					}
+					if err := fields.Validate(); err != nil {
+						return nil, err
+					}
+
+					rateLimit, err := client.ModifyProjectRateLimit(projectID, rateLimitID, fields)
+					if err != nil {
+						return nil, fmt.Errorf("failed to modify project rate limit: %w", err)
+					}
+					return rateLimit.String(), nil
+				},
+				schema,
+			),
+		)
+	}
+
 	// --- User Management ---
 	{
 		schema := ParamSchema{
@@ -852,6 +1000,53 @@ func AddTools(s *server.MCPServer) {
 		),
 	)
 
+	s.AddTool(
+		mcp.NewTool(
+			"describe_user",
+			mcp.WithDescription("Describes one user across the organization: organization role and join date, project memberships and roles, project API keys they own, recent audit events they performed, and their usage per project. Provide exactly one of userId or email"),
+			mcp.WithString("userId", mcp.Description("User ID")),
+			mcp.WithString("email", mcp.Description("User email address (case-insensitive)")),
+			mcp.WithNumber("days", mcp.Description("Days of audit events and usage to include (default 30)")),
+		),
+		GenericToolHandler(
+			func(ctx context.Context, client *openaiorgs.Client, params map[string]any) (any, error) {
+				userID, _, err := optionalString(params, "userId")
+				if err != nil {
+					return nil, err
+				}
+				email, _, err := optionalString(params, "email")
+				if err != nil {
+					return nil, err
+				}
+				if (userID == "") == (email == "") {
+					return nil, fmt.Errorf("exactly one of userId or email is required")
+				}
+				opts := openaiorgs.UserDescriptionOptions{}
+				if v, ok, err := optionalIntFromFloat(params, "days"); err != nil {
+					return nil, err
+				} else if ok {
+					opts.Window = time.Duration(v) * 24 * time.Hour
+				}
+				identifier := userID
+				if email != "" {
+					identifier = email
+				}
+				description, err := openaiorgs.DescribeUser(client, identifier, opts)
+				if err != nil {
+					return nil, fmt.Errorf("failed to describe user: %w", err)
+				}
+				return jsonResult(description)
+			},
+			ParamSchema{
+				Fields: []ParamField{
+					{Name: "userId", Required: false, Type: reflect.String, Description: "User ID"},
+					{Name: "email", Required: false, Type: reflect.String, Description: "User email address"},
+					{Name: "days", Required: false, Type: reflect.Float64, Description: "Days of audit events and usage"},
+				},
+			},
+		),
+	)
+
 	// --- Invites ---
 	{
 		schema := ParamSchema{
@@ -896,6 +1091,7 @@ func AddTools(s *server.MCPServer) {
 		mcp.NewTool(
 			"create_invite",
 			mcp.WithDescription("Creates a new invite for a user to join the organization"),
+			mcp.WithString("projects", mcp.Description("Projects to join on acceptance, as project_id:role pairs separated by commas")),
 		),
 		GenericToolHandler(
 			func(ctx context.Context, client *openaiorgs.Client, params map[string]any) (any, error) {
@@ -907,7 +1103,16 @@ func AddTools(s *server.MCPServer) {
 				if err != nil {
 					return nil, err
 				}
-				invite, err := client.CreateInvite(email, role)
+				var projects []openaiorgs.ProjectAssignment
+				if v, ok, err := optionalString(params, "projects"); err != nil {
+					return nil, err
+				} else if ok {
+					projects, err = openaiorgs.ParseProjectAssignments(v)
+					if err != nil {
+						return nil, err
+					}
+				}
+				invite, err := client.CreateInviteWithProjects(email, role, projects)
 				if err != nil {
 					return nil, fmt.Errorf("failed to create invite: %w", err)
 				}
@@ -917,6 +1122,7 @@ func AddTools(s *server.MCPServer) {
 				Fields: []ParamField{
 					{Name: "email", Required: true, Type: reflect.String, Description: "Email address to invite"},
 					{Name: "role", Required: true, Type: reflect.String, Description: "Role for the invited user (e.g., owner, member)"},
+					{Name: "projects", Required: false, Type: reflect.String, Description: "Comma-separated project_id:role pairs"},
 				},
 			},
 		),
@@ -972,6 +1178,221 @@ func AddTools(s *server.MCPServer) {
 		),
 	)
 
+	s.AddTool(
+		mcp.NewTool(
+			"prune_invites",
+			mcp.WithDescription("Deletes expired invites, and optionally pending invites older than a given number of days"),
+			mcp.WithNumber("olderThanDays", mcp.Description("Also delete pending invites created more than this many days ago")),
+			mcp.WithBoolean("dryRun", mcp.Description("If true, only report the invites that would be deleted")),
+		),
+		GenericToolHandler(
+			func(ctx context.Context, client *openaiorgs.Client, params map[string]any) (any, error) {
+				opts := openaiorgs.InviteLifecycleOptions{}
+				if v, ok, err := optionalIntFromFloat(params, "olderThanDays"); err != nil {
+					return nil, err
+				} else if ok {
+					opts.OlderThan = time.Duration(v) * 24 * time.Hour
+				}
+				if v, ok, err := optionalBool(params, "dryRun"); err != nil {
+					return nil, err
+				} else if ok {
+					opts.DryRun = v
+				}
+				actions, err := openaiorgs.PruneInvites(client, opts)
+				if err != nil {
+					return nil, fmt.Errorf("failed to prune invites: %w", err)
+				}
+				return jsonResult(actions)
+			},
+			ParamSchema{
+				Fields: []ParamField{
+					{Name: "olderThanDays", Required: false, Type: reflect.Float64, Description: "Minimum age in days of pending invites to delete"},
+					{Name: "dryRun", Required: false, Type: reflect.Bool, Description: "Only report planned deletions"},
+				},
+			},
+		),
+	)
+
+	s.AddTool(
+		mcp.NewTool(
+			"resend_expired_invites",
+			mcp.WithDescription("Recreates expired invites with the same email and role"),
+			mcp.WithBoolean("dryRun", mcp.Description("If true, only report the invites that would be resent")),
+		),
+		GenericToolHandler(
+			func(ctx context.Context, client *openaiorgs.Client, params map[string]any) (any, error) {
+				opts := openaiorgs.InviteLifecycleOptions{}
+				if v, ok, err := optionalBool(params, "dryRun"); err != nil {
+					return nil, err
+				} else if ok {
+					opts.DryRun = v
+				}
+				actions, err := openaiorgs.ResendExpiredInvites(client, opts)
+				if err != nil {
+					return nil, fmt.Errorf("failed to resend invites: %w", err)
+				}
+				return jsonResult(actions)
+			},
+			ParamSchema{
+				Fields: []ParamField{
+					{Name: "dryRun", Required: false, Type: reflect.Bool, Description: "Only report planned resends"},
+				},
+			},
+		),
+	)
+
+	s.AddTool(
+		mcp.NewTool(
+			"list_expiring_invites",
+			mcp.WithDescription("Lists pending invites that expire soon, soonest first"),
+			mcp.WithNumber("withinHours", mcp.Description("Time window to look ahead in hours (default 48)")),
+		),
+		GenericToolHandler(
+			func(ctx context.Context, client *openaiorgs.Client, params map[string]any) (any, error) {
+				within := 48 * time.Hour
+				if v, ok, err := optionalIntFromFloat(params, "withinHours"); err != nil {
+					return nil, err
+				} else if ok {
+					within = time.Duration(v) * time.Hour
+				}
+				invites, err := openaiorgs.ExpiringInvites(client, within, time.Time{})
+				if err != nil {
+					return nil, fmt.Errorf("failed to list expiring invites: %w", err)
+				}
+				return jsonResult(invites)
+			},
+			ParamSchema{
+				Fields: []ParamField{
+					{Name: "withinHours", Required: false, Type: reflect.Float64, Description: "Look-ahead window in hours"},
+				},
+			},
+		),
+	)
+
+	// --- Certificates ---
+	s.AddTool(
+		mcp.NewTool(
+			"list_expiring_certificates",
+			mcp.WithDescription("Lists organization certificates that expire soon or have already expired, soonest first"),
+			mcp.WithNumber("withinDays", mcp.Description("Time window to look ahead in days (default 30)")),
+		),
+		GenericToolHandler(
+			func(ctx context.Context, client *openaiorgs.Client, params map[string]any) (any, error) {
+				within := 30 * 24 * time.Hour
+				if v, ok, err := optionalIntFromFloat(params, "withinDays"); err != nil {
+					return nil, err
+				} else if ok {
+					within = time.Duration(v) * 24 * time.Hour
+				}
+				certificates, err := openaiorgs.ExpiringCertificates(client, within, time.Time{})
+				if err != nil {
+					return nil, fmt.Errorf("failed to list expiring certificates: %w", err)
+				}
+				return jsonResult(certificates)
+			},
+			ParamSchema{
+				Fields: []ParamField{
+					{Name: "withinDays", Required: false, Type: reflect.Float64, Description: "Look-ahead window in days"},
+				},
+			},
+		),
+	)
+
+	{
+		schema := ParamSchema{
+			Fields: []ParamField{
+				{Name: "oldCertificateId", Required: true, Type: reflect.String, Description: "ID of the certificate to replace"},
+				{Name: "content", Required: true, Type: reflect.String, Description: "PEM-encoded replacement certificate"},
+				{Name: "name", Required: false, Type: reflect.String, Description: "Name of the new certificate"},
+				{Name: "allowLeaf", Required: false, Type: reflect.Bool, Description: "Allow a replacement that is not a CA certificate"},
+				{Name: "dryRun", Required: false, Type: reflect.Bool, Description: "Only report the planned steps"},
+			},
+		}
+		s.AddTool(
+			mcp.NewTool(
+				"rotate_certificate",
+				mcp.WithDescription("Uploads a replacement certificate, activates it at the organization level and in every project where the old certificate is active, then deactivates the old certificate. Completed steps are rolled back if any step fails"),
+				mcp.WithString("oldCertificateId", mcp.Required(), mcp.Description("ID of the certificate to replace")),
+				mcp.WithString("content", mcp.Required(), mcp.Description("PEM-encoded replacement certificate")),
+				mcp.WithString("name", mcp.Description("Name of the new certificate (default: the old name followed by today's date)")),
+				mcp.WithBoolean("allowLeaf", mcp.Description("If true, allow a replacement that is not a CA certificate")),
+				mcp.WithBoolean("dryRun", mcp.Description("If true, only report the planned steps")),
+			),
+			GenericToolHandler(
+				func(ctx context.Context, client *openaiorgs.Client, params map[string]any) (any, error) {
+					oldID, err := requireString(params, "oldCertificateId")
+					if err != nil {
+						return nil, err
+					}
+					content, err := requireString(params, "content")
+					if err != nil {
+						return nil, err
+					}
+					opts := openaiorgs.CertificateRotationOptions{}
+					if v, ok, err := optionalString(params, "name"); err != nil {
+						return nil, err
+					} else if ok {
+						opts.Name = v
+					}
+					if v, ok, err := optionalBool(params, "allowLeaf"); err != nil {
+						return nil, err
+					} else if ok {
+						opts.AllowLeaf = v
+					}
+					if v, ok, err := optionalBool(params, "dryRun"); err != nil {
+						return nil, err
+					} else if ok {
+						opts.DryRun = v
+					}
+					rotation, err := openaiorgs.RotateCertificate(client, oldID, content, opts)
+					if err != nil {
+						if rotation != nil {
+							return nil, fmt.Errorf("failed to rotate certificate (%s): %w", rotation.Status, err)
+						}
+						return nil, fmt.Errorf("failed to rotate certificate: %w", err)
+					}
+					return jsonResult(rotation)
+				},
+				schema,
+			),
+		)
+	}
+
+	// --- Search ---
+	s.AddTool(
+		mcp.NewTool(
+			"search",
+			mcp.WithDescription("Searches projects, users, invites, admin API keys, certificates, and every project's users, service accounts and API keys for a case-insensitive fragment of an ID, name, email or redacted key value. Returns the kind, ID, matched field and parent project of each match"),
+			mcp.WithString("term", mcp.Required(), mcp.Description("Fragment to search for, such as part of an email or the visible suffix of a key")),
+			mcp.WithBoolean("includeArchived", mcp.Description("Also search archived projects")),
+		),
+		GenericToolHandler(
+			func(ctx context.Context, client *openaiorgs.Client, params map[string]any) (any, error) {
+				term, err := requireString(params, "term")
+				if err != nil {
+					return nil, err
+				}
+				opts := openaiorgs.SearchOptions{}
+				if v, ok, err := optionalBool(params, "includeArchived"); err != nil {
+					return nil, err
+				} else if ok {
+					opts.IncludeArchived = v
+				}
+				matches, err := openaiorgs.Search(client, term, opts)
+				if err != nil {
+					return nil, fmt.Errorf("failed to search: %w", err)
+				}
+				return jsonResult(matches)
+			},
+			ParamSchema{
+				Fields: []ParamField{
+					{Name: "term", Required: true, Type: reflect.String, Description: "Fragment to search for"},
+					{Name: "includeArchived", Required: false, Type: reflect.Bool, Description: "Also search archived projects"},
+				},
+			},
+		),
+	)
+
 	// --- Usage/Billing ---
 	{
 		schema := ParamSchema{
@@ -1138,3 +1559,68 @@ func AddTools(s *server.MCPServer) {
 		),
 	)
 }
+
+// jsonResult renders v as indented JSON for tools whose results have no String method.
+func jsonResult(v any) (string, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal result: %w", err)
+	}
+	return string(data), nil
+}
+
+// SecretDirEnv names the environment variable holding the directory that the file,
+// dotenv and k8s-secret sinks may write to from tool calls. When it is unset, only
+// the vault sink is available over MCP.
+const SecretDirEnv = "OPENAI_ORGS_MCP_SECRET_DIR"
+
+// secretSinkFromParams builds the sink selected with the secretSink parameter, or
+// returns nil if none was selected. Vault credentials come from the environment so
+// they never pass through tool parameters, and file paths are confined to SecretDirEnv.
+func secretSinkFromParams(params map[string]any) (openaiorgs.SecretSink, error) {
+	kind, _, err := optionalString(params, "secretSink")
+	if err != nil || kind == "" {
+		return nil, err
+	}
+	cfg := openaiorgs.SecretSinkConfig{
+		Kind:           kind,
+		VaultAddress:   os.Getenv("VAULT_ADDR"),
+		VaultToken:     os.Getenv("VAULT_TOKEN"),
+		VaultNamespace: os.Getenv("VAULT_NAMESPACE"),
+	}
+	for key, field := range map[string]*string{
+		"secretPath":      &cfg.Path,
+		"secretKey":       &cfg.Key,
+		"secretName":      &cfg.SecretName,
+		"secretNamespace": &cfg.Namespace,
+		"vaultMount":      &cfg.VaultMount,
+	} {
+		if *field, _, err = optionalString(params, key); err != nil {
+			return nil, err
+		}
+	}
+	switch kind {
+	case openaiorgs.SecretSinkFile, openaiorgs.SecretSinkDotenv, openaiorgs.SecretSinkKubernetes:
+		if cfg.Path, err = confineSecretPath(cfg.Path); err != nil {
+			return nil, err
+		}
+	}
+	sink, err := openaiorgs.NewSecretSink(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid secret sink: %w", err)
+	}
+	return sink, nil
+}
+
+// confineSecretPath resolves a tool-supplied secretPath inside the directory named by
+// SecretDirEnv, so that a tool call cannot create or overwrite files anywhere else.
+func confineSecretPath(path string) (string, error) {
+	dir := os.Getenv(SecretDirEnv)
+	if dir == "" {
+		return "", fmt.Errorf("file-based secret sinks are disabled; set %s on the server or use the vault sink", SecretDirEnv)
+	}
+	if !filepath.IsLocal(path) || slices.Contains(strings.Split(filepath.ToSlash(path), "/"), "..") {
+		return "", fmt.Errorf("secretPath must be a relative path within %s and must not contain \"..\"", SecretDirEnv)
+	}
+	return filepath.Join(dir, path), nil
+}
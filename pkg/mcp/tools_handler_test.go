@@ -3,6 +3,11 @@ package mcp
 import (
 	"context"
 	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/jarcoal/httpmock"
@@ -349,6 +354,126 @@ func TestToolHandler_CreateProjectServiceAccount(t *testing.T) {
 	assertToolSuccess(t, resp)
 }
 
+func TestToolHandler_CreateProjectServiceAccount_SecretSink(t *testing.T) {
+	_, cleanup := newToolTestClient(t)
+	defer cleanup()
+
+	httpmock.RegisterResponder("POST", "=~.*/organization/projects/proj-1/service_accounts$",
+		httpmock.NewJsonResponderOrPanic(200, map[string]any{
+			"object": "organization.project.service_account", "id": "sa-1", "name": "test-sa",
+			"api_key": map[string]any{"id": "key-1", "value": "sk-svcacct-0123456789"},
+		}))
+
+	dir := t.TempDir()
+	t.Setenv(SecretDirEnv, dir)
+	keyFile := filepath.Join(dir, "ci", ".env")
+	if err := os.Mkdir(filepath.Dir(keyFile), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	s, ctx := setupToolServer(t)
+	resp := callTool(t, s, ctx, "create_project_service_account", map[string]any{
+		"projectId": "proj-1", "name": "test-sa", "secretSink": "dotenv", "secretPath": "ci/.env",
+	})
+	assertToolSuccess(t, resp)
+
+	raw, _ := json.Marshal(resp)
+	if strings.Contains(string(raw), "sk-svcacct-0123456789") {
+		t.Errorf("API key must not be returned, got: %s", raw)
+	}
+	data, err := os.ReadFile(keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "OPENAI_API_KEY=\"sk-svcacct-0123456789\"\n" {
+		t.Errorf("unexpected dotenv contents %q", data)
+	}
+}
+
+func TestToolHandler_CreateProjectServiceAccount_InvalidSink(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name      string
+		secretDir string
+		sink      string
+		path      string
+	}{
+		{name: "unknown sink", secretDir: dir, sink: "clipboard", path: "key"},
+		{name: "no secret directory", sink: "file", path: "key"},
+		{name: "absolute path", secretDir: dir, sink: "file", path: filepath.Join(dir, "key")},
+		{name: "parent directory", secretDir: dir, sink: "dotenv", path: "../.env"},
+		{name: "parent directory inside path", secretDir: dir, sink: "k8s-secret", path: "a/../secret.yaml"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, cleanup := newToolTestClient(t)
+			defer cleanup()
+			t.Setenv(SecretDirEnv, tt.secretDir)
+
+			s, ctx := setupToolServer(t)
+			resp := callTool(t, s, ctx, "create_project_service_account", map[string]any{
+				"projectId": "proj-1", "name": "test-sa", "secretSink": tt.sink, "secretPath": tt.path,
+			})
+			if _, ok := resp.(mcp.JSONRPCError); !ok {
+				t.Fatalf("expected error response, got %T", resp)
+			}
+			if got := httpmock.GetTotalCallCount(); got != 0 {
+				t.Errorf("expected no API calls, got %d", got)
+			}
+		})
+	}
+}
+
+func TestToolHandler_ListProjectRateLimits(t *testing.T) {
+	_, cleanup := newToolTestClient(t)
+	defer cleanup()
+
+	httpmock.RegisterResponder("GET", "=~.*/organization/projects/proj-1/rate_limits",
+		httpmock.NewJsonResponderOrPanic(200, emptyListResponse))
+
+	s, ctx := setupToolServer(t)
+	resp := callTool(t, s, ctx, "list_project_rate_limits", map[string]any{"projectId": "proj-1"})
+	assertToolSuccess(t, resp)
+}
+
+func TestToolHandler_ModifyProjectRateLimit(t *testing.T) {
+	_, cleanup := newToolTestClient(t)
+	defer cleanup()
+
+	var body map[string]int64
+	httpmock.RegisterResponder("POST", "=~.*/organization/projects/proj-1/rate_limits/rl-1$",
+		func(req *http.Request) (*http.Response, error) {
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return httpmock.NewStringResponse(400, err.Error()), nil
+			}
+			return httpmock.NewJsonResponse(200, map[string]any{"object": "project.rate_limit", "id": "rl-1", "model": "gpt-4o"})
+		})
+
+	s, ctx := setupToolServer(t)
+	resp := callTool(t, s, ctx, "modify_project_rate_limit", map[string]any{
+		"projectId": "proj-1", "rateLimitId": "rl-1", "maxImagesPer1Minute": float64(0), "batch1DayMaxInputTokens": float64(5_000_000_000),
+	})
+	assertToolSuccess(t, resp)
+
+	want := map[string]int64{"max_images_per_1_minute": 0, "batch_1_day_max_input_tokens": 5_000_000_000}
+	if !reflect.DeepEqual(body, want) {
+		t.Errorf("request body = %v, want %v", body, want)
+	}
+}
+
+func TestToolHandler_ModifyProjectRateLimit_NoFields(t *testing.T) {
+	_, cleanup := newToolTestClient(t)
+	defer cleanup()
+
+	s, ctx := setupToolServer(t)
+	resp := callTool(t, s, ctx, "modify_project_rate_limit", map[string]any{"projectId": "proj-1", "rateLimitId": "rl-1"})
+	if _, ok := resp.(mcp.JSONRPCError); !ok {
+		t.Fatalf("expected error response, got %T", resp)
+	}
+	if got := httpmock.GetTotalCallCount(); got != 0 {
+		t.Errorf("expected no API calls, got %d", got)
+	}
+}
+
 func TestToolHandler_DeleteProjectServiceAccount(t *testing.T) {
 	_, cleanup := newToolTestClient(t)
 	defer cleanup()
@@ -461,6 +586,36 @@ func TestToolHandler_CreateInvite(t *testing.T) {
 	assertToolSuccess(t, resp)
 }
 
+func TestToolHandler_CreateInvite_WithProjects(t *testing.T) {
+	_, cleanup := newToolTestClient(t)
+	defer cleanup()
+
+	httpmock.RegisterResponder("POST", "=~.*/organization/invites$",
+		httpmock.NewJsonResponderOrPanic(200, map[string]any{
+			"object": "organization.invite", "id": "inv-1", "email": "test@example.com", "role": "reader",
+			"projects": []map[string]any{{"id": "proj-1", "role": "member"}},
+		}))
+
+	s, ctx := setupToolServer(t)
+	resp := callTool(t, s, ctx, "create_invite", map[string]any{
+		"email": "test@example.com", "role": "reader", "projects": "proj-1:member",
+	})
+	assertToolSuccess(t, resp)
+}
+
+func TestToolHandler_CreateInvite_InvalidProjects(t *testing.T) {
+	_, cleanup := newToolTestClient(t)
+	defer cleanup()
+
+	s, ctx := setupToolServer(t)
+	resp := callTool(t, s, ctx, "create_invite", map[string]any{
+		"email": "test@example.com", "role": "reader", "projects": "proj-1",
+	})
+	if _, ok := resp.(mcp.JSONRPCError); !ok {
+		t.Fatalf("expected error response for malformed projects, got %T", resp)
+	}
+}
+
 func TestToolHandler_RetrieveInvite(t *testing.T) {
 	_, cleanup := newToolTestClient(t)
 	defer cleanup()
@@ -487,6 +642,78 @@ func TestToolHandler_DeleteInvite(t *testing.T) {
 	assertToolSuccess(t, resp)
 }
 
+func TestToolHandler_PruneInvites(t *testing.T) {
+	_, cleanup := newToolTestClient(t)
+	defer cleanup()
+
+	httpmock.RegisterResponder("GET", "=~.*/organization/invites.*",
+		httpmock.NewJsonResponderOrPanic(200, map[string]any{
+			"object": "list",
+			"data": []map[string]any{
+				{"object": "organization.invite", "id": "inv-1", "email": "old@example.com", "role": "reader", "status": "expired", "created_at": 1700000000, "expires_at": 1700600000},
+			},
+		}))
+
+	s, ctx := setupToolServer(t)
+	resp := callTool(t, s, ctx, "prune_invites", map[string]any{"olderThanDays": float64(7), "dryRun": true})
+	assertToolSuccess(t, resp)
+	if got := httpmock.GetCallCountInfo()["DELETE =~.*/organization/invites/inv-1$"]; got != 0 {
+		t.Errorf("expected no deletes on dry run, got %d", got)
+	}
+}
+
+func TestToolHandler_ResendExpiredInvites(t *testing.T) {
+	_, cleanup := newToolTestClient(t)
+	defer cleanup()
+
+	httpmock.RegisterResponder("GET", "=~.*/organization/invites.*",
+		httpmock.NewJsonResponderOrPanic(200, emptyListResponse))
+
+	s, ctx := setupToolServer(t)
+	resp := callTool(t, s, ctx, "resend_expired_invites", map[string]any{})
+	assertToolSuccess(t, resp)
+}
+
+func TestToolHandler_ListExpiringInvites(t *testing.T) {
+	_, cleanup := newToolTestClient(t)
+	defer cleanup()
+
+	httpmock.RegisterResponder("GET", "=~.*/organization/invites.*",
+		httpmock.NewJsonResponderOrPanic(200, emptyListResponse))
+
+	s, ctx := setupToolServer(t)
+	resp := callTool(t, s, ctx, "list_expiring_invites", map[string]any{"withinHours": float64(24)})
+	assertToolSuccess(t, resp)
+}
+
+func TestToolHandler_ListExpiringCertificates(t *testing.T) {
+	_, cleanup := newToolTestClient(t)
+	defer cleanup()
+
+	httpmock.RegisterResponder("GET", "=~.*/organization/certificates.*",
+		httpmock.NewJsonResponderOrPanic(200, emptyListResponse))
+
+	s, ctx := setupToolServer(t)
+	resp := callTool(t, s, ctx, "list_expiring_certificates", map[string]any{"withinDays": float64(30)})
+	assertToolSuccess(t, resp)
+}
+
+func TestToolHandler_RotateCertificate_InvalidContent(t *testing.T) {
+	_, cleanup := newToolTestClient(t)
+	defer cleanup()
+
+	s, ctx := setupToolServer(t)
+	resp := callTool(t, s, ctx, "rotate_certificate", map[string]any{
+		"oldCertificateId": "cert-1", "content": "not a certificate", "dryRun": true,
+	})
+	if _, ok := resp.(mcp.JSONRPCError); !ok {
+		t.Fatalf("expected error response for invalid content, got %T", resp)
+	}
+	if n := httpmock.GetTotalCallCount(); n != 0 {
+		t.Errorf("expected no API calls, got %d", n)
+	}
+}
+
 func TestToolHandler_GetUsage_Completions(t *testing.T) {
 	_, cleanup := newToolTestClient(t)
 	defer cleanup()
@@ -642,3 +869,49 @@ func TestToolHandler_GetUsage_WithTimeParams(t *testing.T) {
 	})
 	assertToolSuccess(t, resp)
 }
+
+func TestToolHandler_DescribeUser(t *testing.T) {
+	_, cleanup := newToolTestClient(t)
+	defer cleanup()
+
+	httpmock.RegisterResponder("GET", "=~.*/organization/users$",
+		httpmock.NewJsonResponderOrPanic(200, map[string]any{
+			"object": "list", "data": []any{map[string]any{"id": "user-1", "email": "dev@example.com", "role": "owner"}},
+		}))
+	httpmock.RegisterResponder("GET", "=~.*/organization/projects$",
+		httpmock.NewJsonResponderOrPanic(200, emptyListResponse))
+	httpmock.RegisterResponder("GET", "=~.*/organization/audit_logs.*",
+		httpmock.NewJsonResponderOrPanic(200, emptyListResponse))
+	httpmock.RegisterResponder("GET", "=~.*/organization/usage/.*",
+		httpmock.NewJsonResponderOrPanic(200, map[string]any{"object": "page", "data": []any{}}))
+
+	s, ctx := setupToolServer(t)
+	resp := callTool(t, s, ctx, "describe_user", map[string]any{"email": "Dev@Example.com", "days": float64(7)})
+	assertToolSuccess(t, resp)
+
+	resp = callTool(t, s, ctx, "describe_user", map[string]any{})
+	if _, ok := resp.(mcp.JSONRPCError); !ok {
+		t.Fatalf("expected error response without userId or email, got %T", resp)
+	}
+}
+
+func TestToolHandler_Search(t *testing.T) {
+	_, cleanup := newToolTestClient(t)
+	defer cleanup()
+
+	httpmock.RegisterResponder("GET", "=~.*/organization/projects$",
+		httpmock.NewJsonResponderOrPanic(200, map[string]any{
+			"object": "list", "data": []any{map[string]any{"id": "proj-1", "name": "DevOps"}},
+		}))
+	httpmock.RegisterResponder("GET", "=~.*/organization/(users|invites|admin_api_keys|certificates)$",
+		httpmock.NewJsonResponderOrPanic(200, emptyListResponse))
+	httpmock.RegisterResponder("GET", "=~.*/organization/projects/proj-1/(users|service_accounts|api_keys)$",
+		httpmock.NewJsonResponderOrPanic(200, emptyListResponse))
+
+	s, ctx := setupToolServer(t)
+	resp := callTool(t, s, ctx, "search", map[string]any{"term": "ops"})
+	assertToolSuccess(t, resp)
+	if raw, _ := json.Marshal(resp); !strings.Contains(string(raw), "proj-1") {
+		t.Errorf("expected proj-1 in search results, got %s", raw)
+	}
+}
@@ -15,6 +15,8 @@ type ResourceURI struct {
 	ProjectID      string
 	ServiceAccount string
 	MemberID       string
+	// Summary is set for openai-orgs://project/{id}/summary.
+	Summary bool
 }
 
 // ParseURI parses a raw URI string into a structured ResourceURI
@@ -42,6 +44,9 @@ func ParseURI(uri string) (*ResourceURI, error) {
 		if len(parts) >= 4 && parts[2] == "service-account" {
 			r.ServiceAccount = parts[3]
 		}
+		if len(parts) == 3 && parts[2] == "summary" {
+			r.Summary = true
+		}
 	case "members":
 		if len(parts) < 2 || parts[1] == "" {
 			return nil, fmt.Errorf("invalid URI: members type requires an ID")
@@ -71,6 +76,9 @@ func (r *ResourceURI) String() string {
 				builder.WriteString("/service-account/")
 				builder.WriteString(r.ServiceAccount)
 			}
+			if r.Summary {
+				builder.WriteString("/summary")
+			}
 		}
 	case "members":
 		if r.MemberID != "" {
@@ -11,6 +11,7 @@ func TestParseURI(t *testing.T) {
 	}{
 		{"valid project", "openai-orgs://project/proj_123", &ResourceURI{Type: "project", ProjectID: "proj_123"}, false},
 		{"valid project with service account", "openai-orgs://project/proj_123/service-account/sa_456", &ResourceURI{Type: "project", ProjectID: "proj_123", ServiceAccount: "sa_456"}, false},
+		{"valid project summary", "openai-orgs://project/proj_123/summary", &ResourceURI{Type: "project", ProjectID: "proj_123", Summary: true}, false},
 		{"valid members", "openai-orgs://members/user_789", &ResourceURI{Type: "members", MemberID: "user_789"}, false},
 		{"valid active-projects", "openai-orgs://active-projects", &ResourceURI{Type: "active-projects"}, false},
 		{"valid current-members", "openai-orgs://current-members", &ResourceURI{Type: "current-members"}, false},
@@ -40,6 +41,9 @@ func TestParseURI(t *testing.T) {
 			if got.ServiceAccount != tc.want.ServiceAccount {
 				t.Errorf("ServiceAccount = %q, want %q", got.ServiceAccount, tc.want.ServiceAccount)
 			}
+			if got.Summary != tc.want.Summary {
+				t.Errorf("Summary = %v, want %v", got.Summary, tc.want.Summary)
+			}
 			if got.MemberID != tc.want.MemberID {
 				t.Errorf("MemberID = %q, want %q", got.MemberID, tc.want.MemberID)
 			}
@@ -54,6 +58,7 @@ func TestResourceURI_RoundTrip(t *testing.T) {
 	}{
 		{"project", "openai-orgs://project/proj_123"},
 		{"project with sa", "openai-orgs://project/proj_123/service-account/sa_456"},
+		{"project summary", "openai-orgs://project/proj_123/summary"},
 		{"members", "openai-orgs://members/user_789"},
 		{"active-projects", "openai-orgs://active-projects"},
 		{"current-members", "openai-orgs://current-members"},
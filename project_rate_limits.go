@@ -75,36 +75,118 @@ func (c *Client) ListProjectRateLimits(limit int, after string, projectId string
 }
 
 // ProjectRateLimitRequestFields defines the modifiable fields when updating a rate limit.
-// All fields are optional - only non-zero values will be included in the update request.
-// This allows for partial updates of rate limit configurations.
+// All fields are optional - only fields that are set (non-nil) will be included in the
+// update request, so an explicit zero can be told apart from "leave unchanged".
+// Use Int64Ptr to set a field.
 type ProjectRateLimitRequestFields struct {
 	// MaxRequestsPer1Minute defines the new maximum requests per minute.
-	// Set to 0 to leave unchanged.
-	MaxRequestsPer1Minute int64
+	// Leave nil to leave unchanged.
+	MaxRequestsPer1Minute *int64 `json:"max_requests_per_1_minute,omitempty" yaml:"max_requests_per_1_minute,omitempty"`
 
 	// MaxTokensPer1Minute defines the new maximum tokens per minute.
-	// Set to 0 to leave unchanged.
-	MaxTokensPer1Minute int64
+	// Leave nil to leave unchanged.
+	MaxTokensPer1Minute *int64 `json:"max_tokens_per_1_minute,omitempty" yaml:"max_tokens_per_1_minute,omitempty"`
 
 	// MaxImagesPer1Minute defines the new maximum images per minute.
-	// Set to 0 to leave unchanged.
-	MaxImagesPer1Minute int64
+	// Leave nil to leave unchanged.
+	MaxImagesPer1Minute *int64 `json:"max_images_per_1_minute,omitempty" yaml:"max_images_per_1_minute,omitempty"`
 
 	// MaxAudioMegabytesPer1Minute defines the new maximum audio MB per minute.
-	// Set to 0 to leave unchanged.
-	MaxAudioMegabytesPer1Minute int64
+	// Leave nil to leave unchanged.
+	MaxAudioMegabytesPer1Minute *int64 `json:"max_audio_megabytes_per_1_minute,omitempty" yaml:"max_audio_megabytes_per_1_minute,omitempty"`
 
 	// MaxRequestsPer1Day defines the new maximum requests per day.
-	// Set to 0 to leave unchanged.
-	MaxRequestsPer1Day int64
+	// Leave nil to leave unchanged.
+	MaxRequestsPer1Day *int64 `json:"max_requests_per_1_day,omitempty" yaml:"max_requests_per_1_day,omitempty"`
 
 	// Batch1DayMaxInputTokens defines the new maximum batch input tokens per day.
-	// Set to 0 to leave unchanged.
-	Batch1DayMaxInputTokens int64
+	// Leave nil to leave unchanged.
+	Batch1DayMaxInputTokens *int64 `json:"batch_1_day_max_input_tokens,omitempty" yaml:"batch_1_day_max_input_tokens,omitempty"`
+}
+
+// Int64Ptr returns a pointer to v, for setting ProjectRateLimitRequestFields.
+func Int64Ptr(v int64) *int64 {
+	return &v
+}
+
+// rateLimitField ties one limit in ProjectRateLimit to the matching field in
+// ProjectRateLimitRequestFields.
+type rateLimitField struct {
+	// name is the API (and profile file) name of the field.
+	name      string
+	current   func(*ProjectRateLimit) int64
+	requested func(*ProjectRateLimitRequestFields) **int64
+}
+
+// rateLimitFields lists every modifiable rate limit field in API order.
+var rateLimitFields = []rateLimitField{
+	{
+		name:      "max_requests_per_1_minute",
+		current:   func(rl *ProjectRateLimit) int64 { return rl.MaxRequestsPer1Minute },
+		requested: func(f *ProjectRateLimitRequestFields) **int64 { return &f.MaxRequestsPer1Minute },
+	},
+	{
+		name:      "max_tokens_per_1_minute",
+		current:   func(rl *ProjectRateLimit) int64 { return rl.MaxTokensPer1Minute },
+		requested: func(f *ProjectRateLimitRequestFields) **int64 { return &f.MaxTokensPer1Minute },
+	},
+	{
+		name:      "max_images_per_1_minute",
+		current:   func(rl *ProjectRateLimit) int64 { return rl.MaxImagesPer1Minute },
+		requested: func(f *ProjectRateLimitRequestFields) **int64 { return &f.MaxImagesPer1Minute },
+	},
+	{
+		name:      "max_audio_megabytes_per_1_minute",
+		current:   func(rl *ProjectRateLimit) int64 { return rl.MaxAudioMegabytesPer1Minute },
+		requested: func(f *ProjectRateLimitRequestFields) **int64 { return &f.MaxAudioMegabytesPer1Minute },
+	},
+	{
+		name:      "max_requests_per_1_day",
+		current:   func(rl *ProjectRateLimit) int64 { return rl.MaxRequestsPer1Day },
+		requested: func(f *ProjectRateLimitRequestFields) **int64 { return &f.MaxRequestsPer1Day },
+	},
+	{
+		name:      "batch_1_day_max_input_tokens",
+		current:   func(rl *ProjectRateLimit) int64 { return rl.Batch1DayMaxInputTokens },
+		requested: func(f *ProjectRateLimitRequestFields) **int64 { return &f.Batch1DayMaxInputTokens },
+	},
+}
+
+// RateLimitFieldNames returns the API names of the modifiable rate limit fields.
+func RateLimitFieldNames() []string {
+	names := make([]string, len(rateLimitFields))
+	for i, field := range rateLimitFields {
+		names[i] = field.name
+	}
+	return names
+}
+
+// IsSet reports whether at least one field is set.
+func (f ProjectRateLimitRequestFields) IsSet() bool {
+	for _, field := range rateLimitFields {
+		if *field.requested(&f) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks that at least one field is set and that no field is negative.
+func (f ProjectRateLimitRequestFields) Validate() error {
+	if !f.IsSet() {
+		return fmt.Errorf("at least one rate limit field must be set")
+	}
+	for _, field := range rateLimitFields {
+		if v := *field.requested(&f); v != nil && *v < 0 {
+			return fmt.Errorf("%s must not be negative, got %d", field.name, *v)
+		}
+	}
+	return nil
 }
 
 // ModifyProjectRateLimit updates the rate limit configuration for a specific model
-// within a project. Only the non-zero fields in the request will be updated.
+// within a project. Only the fields that are set in the request will be updated;
+// a field set to zero is sent as zero.
 //
 // Parameters:
 //   - projectId: The unique identifier of the project containing the rate limit
@@ -114,29 +196,15 @@ type ProjectRateLimitRequestFields struct {
 // Returns the updated ProjectRateLimit object or an error if modification fails.
 // Common errors include invalid rate limit values or insufficient permissions.
 func (c *Client) ModifyProjectRateLimit(projectId, rateLimitId string, fields ProjectRateLimitRequestFields) (*ProjectRateLimit, error) {
-	body := map[string]int64{}
-	if fields.MaxRequestsPer1Minute > 0 {
-		body["max_requests_per_1_minute"] = fields.MaxRequestsPer1Minute
+	if err := fields.Validate(); err != nil {
+		return nil, err
 	}
 
-	if fields.MaxTokensPer1Minute > 0 {
-		body["max_tokens_per_1_minute"] = fields.MaxTokensPer1Minute
-	}
-
-	if fields.MaxImagesPer1Minute > 0 {
-		body["max_images_per_1_minute"] = fields.MaxImagesPer1Minute
-	}
-
-	if fields.MaxAudioMegabytesPer1Minute > 0 {
-		body["max_audio_megabytes_per_1_minute"] = fields.MaxAudioMegabytesPer1Minute
-	}
-
-	if fields.MaxRequestsPer1Day > 0 {
-		body["max_requests_per_1_day"] = fields.MaxRequestsPer1Day
-	}
-
-	if fields.Batch1DayMaxInputTokens > 0 {
-		body["batch_1_day_max_input_tokens"] = fields.Batch1DayMaxInputTokens
+	body := map[string]int64{}
+	for _, field := range rateLimitFields {
+		if v := *field.requested(&fields); v != nil {
+			body[field.name] = *v
+		}
 	}
 
 	path := fmt.Sprintf("%s/%s/rate_limits/%s", ProjectsListEndpoint, projectId, rateLimitId)
@@ -208,12 +208,12 @@ func TestModifyProjectRateLimits(t *testing.T) {
 	h.mockResponse("POST", path, 200, response)
 
 	fields := ProjectRateLimitRequestFields{
-		MaxRequestsPer1Minute:       expectedMaxRequestsPer1Minute,
-		MaxTokensPer1Minute:         expectedMaxTokensPer1Minute,
-		MaxImagesPer1Minute:         expectedMaxImagesPer1Minute,
-		MaxAudioMegabytesPer1Minute: expectedMaxAudioMegabytesPer1Minute,
-		MaxRequestsPer1Day:          expectedMaxRequestsPer1Day,
-		Batch1DayMaxInputTokens:     expectedBatch1DayMaxInputTokens,
+		MaxRequestsPer1Minute:       Int64Ptr(expectedMaxRequestsPer1Minute),
+		MaxTokensPer1Minute:         Int64Ptr(expectedMaxTokensPer1Minute),
+		MaxImagesPer1Minute:         Int64Ptr(expectedMaxImagesPer1Minute),
+		MaxAudioMegabytesPer1Minute: Int64Ptr(expectedMaxAudioMegabytesPer1Minute),
+		MaxRequestsPer1Day:          Int64Ptr(expectedMaxRequestsPer1Day),
+		Batch1DayMaxInputTokens:     Int64Ptr(expectedBatch1DayMaxInputTokens),
 	}
 	// Make the API call
 	projectRateLimit, err := h.client.ModifyProjectRateLimit(projectId, rateLimitId, fields)
@@ -235,3 +235,34 @@ func TestModifyProjectRateLimits(t *testing.T) {
 	// Verify the request was made
 	h.assertRequest("POST", path, 1)
 }
+
+func TestProjectRateLimitRequestFields_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		fields  ProjectRateLimitRequestFields
+		wantErr bool
+	}{
+		{name: "nothing set", fields: ProjectRateLimitRequestFields{}, wantErr: true},
+		{name: "explicit zero", fields: ProjectRateLimitRequestFields{MaxImagesPer1Minute: Int64Ptr(0)}},
+		{name: "positive", fields: ProjectRateLimitRequestFields{MaxTokensPer1Minute: Int64Ptr(1000)}},
+		{name: "negative", fields: ProjectRateLimitRequestFields{MaxRequestsPer1Day: Int64Ptr(-1)}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.fields.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestModifyProjectRateLimit_Invalid(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+
+	_, err := h.client.ModifyProjectRateLimit("proj_123", "rl_1", ProjectRateLimitRequestFields{})
+	if err == nil {
+		t.Fatal("expected error for empty request")
+	}
+	h.assertRequest("POST", "/organization/projects/proj_123/rate_limits/rl_1", 0)
+}
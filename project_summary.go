@@ -0,0 +1,204 @@
+package openaiorgs
+
+import (
+	"fmt"
+	"maps"
+	"strconv"
+	"time"
+)
+
+// DefaultProjectSummaryWindow is the period covered by the costs and usage in a
+// ProjectSummary when ProjectSummaryOptions.Window is not set.
+const DefaultProjectSummaryWindow = 30 * 24 * time.Hour
+
+// ProjectSummaryOptions configures DescribeProject.
+type ProjectSummaryOptions struct {
+	// Window is the period covered by costs and usage. Defaults to DefaultProjectSummaryWindow.
+	Window time.Duration
+	// Now overrides the current time, mainly for tests. Defaults to time.Now.
+	Now time.Time
+}
+
+// ProjectModelUsage is the completions and embeddings usage of one model in a project.
+type ProjectModelUsage struct {
+	Model        string `json:"model"`
+	Requests     int    `json:"requests"`
+	InputTokens  int    `json:"input_tokens"`
+	OutputTokens int    `json:"output_tokens"`
+}
+
+// ProjectSummary describes a project, everything that grants access to it, its limits
+// and its recent spend in a single document.
+type ProjectSummary struct {
+	ProjectInventory
+	RateLimits []ProjectRateLimit `json:"rate_limits"`
+	// Certificates holds only the certificates active for the project.
+	Certificates []Certificate `json:"certificates"`
+
+	GeneratedAt time.Time `json:"generated_at"`
+	Since       time.Time `json:"since"`
+	Costs       float64   `json:"costs"`
+	Currency    string    `json:"currency,omitempty"`
+	// Usage is sorted by model.
+	Usage []ProjectModelUsage `json:"usage"`
+}
+
+// DescribeProject fetches the project with its users, service accounts, API keys, rate
+// limits, active certificates, and the costs and token usage within the window. The
+// parts are fetched concurrently and the first error aborts the summary.
+func DescribeProject(c OpenAIOrgsClient, projectID string, opts ProjectSummaryOptions) (*ProjectSummary, error) {
+	now := lifecycleNow(opts.Now)
+	window := opts.Window
+	if window <= 0 {
+		window = DefaultProjectSummaryWindow
+	}
+	summary := &ProjectSummary{GeneratedAt: now, Since: now.Add(-window)}
+
+	base := map[string]string{
+		"start_time":   strconv.FormatInt(summary.Since.Unix(), 10),
+		"end_time":     strconv.FormatInt(now.Unix(), 10),
+		"bucket_width": "1d",
+		"project_ids":  projectID,
+		"limit":        "31",
+	}
+	// Each usage fetch writes to its own map, since they run concurrently.
+	completions := make(map[string]ProjectModelUsage)
+	embeddings := make(map[string]ProjectModelUsage)
+	byModel := maps.Clone(base)
+	byModel["group_by"] = "model"
+
+	fetches := []func() error{
+		func() error {
+			project, err := c.RetrieveProject(projectID)
+			if err != nil {
+				return fmt.Errorf("failed to retrieve project %s: %w", projectID, err)
+			}
+			summary.Project = *project
+			return nil
+		},
+		func() error {
+			users, err := ListAll(func(after string) (*ListResponse[ProjectUser], error) {
+				return c.ListProjectUsers(projectID, 100, after)
+			})
+			if err != nil {
+				return fmt.Errorf("failed to list users of project %s: %w", projectID, err)
+			}
+			summary.Users = users
+			return nil
+		},
+		func() error {
+			serviceAccounts, err := ListAll(func(after string) (*ListResponse[ProjectServiceAccount], error) {
+				return c.ListProjectServiceAccounts(projectID, 100, after)
+			})
+			if err != nil {
+				return fmt.Errorf("failed to list service accounts of project %s: %w", projectID, err)
+			}
+			summary.ServiceAccounts = serviceAccounts
+			return nil
+		},
+		func() error {
+			keys, err := ListAll(func(after string) (*ListResponse[ProjectApiKey], error) {
+				return c.ListProjectApiKeys(projectID, 100, after)
+			})
+			if err != nil {
+				return fmt.Errorf("failed to list API keys of project %s: %w", projectID, err)
+			}
+			summary.APIKeys = keys
+			return nil
+		},
+		func() error {
+			limits, err := listAllProjectRateLimits(c, projectID)
+			if err != nil {
+				return err
+			}
+			summary.RateLimits = limits
+			return nil
+		},
+		func() error {
+			certificates, err := ListAll(func(after string) (*ListResponse[Certificate], error) {
+				return c.ListProjectCertificates(projectID, 100, after, "")
+			})
+			if err != nil {
+				return fmt.Errorf("failed to list certificates for project %s: %w", projectID, err)
+			}
+			for _, cert := range certificates {
+				if cert.Active != nil && *cert.Active {
+					summary.Certificates = append(summary.Certificates, cert)
+				}
+			}
+			return nil
+		},
+		func() error {
+			return forEachUsagePage(base, func(params map[string]string) (bool, string, error) {
+				resp, err := c.GetCostsUsage(params)
+				if err != nil {
+					return false, "", fmt.Errorf("failed to get costs: %w", err)
+				}
+				for _, bucket := range resp.Data {
+					for _, result := range bucket.Results {
+						summary.Costs += result.Amount.Value
+						if summary.Currency == "" {
+							summary.Currency = result.Amount.Currency
+						}
+					}
+				}
+				return resp.HasMore, resp.NextPage, nil
+			})
+		},
+		func() error {
+			return forEachUsagePage(byModel, func(params map[string]string) (bool, string, error) {
+				resp, err := c.GetCompletionsUsage(params)
+				if err != nil {
+					return false, "", fmt.Errorf("failed to get completions usage: %w", err)
+				}
+				for _, bucket := range resp.Data {
+					for _, result := range bucket.Results {
+						addModelUsage(completions, result.Model, result.NumModelRequests, result.InputTokens, result.OutputTokens)
+					}
+				}
+				return resp.HasMore, resp.NextPage, nil
+			})
+		},
+		func() error {
+			return forEachUsagePage(byModel, func(params map[string]string) (bool, string, error) {
+				resp, err := c.GetEmbeddingsUsage(params)
+				if err != nil {
+					return false, "", fmt.Errorf("failed to get embeddings usage: %w", err)
+				}
+				for _, bucket := range resp.Data {
+					for _, result := range bucket.Results {
+						addModelUsage(embeddings, result.Model, result.NumModelRequests, result.InputTokens, 0)
+					}
+				}
+				return resp.HasMore, resp.NextPage, nil
+			})
+		},
+	}
+
+	err := forEachConcurrently(len(fetches), len(fetches), func(i int) error {
+		return fetches[i]()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for model, u := range embeddings {
+		addModelUsage(completions, model, u.Requests, u.InputTokens, u.OutputTokens)
+	}
+	for _, model := range sortedKeys(completions) {
+		if u := completions[model]; u.Requests > 0 || u.InputTokens > 0 || u.OutputTokens > 0 {
+			summary.Usage = append(summary.Usage, u)
+		}
+	}
+	return summary, nil
+}
+
+// addModelUsage adds requests and tokens to the model's entry in usage.
+func addModelUsage(usage map[string]ProjectModelUsage, model string, requests, input, output int) {
+	u := usage[model]
+	u.Model = model
+	u.Requests += requests
+	u.InputTokens += input
+	u.OutputTokens += output
+	usage[model] = u
+}
@@ -0,0 +1,81 @@
+package openaiorgs
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+// mockProjectSummary sets up proj_1 with members, keys, a rate limit, an active and an
+// inactive certificate, costs and usage for two models.
+func mockProjectSummary(h *testHelper) {
+	h.mockResponse("GET", ProjectsListEndpoint+"/proj_1", 200, Project{ID: "proj_1", Name: "Team", Status: "active"})
+	mockProjectInventory(h, "proj_1",
+		[]ProjectUser{{ID: "user_1", Email: "owner@example.com", Role: "owner"}},
+		[]ProjectServiceAccount{{ID: "svc_1", Name: "ci", Role: "member"}},
+		[]ProjectApiKey{{ID: "key_1", Name: "deploy", Owner: Owner{Type: OwnerTypeServiceAccount, SA: &ProjectServiceAccount{ID: "svc_1", Name: "ci"}}}})
+	mockProjectRateLimits(h, "proj_1", ProjectRateLimit{ID: "rl_1", Model: "gpt-4o", MaxRequestsPer1Minute: 500})
+	h.mockResponse("GET", "/organization/projects/proj_1/certificates", 200, ListResponse[Certificate]{Object: "list", Data: []Certificate{
+		{ID: "cert_a", Name: "active", Active: boolPtr(true)},
+		{ID: "cert_b", Name: "inactive", Active: boolPtr(false)},
+	}})
+	h.mockResponse("GET", usageCostsEndpoint, 200, CostsUsageResponse{Data: []CostsUsageBucket{
+		{Results: []CostsUsageResult{{ProjectID: "proj_1", Amount: CostAmount{Value: 1.25, Currency: "usd"}}}},
+		{Results: []CostsUsageResult{{ProjectID: "proj_1", Amount: CostAmount{Value: 2, Currency: "usd"}}}},
+	}})
+	httpmock.RegisterResponder("GET", testBaseURL+usageCompletionsEndpoint, func(req *http.Request) (*http.Response, error) {
+		if req.URL.Query().Get("project_ids") != "proj_1" || req.URL.Query().Get("group_by") != "model" {
+			return httpmock.NewStringResponse(400, "unexpected query "+req.URL.RawQuery), nil
+		}
+		return httpmock.NewJsonResponse(200, CompletionsUsageResponse{Data: []CompletionsUsageBucket{
+			{Results: []CompletionsUsageResult{{Model: "gpt-4o", NumModelRequests: 2, InputTokens: 100, OutputTokens: 40}}},
+			{Results: []CompletionsUsageResult{{Model: "gpt-4o", NumModelRequests: 1, InputTokens: 50, OutputTokens: 10}}},
+		}})
+	})
+	h.mockResponse("GET", usageEmbeddingsEndpoint, 200, EmbeddingsUsageResponse{Data: []EmbeddingsUsageBucket{
+		{Results: []EmbeddingsUsageResult{{Model: "text-embedding-3-small", NumModelRequests: 4, InputTokens: 800}}},
+	}})
+}
+
+func TestDescribeProject(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+	mockProjectSummary(h)
+
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	summary, err := DescribeProject(h.client, "proj_1", ProjectSummaryOptions{Now: now})
+	if err != nil {
+		t.Fatalf("DescribeProject() error = %v", err)
+	}
+	if summary.Project.Name != "Team" || len(summary.Users) != 1 || len(summary.ServiceAccounts) != 1 || len(summary.APIKeys) != 1 {
+		t.Errorf("unexpected inventory: %+v", summary.ProjectInventory)
+	}
+	if len(summary.RateLimits) != 1 || len(summary.Certificates) != 1 || summary.Certificates[0].ID != "cert_a" {
+		t.Errorf("unexpected limits or certificates: %+v %+v", summary.RateLimits, summary.Certificates)
+	}
+	if summary.Costs != 3.25 || summary.Currency != "usd" || !summary.Since.Equal(now.AddDate(0, 0, -30)) {
+		t.Errorf("unexpected costs: %v %s since %v", summary.Costs, summary.Currency, summary.Since)
+	}
+	want := []ProjectModelUsage{
+		{Model: "gpt-4o", Requests: 3, InputTokens: 150, OutputTokens: 50},
+		{Model: "text-embedding-3-small", Requests: 4, InputTokens: 800},
+	}
+	if !reflect.DeepEqual(summary.Usage, want) {
+		t.Errorf("Usage = %+v, want %+v", summary.Usage, want)
+	}
+}
+
+func TestDescribeProject_Error(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+	mockProjectSummary(h)
+	h.mockResponse("GET", ProjectsListEndpoint+"/proj_1", 404, map[string]string{"error": "not found"})
+
+	if _, err := DescribeProject(h.client, "proj_1", ProjectSummaryOptions{}); err == nil || !strings.Contains(err.Error(), "retrieve project proj_1") {
+		t.Errorf("expected retrieve error, got %v", err)
+	}
+}
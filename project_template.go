@@ -0,0 +1,420 @@
+package openaiorgs
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Project provisioning step actions, in the order ProvisionProject performs them.
+const (
+	ProjectActionCreate               = "create_project"
+	ProjectActionAddUser              = "add_user"
+	ProjectActionCreateServiceAccount = "create_service_account"
+	ProjectActionSetRateLimit         = "set_rate_limit"
+	ProjectActionActivateCertificates = "activate_certificates"
+	ProjectActionDeleteServiceAccount = "delete_service_account"
+	ProjectActionArchive              = "archive_project"
+)
+
+// Project provisioning step statuses.
+const (
+	ProjectStepPlanned = "planned"
+	ProjectStepDone    = "done"
+	ProjectStepFailed  = "failed"
+	// ProjectStepSkipped marks a rate limit for a model the new project has no limit for.
+	ProjectStepSkipped = "skipped"
+)
+
+// Project provisioning statuses.
+const (
+	// ProjectProvisionCompleted means every step of the template was applied.
+	ProjectProvisionCompleted = "completed"
+	// ProjectProvisionRolledBack means a step failed, the created service accounts were
+	// deleted and the project was archived.
+	ProjectProvisionRolledBack = "rolled_back"
+	// ProjectProvisionFailed means a step failed and the project was left as is, either
+	// because rollback was disabled or because it failed too.
+	ProjectProvisionFailed = "failed"
+	// ProjectProvisionPlanned is the status of a dry run.
+	ProjectProvisionPlanned = "planned"
+)
+
+// ProjectTemplate describes the standard contents of a new project, for example:
+//
+//	name: team-x
+//	users:
+//	  - email: lead@example.com
+//	    role: owner
+//	  - user_id: user_abc123
+//	    role: member
+//	service_accounts:
+//	  - ci
+//	rate_limits:
+//	  gpt-4o:
+//	    max_requests_per_1_minute: 500
+//	certificates:
+//	  - cert_abc123
+type ProjectTemplate struct {
+	// Name is the default name of the new project.
+	Name            string                `yaml:"name,omitempty" json:"name,omitempty"`
+	Users           []ProjectTemplateUser `yaml:"users,omitempty" json:"users,omitempty"`
+	ServiceAccounts []string              `yaml:"service_accounts,omitempty" json:"service_accounts,omitempty"`
+	RateLimits      RateLimitProfile      `yaml:"rate_limits,omitempty" json:"rate_limits,omitempty"`
+	// Certificates lists the IDs of organization certificates to activate in the project.
+	Certificates []string `yaml:"certificates,omitempty" json:"certificates,omitempty"`
+}
+
+// ProjectTemplateUser is a project member. Either UserID or Email identifies the
+// organization user.
+type ProjectTemplateUser struct {
+	UserID string `yaml:"user_id,omitempty" json:"user_id,omitempty"`
+	Email  string `yaml:"email,omitempty" json:"email,omitempty"`
+	Role   string `yaml:"role" json:"role"`
+}
+
+// LoadProjectTemplate reads a project template from a YAML (or JSON) file.
+func LoadProjectTemplate(path string) (*ProjectTemplate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read project template: %w", err)
+	}
+
+	var template ProjectTemplate
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&template); err != nil {
+		return nil, fmt.Errorf("failed to parse project template %s: %w", path, err)
+	}
+	if err := template.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid project template %s: %w", path, err)
+	}
+	return &template, nil
+}
+
+// Validate checks that every user has an identity and a valid role and that
+// service account names are unique.
+func (t *ProjectTemplate) Validate() error {
+	for i, user := range t.Users {
+		if user.UserID == "" && user.Email == "" {
+			return fmt.Errorf("user %d needs a user_id or an email", i+1)
+		}
+		if ParseRoleType(user.Role) == "" {
+			return fmt.Errorf("user %s has invalid role %q", user.identity(), user.Role)
+		}
+	}
+	seen := make(map[string]bool, len(t.ServiceAccounts))
+	for _, name := range t.ServiceAccounts {
+		if name == "" {
+			return fmt.Errorf("service account names cannot be empty")
+		}
+		if seen[name] {
+			return fmt.Errorf("duplicate service account %q", name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
+
+func (u ProjectTemplateUser) identity() string {
+	if u.Email != "" {
+		return u.Email
+	}
+	return u.UserID
+}
+
+// ProjectTemplateFromProject captures an existing project as a template: its users and
+// roles, the names of its service accounts, every non-zero rate limit by model, and
+// the certificates active in it.
+func ProjectTemplateFromProject(c OpenAIOrgsClient, projectID string) (*ProjectTemplate, error) {
+	project, err := c.RetrieveProject(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve project %s: %w", projectID, err)
+	}
+	template := &ProjectTemplate{Name: project.Name}
+
+	users, err := ListAll(func(after string) (*ListResponse[ProjectUser], error) {
+		return c.ListProjectUsers(projectID, 100, after)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users for project %s: %w", projectID, err)
+	}
+	for _, user := range users {
+		template.Users = append(template.Users, ProjectTemplateUser{UserID: user.ID, Email: user.Email, Role: user.Role})
+	}
+
+	accounts, err := ListAll(func(after string) (*ListResponse[ProjectServiceAccount], error) {
+		return c.ListProjectServiceAccounts(projectID, 100, after)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service accounts for project %s: %w", projectID, err)
+	}
+	for _, account := range accounts {
+		template.ServiceAccounts = append(template.ServiceAccounts, account.Name)
+	}
+
+	limits, err := listAllProjectRateLimits(c, projectID)
+	if err != nil {
+		return nil, err
+	}
+	for _, limit := range limits {
+		var fields ProjectRateLimitRequestFields
+		for _, field := range rateLimitFields {
+			if value := field.current(&limit); value > 0 {
+				*field.requested(&fields) = Int64Ptr(value)
+			}
+		}
+		if template.RateLimits == nil {
+			template.RateLimits = make(RateLimitProfile)
+		}
+		template.RateLimits[limit.Model] = fields
+	}
+
+	certificates, err := ListAll(func(after string) (*ListResponse[Certificate], error) {
+		return c.ListProjectCertificates(projectID, 100, after, "")
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list certificates for project %s: %w", projectID, err)
+	}
+	for _, cert := range certificates {
+		if cert.Active != nil && *cert.Active {
+			template.Certificates = append(template.Certificates, cert.ID)
+		}
+	}
+	return template, nil
+}
+
+// ProjectProvisionStep records one step of ProvisionProject. Target is the user ID,
+// service account name or model the step applies to. Rollback is set on steps that
+// undo an earlier step.
+type ProjectProvisionStep struct {
+	Action   string `json:"action"`
+	Target   string `json:"target,omitempty"`
+	Detail   string `json:"detail,omitempty"`
+	Rollback bool   `json:"rollback,omitempty"`
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+}
+
+// ProjectProvision is the outcome of ProvisionProject.
+type ProjectProvision struct {
+	ProjectID   string                 `json:"project_id,omitempty"`
+	ProjectName string                 `json:"project_name"`
+	Steps       []ProjectProvisionStep `json:"steps"`
+	Status      string                 `json:"status"`
+	Error       string                 `json:"error,omitempty"`
+}
+
+// ProjectProvisionOptions configures ProvisionProject.
+type ProjectProvisionOptions struct {
+	// Name overrides the template's project name.
+	Name string
+	// Sink receives the API key of each new service account. It is required when the
+	// template has service accounts, so that keys are never only returned in memory.
+	Sink SecretSink
+	// DryRun resolves users and lists the steps without changing anything.
+	DryRun bool
+	// KeepPartial leaves the project and whatever was created so far in place when a step
+	// fails, instead of deleting the new service accounts and archiving the project.
+	KeepPartial bool
+}
+
+// ProvisionProject creates a project from a template. It creates the project, adds
+// the users with their roles, creates the service accounts (storing each API key in
+// opts.Sink), applies the template rate limits by model and activates the certificates.
+// Template rate limits for models the new project has no limit for are skipped.
+//
+// Projects cannot be deleted, so if a step fails the service accounts created so far
+// are deleted and the project is archived, unless opts.KeepPartial is set. The returned
+// provision describes every step, including the rollback, and is returned even when err
+// is non-nil unless nothing was changed.
+func ProvisionProject(c OpenAIOrgsClient, template *ProjectTemplate, opts ProjectProvisionOptions) (*ProjectProvision, error) {
+	if err := template.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid project template: %w", err)
+	}
+	name := opts.Name
+	if name == "" {
+		name = template.Name
+	}
+	if name == "" {
+		return nil, fmt.Errorf("a project name is required")
+	}
+	if len(template.ServiceAccounts) > 0 && opts.Sink == nil && !opts.DryRun {
+		return nil, fmt.Errorf("a secret sink is required to store the keys of %d service accounts", len(template.ServiceAccounts))
+	}
+
+	users, err := resolveTemplateUsers(c, template.Users)
+	if err != nil {
+		return nil, err
+	}
+	models := sortedKeys(template.RateLimits)
+
+	provision := &ProjectProvision{ProjectName: name}
+	if opts.DryRun {
+		provision.Status = ProjectProvisionPlanned
+		planned := func(action, target, detail string) {
+			provision.Steps = append(provision.Steps, ProjectProvisionStep{Action: action, Target: target, Detail: detail, Status: ProjectStepPlanned})
+		}
+		planned(ProjectActionCreate, name, "")
+		for _, user := range users {
+			planned(ProjectActionAddUser, user.UserID, user.Role)
+		}
+		for _, account := range template.ServiceAccounts {
+			planned(ProjectActionCreateServiceAccount, account, "")
+		}
+		for _, model := range models {
+			planned(ProjectActionSetRateLimit, model, "")
+		}
+		if len(template.Certificates) > 0 {
+			planned(ProjectActionActivateCertificates, strings.Join(template.Certificates, ","), "")
+		}
+		return provision, nil
+	}
+
+	project, err := c.CreateProject(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create project %q: %w", name, err)
+	}
+	provision.ProjectID = project.ID
+	provision.Steps = append(provision.Steps, ProjectProvisionStep{Action: ProjectActionCreate, Target: name, Detail: project.ID, Status: ProjectStepDone})
+
+	var serviceAccounts []*ProjectServiceAccount
+	fail := func(step ProjectProvisionStep, err error) (*ProjectProvision, error) {
+		step.Status = ProjectStepFailed
+		step.Error = err.Error()
+		provision.Steps = append(provision.Steps, step)
+		stepErr := fmt.Errorf("%s %s failed: %w", step.Action, step.Target, err)
+
+		provision.Status = ProjectProvisionFailed
+		if !opts.KeepPartial {
+			if rollbackProjectProvision(c, provision, serviceAccounts) {
+				provision.Status = ProjectProvisionRolledBack
+			} else {
+				stepErr = fmt.Errorf("%w; rollback was incomplete", stepErr)
+			}
+		}
+		provision.Error = stepErr.Error()
+		return provision, stepErr
+	}
+
+	for _, user := range users {
+		step := ProjectProvisionStep{Action: ProjectActionAddUser, Target: user.UserID, Detail: user.Role}
+		if _, err := c.CreateProjectUser(project.ID, user.UserID, user.Role); err != nil {
+			return fail(step, err)
+		}
+		step.Status = ProjectStepDone
+		provision.Steps = append(provision.Steps, step)
+	}
+
+	for _, name := range template.ServiceAccounts {
+		step := ProjectProvisionStep{Action: ProjectActionCreateServiceAccount, Target: name}
+		account, err := c.CreateProjectServiceAccount(project.ID, name)
+		if err != nil {
+			return fail(step, err)
+		}
+		serviceAccounts = append(serviceAccounts, account)
+		if account.APIKey == nil || account.APIKey.Value == "" {
+			return fail(step, fmt.Errorf("service account %s was created without an API key", account.ID))
+		}
+		destination, err := opts.Sink.Store(Secret{Name: account.Name, ID: account.APIKey.ID, Value: account.APIKey.Value})
+		if err != nil {
+			return fail(step, fmt.Errorf("failed to store API key: %w", err))
+		}
+		step.Detail = destination
+		step.Status = ProjectStepDone
+		provision.Steps = append(provision.Steps, step)
+	}
+
+	if len(models) > 0 {
+		plans := PlanRateLimitProfile(c, []string{project.ID}, template.RateLimits, 1)
+		plan := plans[0]
+		if len(plan.Errors) > 0 {
+			return fail(ProjectProvisionStep{Action: ProjectActionSetRateLimit}, fmt.Errorf("%s", plan.Errors[0]))
+		}
+		for _, model := range plan.MissingModels {
+			provision.Steps = append(provision.Steps, ProjectProvisionStep{
+				Action: ProjectActionSetRateLimit,
+				Target: model,
+				Status: ProjectStepSkipped,
+				Error:  "the project has no rate limit for this model",
+			})
+		}
+		for _, change := range plan.Changes {
+			step := ProjectProvisionStep{Action: ProjectActionSetRateLimit, Target: change.Model, Detail: change.RateLimitID}
+			if err := ApplyRateLimitChange(c, change); err != nil {
+				return fail(step, err)
+			}
+			step.Status = ProjectStepDone
+			provision.Steps = append(provision.Steps, step)
+		}
+	}
+
+	if len(template.Certificates) > 0 {
+		step := ProjectProvisionStep{Action: ProjectActionActivateCertificates, Target: strings.Join(template.Certificates, ",")}
+		if err := activationResult(c.ActivateProjectCertificates(project.ID, template.Certificates)); err != nil {
+			return fail(step, err)
+		}
+		step.Status = ProjectStepDone
+		provision.Steps = append(provision.Steps, step)
+	}
+
+	provision.Status = ProjectProvisionCompleted
+	return provision, nil
+}
+
+// rollbackProjectProvision deletes the service accounts created so far, so their keys
+// stop working, and archives the project. It reports whether every step succeeded.
+func rollbackProjectProvision(c OpenAIOrgsClient, provision *ProjectProvision, serviceAccounts []*ProjectServiceAccount) bool {
+	ok := true
+	record := func(action, target string, err error) {
+		step := ProjectProvisionStep{Action: action, Target: target, Rollback: true, Status: ProjectStepDone}
+		if err != nil {
+			step.Status = ProjectStepFailed
+			step.Error = err.Error()
+			ok = false
+		}
+		provision.Steps = append(provision.Steps, step)
+	}
+	for i := len(serviceAccounts) - 1; i >= 0; i-- {
+		record(ProjectActionDeleteServiceAccount, serviceAccounts[i].Name, c.DeleteProjectServiceAccount(provision.ProjectID, serviceAccounts[i].ID))
+	}
+	_, err := c.ArchiveProject(provision.ProjectID)
+	record(ProjectActionArchive, provision.ProjectID, err)
+	return ok
+}
+
+// resolveTemplateUsers looks up the user ID of every user identified by email.
+func resolveTemplateUsers(c OpenAIOrgsClient, users []ProjectTemplateUser) ([]ProjectTemplateUser, error) {
+	resolved := make([]ProjectTemplateUser, len(users))
+	copy(resolved, users)
+
+	var byEmail map[string]string
+	for i, user := range resolved {
+		if user.UserID != "" {
+			continue
+		}
+		if byEmail == nil {
+			orgUsers, err := ListAll(func(after string) (*ListResponse[User], error) {
+				return c.ListUsers(100, after)
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list users: %w", err)
+			}
+			byEmail = make(map[string]string, len(orgUsers))
+			for _, orgUser := range orgUsers {
+				byEmail[strings.ToLower(orgUser.Email)] = orgUser.ID
+			}
+		}
+		id, ok := byEmail[strings.ToLower(user.Email)]
+		if !ok {
+			return nil, fmt.Errorf("no organization user with email %s", user.Email)
+		}
+		resolved[i].UserID = id
+	}
+	sort.SliceStable(resolved, func(i, j int) bool { return resolved[i].UserID < resolved[j].UserID })
+	return resolved, nil
+}
@@ -0,0 +1,231 @@
+package openaiorgs
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadProjectTemplate(t *testing.T) {
+	write := func(t *testing.T, contents string) string {
+		path := filepath.Join(t.TempDir(), "template.yaml")
+		if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	template, err := LoadProjectTemplate(write(t, `
+name: team-x
+users:
+  - email: lead@example.com
+    role: owner
+service_accounts: [ci, batch]
+rate_limits:
+  gpt-4o:
+    max_requests_per_1_minute: 500
+certificates: [cert_abc]
+`))
+	if err != nil {
+		t.Fatalf("LoadProjectTemplate() error = %v", err)
+	}
+	if template.Name != "team-x" || len(template.Users) != 1 || len(template.ServiceAccounts) != 2 ||
+		*template.RateLimits["gpt-4o"].MaxRequestsPer1Minute != 500 || template.Certificates[0] != "cert_abc" {
+		t.Errorf("unexpected template: %+v", template)
+	}
+
+	for name, contents := range map[string]string{
+		"unknown field":     "nmae: x\n",
+		"invalid role":      "users:\n  - user_id: user_1\n    role: boss\n",
+		"missing identity":  "users:\n  - role: owner\n",
+		"duplicate account": "service_accounts: [ci, ci]\n",
+	} {
+		if _, err := LoadProjectTemplate(write(t, contents)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+// mockProjectTemplateSource sets up proj_src with a user, a service account, a rate
+// limit and an active certificate.
+func mockProjectTemplateSource(h *testHelper) {
+	h.mockResponse("GET", "/organization/projects/proj_src", 200, Project{ID: "proj_src", Name: "template"})
+	h.mockResponse("GET", "/organization/projects/proj_src/users", 200, ListResponse[ProjectUser]{Object: "list", Data: []ProjectUser{
+		{ID: "user_1", Email: "lead@example.com", Role: "owner"},
+	}})
+	h.mockResponse("GET", "/organization/projects/proj_src/service_accounts", 200, ListResponse[ProjectServiceAccount]{Object: "list", Data: []ProjectServiceAccount{
+		{ID: "svc_old", Name: "ci"},
+	}})
+	mockProjectRateLimits(h, "proj_src", ProjectRateLimit{ID: "rl_src", Model: "gpt-4o", MaxRequestsPer1Minute: 500, MaxTokensPer1Minute: 30000})
+	h.mockResponse("GET", "/organization/projects/proj_src/certificates", 200, ListResponse[Certificate]{Object: "list", Data: []Certificate{
+		{ID: "cert_a", Active: boolPtr(true)},
+		{ID: "cert_b", Active: boolPtr(false)},
+	}})
+}
+
+// mockProjectProvisioning sets up the responses for creating proj_new.
+func mockProjectProvisioning(h *testHelper) {
+	h.mockResponse("POST", "/organization/projects", 200, Project{ID: "proj_new", Name: "team-x"})
+	h.mockResponse("POST", "/organization/projects/proj_new/users", 200, ProjectUser{ID: "user_1", Role: "owner"})
+	h.mockResponse("POST", "/organization/projects/proj_new/service_accounts", 200, ProjectServiceAccount{
+		ID:     "svc_new",
+		Name:   "ci",
+		APIKey: &ProjectServiceAccountAPIKey{ID: "key_new", Value: "sk-svcacct-new"},
+	})
+	mockProjectRateLimits(h, "proj_new",
+		ProjectRateLimit{ID: "rl_new", Model: "gpt-4o", MaxRequestsPer1Minute: 10000, MaxTokensPer1Minute: 30000},
+	)
+	h.mockResponse("POST", "/organization/projects/proj_new/rate_limits/rl_new", 200, ProjectRateLimit{ID: "rl_new"})
+	h.mockResponse("POST", fmt.Sprintf(ProjectCertificateActivateEndpoint, "proj_new"), 200, CertificateActivationResponse{Success: true})
+	h.mockResponse("DELETE", "/organization/projects/proj_new/service_accounts/svc_new", 200, map[string]any{"deleted": true})
+	h.mockResponse("POST", "/organization/projects/proj_new/archive", 200, Project{ID: "proj_new", Status: "archived"})
+}
+
+func TestProjectTemplateFromProject(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+	mockProjectTemplateSource(h)
+
+	template, err := ProjectTemplateFromProject(h.client, "proj_src")
+	if err != nil {
+		t.Fatalf("ProjectTemplateFromProject() error = %v", err)
+	}
+	want := &ProjectTemplate{
+		Name:            "template",
+		Users:           []ProjectTemplateUser{{UserID: "user_1", Email: "lead@example.com", Role: "owner"}},
+		ServiceAccounts: []string{"ci"},
+		RateLimits: RateLimitProfile{"gpt-4o": {
+			MaxRequestsPer1Minute: Int64Ptr(500),
+			MaxTokensPer1Minute:   Int64Ptr(30000),
+		}},
+		Certificates: []string{"cert_a"},
+	}
+	if !reflect.DeepEqual(template, want) {
+		t.Errorf("template = %+v, want %+v", template, want)
+	}
+}
+
+func TestProvisionProject(t *testing.T) {
+	template := &ProjectTemplate{
+		Users:           []ProjectTemplateUser{{Email: "lead@example.com", Role: "owner"}},
+		ServiceAccounts: []string{"ci"},
+		RateLimits: RateLimitProfile{
+			"gpt-4o":   {MaxRequestsPer1Minute: Int64Ptr(500)},
+			"dall-e-3": {MaxImagesPer1Minute: Int64Ptr(5)},
+		},
+		Certificates: []string{"cert_a"},
+	}
+
+	var stored []Secret
+	sink := SecretSinkFunc(func(secret Secret) (string, error) {
+		stored = append(stored, secret)
+		return "memory:" + secret.Name, nil
+	})
+	mockUsers := func(h *testHelper) {
+		h.mockResponse("GET", "/organization/users", 200, ListResponse[User]{Object: "list", Data: []User{{ID: "user_1", Email: "Lead@example.com"}}})
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		h := newTestHelper(t)
+		defer h.cleanup()
+		mockUsers(h)
+
+		provision, err := ProvisionProject(h.client, template, ProjectProvisionOptions{Name: "team-x", DryRun: true})
+		if err != nil {
+			t.Fatalf("ProvisionProject() error = %v", err)
+		}
+		var actions []string
+		for _, step := range provision.Steps {
+			actions = append(actions, step.Action+":"+step.Target)
+		}
+		want := "create_project:team-x add_user:user_1 create_service_account:ci set_rate_limit:dall-e-3 set_rate_limit:gpt-4o activate_certificates:cert_a"
+		if got := strings.Join(actions, " "); got != want || provision.Status != ProjectProvisionPlanned {
+			t.Errorf("steps = %s, want %s", got, want)
+		}
+		h.assertRequest("POST", "/organization/projects", 0)
+	})
+
+	t.Run("completed", func(t *testing.T) {
+		h := newTestHelper(t)
+		defer h.cleanup()
+		mockUsers(h)
+		mockProjectProvisioning(h)
+		stored = nil
+
+		provision, err := ProvisionProject(h.client, template, ProjectProvisionOptions{Name: "team-x", Sink: sink})
+		if err != nil {
+			t.Fatalf("ProvisionProject() error = %v", err)
+		}
+		if provision.Status != ProjectProvisionCompleted || provision.ProjectID != "proj_new" {
+			t.Errorf("unexpected provision: %+v", provision)
+		}
+		var steps []string
+		for _, step := range provision.Steps {
+			steps = append(steps, step.Action+":"+step.Target+":"+step.Status)
+		}
+		want := "create_project:team-x:done add_user:user_1:done create_service_account:ci:done set_rate_limit:dall-e-3:skipped set_rate_limit:gpt-4o:done activate_certificates:cert_a:done"
+		if got := strings.Join(steps, " "); got != want {
+			t.Errorf("steps = %s, want %s", got, want)
+		}
+		if len(stored) != 1 || stored[0].Value != "sk-svcacct-new" || provision.Steps[2].Detail != "memory:ci" {
+			t.Errorf("unexpected stored secrets: %+v", stored)
+		}
+		h.assertRequest("POST", "/organization/projects/proj_new/rate_limits/rl_new", 1)
+		h.assertRequest("POST", "/organization/projects/proj_new/archive", 0)
+	})
+
+	t.Run("rolled back", func(t *testing.T) {
+		h := newTestHelper(t)
+		defer h.cleanup()
+		mockUsers(h)
+		mockProjectProvisioning(h)
+		h.mockResponse("POST", fmt.Sprintf(ProjectCertificateActivateEndpoint, "proj_new"), 400, map[string]any{"error": "unknown certificate"})
+
+		provision, err := ProvisionProject(h.client, template, ProjectProvisionOptions{Name: "team-x", Sink: sink})
+		if err == nil || !strings.Contains(err.Error(), "activate_certificates cert_a failed") {
+			t.Fatalf("expected step error, got %v", err)
+		}
+		if provision.Status != ProjectProvisionRolledBack {
+			t.Errorf("unexpected status: %+v", provision)
+		}
+		var rollback []string
+		for _, step := range provision.Steps {
+			if step.Rollback {
+				rollback = append(rollback, step.Action+":"+step.Target+":"+step.Status)
+			}
+		}
+		want := "delete_service_account:ci:done archive_project:proj_new:done"
+		if got := strings.Join(rollback, " "); got != want {
+			t.Errorf("rollback = %s, want %s", got, want)
+		}
+	})
+
+	t.Run("keep partial", func(t *testing.T) {
+		h := newTestHelper(t)
+		defer h.cleanup()
+		mockUsers(h)
+		mockProjectProvisioning(h)
+		failing := SecretSinkFunc(func(Secret) (string, error) { return "", errors.New("disk full") })
+
+		provision, err := ProvisionProject(h.client, template, ProjectProvisionOptions{Name: "team-x", Sink: failing, KeepPartial: true})
+		if err == nil || !strings.Contains(err.Error(), "disk full") {
+			t.Fatalf("expected sink error, got %v", err)
+		}
+		if provision.Status != ProjectProvisionFailed {
+			t.Errorf("unexpected status: %+v", provision)
+		}
+		h.assertRequest("POST", "/organization/projects/proj_new/archive", 0)
+	})
+
+	t.Run("sink required", func(t *testing.T) {
+		h := newTestHelper(t)
+		defer h.cleanup()
+		if _, err := ProvisionProject(h.client, template, ProjectProvisionOptions{Name: "team-x"}); err == nil {
+			t.Error("expected error without a secret sink")
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package openaiorgs
+
+import (
+	"fmt"
+	"sort"
+)
+
+// CompareRateLimits returns every field whose value in target differs from source,
+// as changes that would make target match source.
+func CompareRateLimits(target, source ProjectRateLimit) []RateLimitFieldChange {
+	var changes []RateLimitFieldChange
+	for _, field := range rateLimitFields {
+		if have, want := field.current(&target), field.current(&source); have != want {
+			changes = append(changes, RateLimitFieldChange{Field: field.name, From: have, To: want})
+		}
+	}
+	return changes
+}
+
+// PlanRateLimitCopy compares the rate limits of two projects by model and returns the
+// plan that makes the target project's limits match the source project's. Models that
+// only one of the projects has are listed in MissingModels (source only) and
+// ExtraModels (target only); they cannot be created or removed through the API.
+func PlanRateLimitCopy(c OpenAIOrgsClient, sourceID, targetID string) (*RateLimitProjectPlan, error) {
+	source, err := listAllProjectRateLimits(c, sourceID)
+	if err != nil {
+		return nil, err
+	}
+	if len(source) == 0 {
+		return nil, fmt.Errorf("project %s has no rate limits to copy", sourceID)
+	}
+	target, err := listAllProjectRateLimits(c, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceByModel := make(map[string]ProjectRateLimit, len(source))
+	for _, limit := range source {
+		sourceByModel[limit.Model] = limit
+	}
+	targetByModel := make(map[string]ProjectRateLimit, len(target))
+	for _, limit := range target {
+		targetByModel[limit.Model] = limit
+	}
+
+	models := make([]string, 0, len(sourceByModel))
+	for model := range sourceByModel {
+		models = append(models, model)
+	}
+	sort.Strings(models)
+
+	plan := &RateLimitProjectPlan{ProjectID: targetID}
+	for _, model := range models {
+		limit, ok := targetByModel[model]
+		if !ok {
+			plan.MissingModels = append(plan.MissingModels, model)
+			continue
+		}
+		if fields := CompareRateLimits(limit, sourceByModel[model]); len(fields) > 0 {
+			plan.Changes = append(plan.Changes, RateLimitChange{
+				ProjectID:   targetID,
+				RateLimitID: limit.ID,
+				Model:       model,
+				Fields:      fields,
+			})
+		}
+	}
+	for model := range targetByModel {
+		if _, ok := sourceByModel[model]; !ok {
+			plan.ExtraModels = append(plan.ExtraModels, model)
+		}
+	}
+	sort.Strings(plan.ExtraModels)
+
+	return plan, nil
+}
@@ -0,0 +1,91 @@
+package openaiorgs
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestCompareRateLimits(t *testing.T) {
+	source := ProjectRateLimit{MaxRequestsPer1Minute: 500, MaxTokensPer1Minute: 30000, MaxImagesPer1Minute: 0}
+	target := ProjectRateLimit{MaxRequestsPer1Minute: 500, MaxTokensPer1Minute: 10000, MaxImagesPer1Minute: 5}
+
+	got := CompareRateLimits(target, source)
+	want := []RateLimitFieldChange{
+		{Field: "max_tokens_per_1_minute", From: 10000, To: 30000},
+		{Field: "max_images_per_1_minute", From: 5, To: 0},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CompareRateLimits() = %+v, want %+v", got, want)
+	}
+}
+
+func TestPlanRateLimitCopy(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+
+	mockProjectRateLimits(h, "proj_prod",
+		ProjectRateLimit{ID: "rl_p1", Model: "gpt-4o", MaxRequestsPer1Minute: 500, MaxTokensPer1Minute: 30000},
+		ProjectRateLimit{ID: "rl_p2", Model: "gpt-4o-mini", MaxRequestsPer1Minute: 1000},
+		ProjectRateLimit{ID: "rl_p3", Model: "dall-e-3", MaxImagesPer1Minute: 5})
+	mockProjectRateLimits(h, "proj_staging",
+		ProjectRateLimit{ID: "rl_s1", Model: "gpt-4o", MaxRequestsPer1Minute: 500, MaxTokensPer1Minute: 10000},
+		ProjectRateLimit{ID: "rl_s2", Model: "gpt-4o-mini", MaxRequestsPer1Minute: 1000},
+		ProjectRateLimit{ID: "rl_s4", Model: "whisper-1", MaxRequestsPer1Minute: 50})
+
+	plan, err := PlanRateLimitCopy(h.client, "proj_prod", "proj_staging")
+	if err != nil {
+		t.Fatalf("PlanRateLimitCopy() error = %v", err)
+	}
+	if plan.ProjectID != "proj_staging" || len(plan.Changes) != 1 {
+		t.Fatalf("unexpected plan: %+v", plan)
+	}
+	change := plan.Changes[0]
+	if change.RateLimitID != "rl_s1" || change.Model != "gpt-4o" || len(change.Fields) != 1 || change.Fields[0].To != 30000 {
+		t.Errorf("unexpected change: %+v", change)
+	}
+	if !reflect.DeepEqual(plan.MissingModels, []string{"dall-e-3"}) || !reflect.DeepEqual(plan.ExtraModels, []string{"whisper-1"}) {
+		t.Errorf("unexpected model differences: missing %v, extra %v", plan.MissingModels, plan.ExtraModels)
+	}
+}
+
+func TestPlanRateLimitCopy_EmptySource(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+
+	mockProjectRateLimits(h, "proj_empty")
+
+	_, err := PlanRateLimitCopy(h.client, "proj_empty", "proj_staging")
+	if err == nil || !strings.Contains(err.Error(), "no rate limits to copy") {
+		t.Errorf("expected empty source error, got %v", err)
+	}
+}
+
+func TestApplyRateLimitChange_Zero(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+
+	var body map[string]int64
+	httpmock.RegisterResponder("POST", testBaseURL+"/organization/projects/proj_1/rate_limits/rl_1", func(req *http.Request) (*http.Response, error) {
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			return httpmock.NewStringResponse(400, err.Error()), nil
+		}
+		return httpmock.NewJsonResponse(200, ProjectRateLimit{ID: "rl_1"})
+	})
+
+	err := ApplyRateLimitChange(h.client, RateLimitChange{
+		ProjectID:   "proj_1",
+		RateLimitID: "rl_1",
+		Fields:      []RateLimitFieldChange{{Field: "max_images_per_1_minute", From: 5, To: 0}},
+	})
+	if err != nil {
+		t.Fatalf("ApplyRateLimitChange() error = %v", err)
+	}
+	if v, ok := body["max_images_per_1_minute"]; !ok || v != 0 || len(body) != 1 {
+		t.Errorf("expected only an explicit zero in the request, got %v", body)
+	}
+}
@@ -0,0 +1,202 @@
+package openaiorgs
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Defaults for AnalyzeRateLimitHeadroom when the corresponding HeadroomOptions field is not set.
+const (
+	DefaultHeadroomWindow            = 24 * time.Hour
+	DefaultHeadroomTargetUtilization = 0.8
+)
+
+// HeadroomOptions configures AnalyzeRateLimitHeadroom.
+type HeadroomOptions struct {
+	// Window is how far back usage is read. Defaults to DefaultHeadroomWindow.
+	Window time.Duration
+	// TargetUtilization is the share of the recommended limit that the observed peak
+	// should use, between 0 and 1. Defaults to DefaultHeadroomTargetUtilization.
+	TargetUtilization float64
+	// Now overrides the end of the window, mainly for tests. Defaults to time.Now.
+	Now time.Time
+}
+
+// RateLimitHeadroom compares one model's per-minute usage with its rate limits.
+type RateLimitHeadroom struct {
+	Model string `json:"model"`
+	// RateLimitID is empty when the model was used but the project has no rate limit for it.
+	RateLimitID           string `json:"rate_limit_id,omitempty"`
+	MaxRequestsPer1Minute int64  `json:"max_requests_per_1_minute"`
+	MaxTokensPer1Minute   int64  `json:"max_tokens_per_1_minute"`
+	// ActiveMinutes is the number of minutes in the window with at least one request.
+	ActiveMinutes         int   `json:"active_minutes"`
+	PeakRequestsPerMinute int64 `json:"peak_requests_per_minute"`
+	P95RequestsPerMinute  int64 `json:"p95_requests_per_minute"`
+	PeakTokensPerMinute   int64 `json:"peak_tokens_per_minute"`
+	P95TokensPerMinute    int64 `json:"p95_tokens_per_minute"`
+	// RequestUtilization and TokenUtilization are the peaks as a fraction of the
+	// limits, or 0 when there is no limit.
+	RequestUtilization float64 `json:"request_utilization"`
+	TokenUtilization   float64 `json:"token_utilization"`
+	// RecommendedRequestsPerMinute and RecommendedTokensPerMinute size the limits so
+	// the peaks use the target utilization. They are 0 when the model was not used.
+	RecommendedRequestsPerMinute int64 `json:"recommended_requests_per_minute"`
+	RecommendedTokensPerMinute   int64 `json:"recommended_tokens_per_minute"`
+}
+
+// HeadroomReport is the result of AnalyzeRateLimitHeadroom.
+type HeadroomReport struct {
+	ProjectID         string              `json:"project_id"`
+	Start             time.Time           `json:"start"`
+	End               time.Time           `json:"end"`
+	TargetUtilization float64             `json:"target_utilization"`
+	Models            []RateLimitHeadroom `json:"models"`
+}
+
+// AnalyzeRateLimitHeadroom reads a project's completions usage in one-minute buckets
+// grouped by model, computes the peak and 95th percentile requests and tokens
+// (input plus output) per minute, and compares them with the project's
+// MaxRequestsPer1Minute and MaxTokensPer1Minute limits.
+//
+// Percentiles are taken over the minutes in which the model was used, so that idle
+// periods do not hide bursts. Usage is matched to rate limits by exact model name.
+func AnalyzeRateLimitHeadroom(c OpenAIOrgsClient, projectID string, opts HeadroomOptions) (*HeadroomReport, error) {
+	end := opts.Now
+	if end.IsZero() {
+		end = time.Now()
+	}
+	window := opts.Window
+	if window <= 0 {
+		window = DefaultHeadroomWindow
+	}
+	target := opts.TargetUtilization
+	if target <= 0 {
+		target = DefaultHeadroomTargetUtilization
+	}
+	if target > 1 {
+		return nil, fmt.Errorf("target utilization must be at most 1, got %v", target)
+	}
+
+	limits, err := listAllProjectRateLimits(c, projectID)
+	if err != nil {
+		return nil, err
+	}
+	usage, err := collectMinuteUsage(c, projectID, end.Add(-window), end)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &HeadroomReport{
+		ProjectID:         projectID,
+		Start:             end.Add(-window),
+		End:               end,
+		TargetUtilization: target,
+	}
+	seen := make(map[string]bool, len(limits))
+	for _, limit := range limits {
+		seen[limit.Model] = true
+		entry := RateLimitHeadroom{
+			Model:                 limit.Model,
+			RateLimitID:           limit.ID,
+			MaxRequestsPer1Minute: limit.MaxRequestsPer1Minute,
+			MaxTokensPer1Minute:   limit.MaxTokensPer1Minute,
+		}
+		entry.addUsage(usage[limit.Model], target)
+		report.Models = append(report.Models, entry)
+	}
+	for model, minutes := range usage {
+		if !seen[model] {
+			entry := RateLimitHeadroom{Model: model}
+			entry.addUsage(minutes, target)
+			report.Models = append(report.Models, entry)
+		}
+	}
+
+	sort.SliceStable(report.Models, func(i, j int) bool {
+		a, b := report.Models[i], report.Models[j]
+		if ua, ub := max(a.RequestUtilization, a.TokenUtilization), max(b.RequestUtilization, b.TokenUtilization); ua != ub {
+			return ua > ub
+		}
+		return a.Model < b.Model
+	})
+	return report, nil
+}
+
+// minuteUsage is one model's usage in one minute.
+type minuteUsage struct {
+	requests int64
+	tokens   int64
+}
+
+func (e *RateLimitHeadroom) addUsage(minutes []minuteUsage, target float64) {
+	e.ActiveMinutes = len(minutes)
+	if len(minutes) == 0 {
+		return
+	}
+
+	requests := make([]int64, len(minutes))
+	tokens := make([]int64, len(minutes))
+	for i, m := range minutes {
+		requests[i] = m.requests
+		tokens[i] = m.tokens
+	}
+	e.PeakRequestsPerMinute, e.P95RequestsPerMinute = peakAndP95(requests)
+	e.PeakTokensPerMinute, e.P95TokensPerMinute = peakAndP95(tokens)
+
+	if e.MaxRequestsPer1Minute > 0 {
+		e.RequestUtilization = float64(e.PeakRequestsPerMinute) / float64(e.MaxRequestsPer1Minute)
+	}
+	if e.MaxTokensPer1Minute > 0 {
+		e.TokenUtilization = float64(e.PeakTokensPerMinute) / float64(e.MaxTokensPer1Minute)
+	}
+	e.RecommendedRequestsPerMinute = int64(math.Ceil(float64(e.PeakRequestsPerMinute) / target))
+	e.RecommendedTokensPerMinute = int64(math.Ceil(float64(e.PeakTokensPerMinute) / target))
+}
+
+// peakAndP95 returns the maximum and the nearest-rank 95th percentile of values.
+func peakAndP95(values []int64) (int64, int64) {
+	sorted := append([]int64(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	rank := int(math.Ceil(0.95*float64(len(sorted)))) - 1
+	return sorted[len(sorted)-1], sorted[max(rank, 0)]
+}
+
+// collectMinuteUsage returns the project's completions usage per model, one entry for
+// every minute with at least one request.
+func collectMinuteUsage(c OpenAIOrgsClient, projectID string, start, end time.Time) (map[string][]minuteUsage, error) {
+	params := map[string]string{
+		"start_time":   strconv.FormatInt(start.Unix(), 10),
+		"end_time":     strconv.FormatInt(end.Unix(), 10),
+		"bucket_width": "1m",
+		"group_by":     "model",
+		"project_ids":  projectID,
+		"limit":        "1440",
+	}
+
+	usage := make(map[string][]minuteUsage)
+	for {
+		resp, err := c.GetCompletionsUsage(params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get completions usage: %w", err)
+		}
+		for _, bucket := range resp.Data {
+			for _, result := range bucket.Results {
+				if result.Model == "" || result.NumModelRequests == 0 {
+					continue
+				}
+				usage[result.Model] = append(usage[result.Model], minuteUsage{
+					requests: int64(result.NumModelRequests),
+					tokens:   int64(result.InputTokens + result.OutputTokens),
+				})
+			}
+		}
+		if !resp.HasMore || resp.NextPage == "" || resp.NextPage == params["page"] {
+			return usage, nil
+		}
+		params["page"] = resp.NextPage
+	}
+}
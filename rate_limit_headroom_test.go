@@ -0,0 +1,101 @@
+package openaiorgs
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestAnalyzeRateLimitHeadroom(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	mockProjectRateLimits(h, "proj_1",
+		ProjectRateLimit{ID: "rl_4o", Model: "gpt-4o", MaxRequestsPer1Minute: 25, MaxTokensPer1Minute: 4000},
+		ProjectRateLimit{ID: "rl_mini", Model: "gpt-4o-mini", MaxRequestsPer1Minute: 100, MaxTokensPer1Minute: 10000})
+
+	var buckets []CompletionsUsageBucket
+	for i := 1; i <= 20; i++ {
+		buckets = append(buckets, CompletionsUsageBucket{
+			StartTime: now.Add(-time.Duration(i) * time.Minute).Unix(),
+			Results: []CompletionsUsageResult{
+				{Model: "gpt-4o", NumModelRequests: i, InputTokens: 60 * i, OutputTokens: 40 * i},
+			},
+		})
+	}
+	// An idle minute and a model without a rate limit.
+	buckets = append(buckets,
+		CompletionsUsageBucket{StartTime: now.Add(-30 * time.Minute).Unix()},
+		CompletionsUsageBucket{StartTime: now.Add(-31 * time.Minute).Unix(), Results: []CompletionsUsageResult{{Model: "o1", NumModelRequests: 2, InputTokens: 10}}},
+	)
+
+	httpmock.RegisterResponder("GET", testBaseURL+usageCompletionsEndpoint, func(req *http.Request) (*http.Response, error) {
+		q := req.URL.Query()
+		if q.Get("bucket_width") != "1m" || q.Get("group_by") != "model" || q.Get("project_ids") != "proj_1" {
+			return httpmock.NewStringResponse(400, "unexpected query "+req.URL.RawQuery), nil
+		}
+		return httpmock.NewJsonResponse(200, CompletionsUsageResponse{Object: "page", Data: buckets})
+	})
+
+	report, err := AnalyzeRateLimitHeadroom(h.client, "proj_1", HeadroomOptions{Window: time.Hour, Now: now})
+	if err != nil {
+		t.Fatalf("AnalyzeRateLimitHeadroom() error = %v", err)
+	}
+	if !report.Start.Equal(now.Add(-time.Hour)) || report.TargetUtilization != DefaultHeadroomTargetUtilization {
+		t.Errorf("unexpected report window: %+v", report)
+	}
+	if len(report.Models) != 3 {
+		t.Fatalf("expected 3 models, got %+v", report.Models)
+	}
+
+	got := report.Models[0]
+	want := RateLimitHeadroom{
+		Model:                        "gpt-4o",
+		RateLimitID:                  "rl_4o",
+		MaxRequestsPer1Minute:        25,
+		MaxTokensPer1Minute:          4000,
+		ActiveMinutes:                20,
+		PeakRequestsPerMinute:        20,
+		P95RequestsPerMinute:         19,
+		PeakTokensPerMinute:          2000,
+		P95TokensPerMinute:           1900,
+		RequestUtilization:           0.8,
+		TokenUtilization:             0.5,
+		RecommendedRequestsPerMinute: 25,
+		RecommendedTokensPerMinute:   2500,
+	}
+	if got != want {
+		t.Errorf("gpt-4o headroom = %+v, want %+v", got, want)
+	}
+
+	// Models without utilization are ordered by name.
+	if report.Models[1].Model != "gpt-4o-mini" || report.Models[1].ActiveMinutes != 0 || report.Models[1].RecommendedRequestsPerMinute != 0 {
+		t.Errorf("unexpected unused model entry: %+v", report.Models[1])
+	}
+	if report.Models[2].Model != "o1" || report.Models[2].RateLimitID != "" || report.Models[2].PeakRequestsPerMinute != 2 {
+		t.Errorf("unexpected unlimited model entry: %+v", report.Models[2])
+	}
+}
+
+func TestAnalyzeRateLimitHeadroom_InvalidTarget(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+
+	if _, err := AnalyzeRateLimitHeadroom(h.client, "proj_1", HeadroomOptions{TargetUtilization: 1.5}); err == nil {
+		t.Error("expected error for target utilization above 1")
+	}
+}
+
+func TestPeakAndP95(t *testing.T) {
+	peak, p95 := peakAndP95([]int64{5})
+	if peak != 5 || p95 != 5 {
+		t.Errorf("peakAndP95([5]) = %d, %d", peak, p95)
+	}
+	peak, p95 = peakAndP95([]int64{100, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19})
+	if peak != 100 || p95 != 19 {
+		t.Errorf("peakAndP95() = %d, %d, want 100, 19", peak, p95)
+	}
+}
@@ -0,0 +1,187 @@
+package openaiorgs
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RateLimitFieldChange is a single limit that differs from the desired value.
+type RateLimitFieldChange struct {
+	Field string `json:"field"`
+	From  int64  `json:"from"`
+	To    int64  `json:"to"`
+}
+
+// RateLimitChange describes the update needed to bring one project rate limit to
+// the desired values.
+type RateLimitChange struct {
+	ProjectID   string                 `json:"project_id"`
+	RateLimitID string                 `json:"rate_limit_id"`
+	Model       string                 `json:"model"`
+	Fields      []RateLimitFieldChange `json:"fields"`
+}
+
+// DiffRateLimit compares current with desired and returns the fields that differ.
+// Fields left unset (nil) in desired are not compared.
+func DiffRateLimit(current ProjectRateLimit, desired ProjectRateLimitRequestFields) []RateLimitFieldChange {
+	var changes []RateLimitFieldChange
+	for _, field := range rateLimitFields {
+		want := *field.requested(&desired)
+		if have := field.current(&current); want != nil && *want != have {
+			changes = append(changes, RateLimitFieldChange{Field: field.name, From: have, To: *want})
+		}
+	}
+	return changes
+}
+
+// Request returns the modification request that applies the change.
+func (ch RateLimitChange) Request() ProjectRateLimitRequestFields {
+	var req ProjectRateLimitRequestFields
+	for _, change := range ch.Fields {
+		if field, ok := findRateLimitField(change.Field); ok {
+			*field.requested(&req) = Int64Ptr(change.To)
+		}
+	}
+	return req
+}
+
+// ApplyRateLimitChange sends the change to the API.
+func ApplyRateLimitChange(c OpenAIOrgsClient, ch RateLimitChange) error {
+	if _, err := c.ModifyProjectRateLimit(ch.ProjectID, ch.RateLimitID, ch.Request()); err != nil {
+		return fmt.Errorf("failed to modify rate limit %s (%s) in project %s: %w", ch.RateLimitID, ch.Model, ch.ProjectID, err)
+	}
+	return nil
+}
+
+// RateLimitProfile maps a model name to the limits it should have.
+type RateLimitProfile map[string]ProjectRateLimitRequestFields
+
+// LoadRateLimitProfile reads the named profile from a YAML (or JSON) file that maps
+// profile names to models to limits, for example:
+//
+//	tier-small:
+//	  gpt-4o:
+//	    max_requests_per_1_minute: 500
+//	    max_tokens_per_1_minute: 30000
+func LoadRateLimitProfile(path, name string) (RateLimitProfile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rate limit profiles: %w", err)
+	}
+
+	var profiles map[string]RateLimitProfile
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&profiles); err != nil {
+		return nil, fmt.Errorf("failed to parse rate limit profiles %s: %w", path, err)
+	}
+
+	profile, ok := profiles[name]
+	if !ok {
+		names := make([]string, 0, len(profiles))
+		for n := range profiles {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("no rate limit profile %q in %s (available: %s)", name, path, strings.Join(names, ", "))
+	}
+	if len(profile) == 0 {
+		return nil, fmt.Errorf("rate limit profile %q has no models", name)
+	}
+	return profile, nil
+}
+
+// RateLimitProjectPlan holds the changes needed to apply a profile to one project,
+// and after ApplyRateLimitPlans, the outcome.
+type RateLimitProjectPlan struct {
+	ProjectID string            `json:"project_id"`
+	Changes   []RateLimitChange `json:"changes,omitempty"`
+	// MissingModels lists profile (or source project) models the project has no rate limit for.
+	MissingModels []string `json:"missing_models,omitempty"`
+	// ExtraModels lists models the project has that the source project does not.
+	// It is only set by PlanRateLimitCopy.
+	ExtraModels []string `json:"extra_models,omitempty"`
+	Applied     int      `json:"applied"`
+	Errors      []string `json:"errors,omitempty"`
+}
+
+// PlanRateLimitProfile lists the rate limits of each project, matches them to the
+// profile by model and returns the changes needed. Projects are fetched concurrently;
+// a project whose rate limits cannot be listed gets an error instead of changes.
+func PlanRateLimitProfile(c OpenAIOrgsClient, projectIDs []string, profile RateLimitProfile, concurrency int) []RateLimitProjectPlan {
+	models := make([]string, 0, len(profile))
+	for model := range profile {
+		models = append(models, model)
+	}
+	sort.Strings(models)
+
+	return planProjectRateLimits(c, projectIDs, concurrency, func(plan *RateLimitProjectPlan, limits []ProjectRateLimit) {
+		byModel := make(map[string]ProjectRateLimit, len(limits))
+		for _, limit := range limits {
+			byModel[limit.Model] = limit
+		}
+
+		for _, model := range models {
+			limit, ok := byModel[model]
+			if !ok {
+				plan.MissingModels = append(plan.MissingModels, model)
+				continue
+			}
+			if fields := DiffRateLimit(limit, profile[model]); len(fields) > 0 {
+				plan.Changes = append(plan.Changes, RateLimitChange{
+					ProjectID:   plan.ProjectID,
+					RateLimitID: limit.ID,
+					Model:       model,
+					Fields:      fields,
+				})
+			}
+		}
+	})
+}
+
+// planProjectRateLimits lists the rate limits of each project concurrently and lets
+// fill add the changes for that project. A project whose rate limits cannot be listed
+// gets an error instead of changes.
+func planProjectRateLimits(c OpenAIOrgsClient, projectIDs []string, concurrency int, fill func(plan *RateLimitProjectPlan, limits []ProjectRateLimit)) []RateLimitProjectPlan {
+	plans := make([]RateLimitProjectPlan, len(projectIDs))
+	_ = forEachConcurrently(len(projectIDs), concurrency, func(i int) error {
+		plans[i].ProjectID = projectIDs[i]
+		limits, err := listAllProjectRateLimits(c, projectIDs[i])
+		if err != nil {
+			plans[i].Errors = append(plans[i].Errors, err.Error())
+			return nil
+		}
+		fill(&plans[i], limits)
+		return nil
+	})
+	return plans
+}
+
+// ApplyRateLimitPlans applies every change in plans, continuing past failures, and
+// records the outcome in each plan.
+func ApplyRateLimitPlans(c OpenAIOrgsClient, plans []RateLimitProjectPlan) {
+	for i := range plans {
+		for _, change := range plans[i].Changes {
+			if err := ApplyRateLimitChange(c, change); err != nil {
+				plans[i].Errors = append(plans[i].Errors, err.Error())
+				continue
+			}
+			plans[i].Applied++
+		}
+	}
+}
+
+func listAllProjectRateLimits(c OpenAIOrgsClient, projectID string) ([]ProjectRateLimit, error) {
+	limits, err := ListAll(func(after string) (*ListResponse[ProjectRateLimit], error) {
+		return c.ListProjectRateLimits(100, after, projectID)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rate limits for project %s: %w", projectID, err)
+	}
+	return limits, nil
+}
@@ -0,0 +1,112 @@
+package openaiorgs
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func mockProjectRateLimits(h *testHelper, projectID string, limits ...ProjectRateLimit) {
+	h.mockResponse("GET", "/organization/projects/"+projectID+"/rate_limits", 200, ListResponse[ProjectRateLimit]{Object: "list", Data: limits})
+}
+
+func writeRateLimitProfiles(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "profiles.yaml")
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestDiffRateLimit(t *testing.T) {
+	current := ProjectRateLimit{Model: "gpt-4o", MaxRequestsPer1Minute: 500, MaxTokensPer1Minute: 10000, MaxRequestsPer1Day: 1000}
+	desired := ProjectRateLimitRequestFields{MaxRequestsPer1Minute: Int64Ptr(500), MaxTokensPer1Minute: Int64Ptr(30000), MaxRequestsPer1Day: Int64Ptr(0), Batch1DayMaxInputTokens: Int64Ptr(5)}
+
+	got := DiffRateLimit(current, desired)
+	want := []RateLimitFieldChange{
+		{Field: "max_tokens_per_1_minute", From: 10000, To: 30000},
+		{Field: "max_requests_per_1_day", From: 1000, To: 0},
+		{Field: "batch_1_day_max_input_tokens", From: 0, To: 5},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffRateLimit() = %+v, want %+v", got, want)
+	}
+
+	change := RateLimitChange{Fields: got}
+	wantReq := ProjectRateLimitRequestFields{MaxTokensPer1Minute: Int64Ptr(30000), MaxRequestsPer1Day: Int64Ptr(0), Batch1DayMaxInputTokens: Int64Ptr(5)}
+	if req := change.Request(); !reflect.DeepEqual(req, wantReq) {
+		t.Errorf("Request() = %+v, want %+v", req, wantReq)
+	}
+}
+
+func TestLoadRateLimitProfile(t *testing.T) {
+	path := writeRateLimitProfiles(t, `
+tier-small:
+  gpt-4o:
+    max_requests_per_1_minute: 500
+    max_tokens_per_1_minute: 30000
+tier-large:
+  gpt-4o:
+    max_tokens_per_1_minute: 800000
+`)
+
+	profile, err := LoadRateLimitProfile(path, "tier-small")
+	if err != nil {
+		t.Fatalf("LoadRateLimitProfile() error = %v", err)
+	}
+	if got := profile["gpt-4o"]; *got.MaxRequestsPer1Minute != 500 || *got.MaxTokensPer1Minute != 30000 || got.MaxImagesPer1Minute != nil {
+		t.Errorf("unexpected profile: %+v", profile)
+	}
+
+	_, err = LoadRateLimitProfile(path, "tier-medium")
+	if err == nil || !strings.Contains(err.Error(), "available: tier-large, tier-small") {
+		t.Errorf("expected unknown profile error, got %v", err)
+	}
+
+	typo := writeRateLimitProfiles(t, "tier-small:\n  gpt-4o:\n    max_tokens_per_minute: 1\n")
+	if _, err := LoadRateLimitProfile(typo, "tier-small"); err == nil {
+		t.Error("expected error for unknown field")
+	}
+}
+
+func TestPlanAndApplyRateLimitProfile(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+
+	mockProjectRateLimits(h, "proj_1",
+		ProjectRateLimit{ID: "rl_4o", Model: "gpt-4o", MaxRequestsPer1Minute: 500, MaxTokensPer1Minute: 10000},
+		ProjectRateLimit{ID: "rl_mini", Model: "gpt-4o-mini", MaxRequestsPer1Minute: 1000})
+	mockProjectRateLimits(h, "proj_2",
+		ProjectRateLimit{ID: "rl_4o", Model: "gpt-4o", MaxRequestsPer1Minute: 500, MaxTokensPer1Minute: 30000})
+	h.mockResponse("GET", "/organization/projects/proj_3/rate_limits", 404, map[string]string{"error": "not found"})
+	h.mockResponse("POST", "/organization/projects/proj_1/rate_limits/rl_4o", 200, ProjectRateLimit{ID: "rl_4o"})
+	h.mockResponse("POST", "/organization/projects/proj_1/rate_limits/rl_mini", 500, map[string]string{"error": "boom"})
+
+	profile := RateLimitProfile{
+		"gpt-4o":      {MaxRequestsPer1Minute: Int64Ptr(500), MaxTokensPer1Minute: Int64Ptr(30000)},
+		"gpt-4o-mini": {MaxRequestsPer1Minute: Int64Ptr(2000)},
+	}
+	plans := PlanRateLimitProfile(h.client, []string{"proj_1", "proj_2", "proj_3"}, profile, 2)
+	if len(plans) != 3 {
+		t.Fatalf("expected 3 plans, got %d", len(plans))
+	}
+	if len(plans[0].Changes) != 2 || plans[0].Changes[0].Model != "gpt-4o" || plans[0].Changes[1].Model != "gpt-4o-mini" {
+		t.Errorf("unexpected plan for proj_1: %+v", plans[0])
+	}
+	if len(plans[1].Changes) != 0 || !reflect.DeepEqual(plans[1].MissingModels, []string{"gpt-4o-mini"}) {
+		t.Errorf("unexpected plan for proj_2: %+v", plans[1])
+	}
+	if len(plans[2].Errors) != 1 {
+		t.Errorf("expected list error for proj_3, got %+v", plans[2])
+	}
+
+	ApplyRateLimitPlans(h.client, plans)
+	if plans[0].Applied != 1 || len(plans[0].Errors) != 1 {
+		t.Errorf("unexpected outcome for proj_1: %+v", plans[0])
+	}
+	h.assertRequest("POST", "/organization/projects/proj_1/rate_limits/rl_4o", 1)
+	h.assertRequest("POST", "/organization/projects/proj_2/rate_limits/rl_4o", 0)
+}
@@ -0,0 +1,90 @@
+package openaiorgs
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+// RateLimitScaleOptions configures PlanRateLimitScale.
+type RateLimitScaleOptions struct {
+	// Models limits scaling to these models. Empty means every model.
+	Models []string
+	// Fields limits scaling to these fields, by API name (see RateLimitFieldNames).
+	// Empty means every field.
+	Fields []string
+	// Percent is the new limit as a percentage of the current one; 50 halves the
+	// limits and 200 doubles them.
+	Percent float64
+	// Concurrency is the number of projects read in parallel.
+	Concurrency int
+}
+
+// ScaleRateLimit returns percent percent of value, rounded to the nearest integer.
+// A non-zero limit is never scaled below 1, so scaling down cannot block a model
+// entirely.
+func ScaleRateLimit(value int64, percent float64) int64 {
+	scaled := int64(math.Round(float64(value) * percent / 100))
+	if scaled < 1 && value > 0 {
+		return 1
+	}
+	return scaled
+}
+
+// PlanRateLimitScale reads the current rate limits of each project and returns the
+// changes that set the selected fields to opts.Percent percent of their current values.
+// Limits that are zero are left alone. Models in opts.Models that a project has no
+// rate limit for are reported in MissingModels.
+func PlanRateLimitScale(c OpenAIOrgsClient, projectIDs []string, opts RateLimitScaleOptions) ([]RateLimitProjectPlan, error) {
+	if opts.Percent <= 0 {
+		return nil, fmt.Errorf("percent must be greater than zero, got %v", opts.Percent)
+	}
+
+	fields := rateLimitFields
+	if len(opts.Fields) > 0 {
+		fields = nil
+		for _, name := range opts.Fields {
+			field, ok := findRateLimitField(name)
+			if !ok {
+				return nil, fmt.Errorf("unknown rate limit field %q (valid fields: %s)", name, strings.Join(RateLimitFieldNames(), ", "))
+			}
+			fields = append(fields, field)
+		}
+	}
+
+	return planProjectRateLimits(c, projectIDs, opts.Concurrency, func(plan *RateLimitProjectPlan, limits []ProjectRateLimit) {
+		found := make(map[string]bool, len(limits))
+		for _, limit := range limits {
+			found[limit.Model] = true
+			if len(opts.Models) > 0 && !slices.Contains(opts.Models, limit.Model) {
+				continue
+			}
+
+			change := RateLimitChange{ProjectID: plan.ProjectID, RateLimitID: limit.ID, Model: limit.Model}
+			for _, field := range fields {
+				have := field.current(&limit)
+				if want := ScaleRateLimit(have, opts.Percent); have > 0 && want != have {
+					change.Fields = append(change.Fields, RateLimitFieldChange{Field: field.name, From: have, To: want})
+				}
+			}
+			if len(change.Fields) > 0 {
+				plan.Changes = append(plan.Changes, change)
+			}
+		}
+		for _, model := range opts.Models {
+			if !found[model] {
+				plan.MissingModels = append(plan.MissingModels, model)
+			}
+		}
+	}), nil
+}
+
+func findRateLimitField(name string) (rateLimitField, bool) {
+	for _, field := range rateLimitFields {
+		if field.name == name {
+			return field, true
+		}
+	}
+	return rateLimitField{}, false
+}
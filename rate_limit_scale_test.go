@@ -0,0 +1,71 @@
+package openaiorgs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScaleRateLimit(t *testing.T) {
+	tests := []struct {
+		value   int64
+		percent float64
+		want    int64
+	}{
+		{value: 30000, percent: 50, want: 15000},
+		{value: 3, percent: 50, want: 2},
+		{value: 1, percent: 10, want: 1},
+		{value: 0, percent: 50, want: 0},
+		{value: 100, percent: 250, want: 250},
+	}
+	for _, tt := range tests {
+		if got := ScaleRateLimit(tt.value, tt.percent); got != tt.want {
+			t.Errorf("ScaleRateLimit(%d, %v) = %d, want %d", tt.value, tt.percent, got, tt.want)
+		}
+	}
+}
+
+func TestPlanRateLimitScale(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+
+	mockProjectRateLimits(h, "proj_1",
+		ProjectRateLimit{ID: "rl_4o", Model: "gpt-4o", MaxRequestsPer1Minute: 500, MaxTokensPer1Minute: 30000},
+		ProjectRateLimit{ID: "rl_mini", Model: "gpt-4o-mini", MaxTokensPer1Minute: 200000})
+	mockProjectRateLimits(h, "proj_2",
+		ProjectRateLimit{ID: "rl_mini", Model: "gpt-4o-mini", MaxTokensPer1Minute: 200000})
+
+	plans, err := PlanRateLimitScale(h.client, []string{"proj_1", "proj_2"}, RateLimitScaleOptions{
+		Models:  []string{"gpt-4o"},
+		Fields:  []string{"max_tokens_per_1_minute", "max_images_per_1_minute"},
+		Percent: 50,
+	})
+	if err != nil {
+		t.Fatalf("PlanRateLimitScale() error = %v", err)
+	}
+
+	want := []RateLimitChange{{
+		ProjectID:   "proj_1",
+		RateLimitID: "rl_4o",
+		Model:       "gpt-4o",
+		Fields:      []RateLimitFieldChange{{Field: "max_tokens_per_1_minute", From: 30000, To: 15000}},
+	}}
+	if !reflect.DeepEqual(plans[0].Changes, want) {
+		t.Errorf("unexpected changes for proj_1: %+v", plans[0].Changes)
+	}
+	if len(plans[1].Changes) != 0 || !reflect.DeepEqual(plans[1].MissingModels, []string{"gpt-4o"}) {
+		t.Errorf("unexpected plan for proj_2: %+v", plans[1])
+	}
+}
+
+func TestPlanRateLimitScale_Invalid(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+
+	if _, err := PlanRateLimitScale(h.client, []string{"proj_1"}, RateLimitScaleOptions{Percent: 0}); err == nil {
+		t.Error("expected error for zero percent")
+	}
+	if _, err := PlanRateLimitScale(h.client, []string{"proj_1"}, RateLimitScaleOptions{Percent: 50, Fields: []string{"tpm"}}); err == nil {
+		t.Error("expected error for unknown field")
+	}
+	h.assertRequest("GET", "/organization/projects/proj_1/rate_limits", 0)
+}
@@ -0,0 +1,173 @@
+package openaiorgs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Search match kinds, in the order Search returns them.
+const (
+	SearchKindProject        = "project"
+	SearchKindUser           = "user"
+	SearchKindInvite         = "invite"
+	SearchKindAdminAPIKey    = "admin_api_key"
+	SearchKindCertificate    = "certificate"
+	SearchKindProjectUser    = "project_user"
+	SearchKindServiceAccount = "service_account"
+	SearchKindAPIKey         = "api_key"
+)
+
+// SearchOptions configures Search.
+type SearchOptions struct {
+	// IncludeArchived also searches archived projects and their members and keys.
+	IncludeArchived bool
+	// Concurrency is the number of projects searched in parallel.
+	// Defaults to DefaultInventoryConcurrency.
+	Concurrency int
+}
+
+// SearchMatch is a resource with a field that contains the search term.
+type SearchMatch struct {
+	Kind string `json:"kind"`
+	ID   string `json:"id"`
+	// Name is the resource's name, or its email for users and invites.
+	Name string `json:"name"`
+	// Field is the first field that matched: id, name, email or redacted_value.
+	Field string `json:"field"`
+	// ProjectID and ProjectName are set for project users, service accounts and API keys.
+	ProjectID   string `json:"project_id,omitempty"`
+	ProjectName string `json:"project_name,omitempty"`
+}
+
+// searchField is a named value that Search compares against the term.
+type searchField struct {
+	name  string
+	value string
+}
+
+// Search looks for term, case-insensitively, in the IDs, names and emails of projects,
+// organization users, invites, admin API keys and certificates, and of the users,
+// service accounts and API keys of every project. API keys and admin API keys also
+// match on their redacted value, so a key can be found from its visible suffix.
+//
+// The organization-level lists are fetched concurrently, then projects are searched
+// concurrently. Matches are grouped by kind, with project resources in the order
+// ListProjects returns the projects. The first error aborts the search.
+func Search(c OpenAIOrgsClient, term string, opts SearchOptions) ([]SearchMatch, error) {
+	term = strings.ToLower(strings.TrimSpace(term))
+	if term == "" {
+		return nil, fmt.Errorf("search term must not be empty")
+	}
+
+	var (
+		projects     []Project
+		users        []User
+		invites      []Invite
+		adminKeys    []AdminAPIKey
+		certificates []Certificate
+	)
+	fetches := []func() error{
+		func() (err error) {
+			projects, err = ListAll(func(after string) (*ListResponse[Project], error) {
+				return c.ListProjects(100, after, opts.IncludeArchived)
+			})
+			if err != nil {
+				return fmt.Errorf("failed to list projects: %w", err)
+			}
+			return nil
+		},
+		func() (err error) {
+			users, err = ListAll(func(after string) (*ListResponse[User], error) {
+				return c.ListUsers(100, after)
+			})
+			if err != nil {
+				return fmt.Errorf("failed to list users: %w", err)
+			}
+			return nil
+		},
+		func() (err error) {
+			invites, err = listAllInvites(c)
+			return err
+		},
+		func() (err error) {
+			adminKeys, err = ListAll(func(after string) (*ListResponse[AdminAPIKey], error) {
+				return c.ListAdminAPIKeys(100, after)
+			})
+			if err != nil {
+				return fmt.Errorf("failed to list admin API keys: %w", err)
+			}
+			return nil
+		},
+		func() (err error) {
+			certificates, err = listAllOrganizationCertificates(c)
+			return err
+		},
+	}
+	if err := forEachConcurrently(len(fetches), len(fetches), func(i int) error { return fetches[i]() }); err != nil {
+		return nil, err
+	}
+
+	var matches []SearchMatch
+	add := func(kind, id, name string, project *Project, fields ...searchField) {
+		for _, field := range fields {
+			if strings.Contains(strings.ToLower(field.value), term) {
+				match := SearchMatch{Kind: kind, ID: id, Name: name, Field: field.name}
+				if project != nil {
+					match.ProjectID = project.ID
+					match.ProjectName = project.Name
+				}
+				matches = append(matches, match)
+				return
+			}
+		}
+	}
+
+	for _, project := range projects {
+		add(SearchKindProject, project.ID, project.Name, nil, searchField{"id", project.ID}, searchField{"name", project.Name})
+	}
+	for _, user := range users {
+		add(SearchKindUser, user.ID, user.Email, nil, searchField{"id", user.ID}, searchField{"name", user.Name}, searchField{"email", user.Email})
+	}
+	for _, invite := range invites {
+		add(SearchKindInvite, invite.ID, invite.Email, nil, searchField{"id", invite.ID}, searchField{"email", invite.Email})
+	}
+	for _, key := range adminKeys {
+		add(SearchKindAdminAPIKey, key.ID, key.Name, nil, searchField{"id", key.ID}, searchField{"name", key.Name}, searchField{"redacted_value", key.RedactedValue})
+	}
+	for _, cert := range certificates {
+		add(SearchKindCertificate, cert.ID, cert.Name, nil, searchField{"id", cert.ID}, searchField{"name", cert.Name})
+	}
+
+	inventory := make([]ProjectInventory, len(projects))
+	err := forEachConcurrently(len(projects), opts.Concurrency, func(i int) error {
+		item, err := collectProject(c, projects[i])
+		if err != nil {
+			return err
+		}
+		inventory[i] = *item
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range inventory {
+		project := &inventory[i].Project
+		for _, user := range inventory[i].Users {
+			add(SearchKindProjectUser, user.ID, user.Email, project, searchField{"id", user.ID}, searchField{"name", user.Name}, searchField{"email", user.Email})
+		}
+	}
+	for i := range inventory {
+		project := &inventory[i].Project
+		for _, account := range inventory[i].ServiceAccounts {
+			add(SearchKindServiceAccount, account.ID, account.Name, project, searchField{"id", account.ID}, searchField{"name", account.Name})
+		}
+	}
+	for i := range inventory {
+		project := &inventory[i].Project
+		for _, key := range inventory[i].APIKeys {
+			add(SearchKindAPIKey, key.ID, key.Name, project, searchField{"id", key.ID}, searchField{"name", key.Name}, searchField{"redacted_value", key.RedactedValue})
+		}
+	}
+	return matches, nil
+}
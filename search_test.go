@@ -0,0 +1,88 @@
+package openaiorgs
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// mockSearchOrg sets up an organization where "ops" appears in a project name, a
+// user's email, an invite, a service account, a project user and a certificate, and
+// "xY9z" in the redacted values of a project API key and an admin API key.
+func mockSearchOrg(h *testHelper) {
+	h.mockResponse("GET", ProjectsListEndpoint, 200, ListResponse[Project]{Object: "list", Data: []Project{
+		{ID: "proj_1", Name: "DevOps"},
+		{ID: "proj_2", Name: "Research"},
+	}})
+	h.mockResponse("GET", UsersListEndpoint, 200, ListResponse[User]{Object: "list", Data: []User{
+		{ID: "user_1", Name: "Ana", Email: "ops-lead@example.com"},
+		{ID: "user_2", Name: "Ben", Email: "ben@example.com"},
+	}})
+	h.mockResponse("GET", InviteListEndpoint, 200, ListResponse[Invite]{Object: "list", Data: []Invite{
+		{ID: "invite_1", Email: "new-ops@example.com"},
+	}})
+	h.mockResponse("GET", AdminAPIKeysEndpoint, 200, ListResponse[AdminAPIKey]{Object: "list", Data: []AdminAPIKey{
+		{ID: "key_admin", Name: "terraform", RedactedValue: "sk-admin-...xY9z"},
+	}})
+	h.mockResponse("GET", OrganizationCertificatesEndpoint, 200, ListResponse[Certificate]{Object: "list", Data: []Certificate{
+		{ID: "cert_1", Name: "ops-ca"},
+	}})
+	mockProjectInventory(h, "proj_1",
+		[]ProjectUser{{ID: "user_1", Name: "Ana", Email: "ops-lead@example.com", Role: "owner"}},
+		[]ProjectServiceAccount{{ID: "svc_1", Name: "ops-bot"}},
+		[]ProjectApiKey{{ID: "key_1", Name: "deploy", RedactedValue: "sk-proj-...XY9Z"}})
+	mockProjectInventory(h, "proj_2",
+		[]ProjectUser{{ID: "user_2", Name: "Ben", Email: "ben@example.com", Role: "member"}},
+		nil,
+		[]ProjectApiKey{{ID: "key_2", Name: "notebook", RedactedValue: "sk-proj-...abcd"}})
+}
+
+func TestSearch(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+	mockSearchOrg(h)
+
+	matches, err := Search(h.client, "OPS", SearchOptions{})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	var got []string
+	for _, m := range matches {
+		got = append(got, m.Kind+":"+m.ID+":"+m.Field+":"+m.ProjectID)
+	}
+	want := []string{
+		"project:proj_1:name:",
+		"user:user_1:email:",
+		"invite:invite_1:email:",
+		"certificate:cert_1:name:",
+		"project_user:user_1:email:proj_1",
+		"service_account:svc_1:name:proj_1",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("matches = %v, want %v", got, want)
+	}
+
+	matches, err = Search(h.client, "xy9z", SearchOptions{})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(matches) != 2 || matches[0].Kind != SearchKindAdminAPIKey || matches[1].ID != "key_1" ||
+		matches[1].Field != "redacted_value" || matches[1].ProjectName != "DevOps" {
+		t.Errorf("unexpected key matches: %+v", matches)
+	}
+}
+
+func TestSearch_Errors(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+	mockSearchOrg(h)
+
+	if _, err := Search(h.client, "  ", SearchOptions{}); err == nil {
+		t.Error("expected error for an empty term")
+	}
+
+	h.mockResponse("GET", AdminAPIKeysEndpoint, 403, map[string]string{"error": "forbidden"})
+	if _, err := Search(h.client, "ops", SearchOptions{}); err == nil || !strings.Contains(err.Error(), "admin API keys") {
+		t.Errorf("expected admin API key error, got %v", err)
+	}
+}
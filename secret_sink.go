@@ -0,0 +1,329 @@
+package openaiorgs
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Secret sink kinds accepted by NewSecretSink.
+const (
+	SecretSinkFile       = "file"
+	SecretSinkDotenv     = "dotenv"
+	SecretSinkKubernetes = "k8s-secret"
+	SecretSinkVault      = "vault"
+)
+
+// DefaultSecretKey is the dotenv variable name, Kubernetes Secret data key or Vault
+// field used when a sink's Key is empty.
+const DefaultSecretKey = "OPENAI_API_KEY"
+
+// DefaultVaultMount is the KV v2 mount used when VaultKVSink.Mount is empty.
+const DefaultVaultMount = "secret"
+
+// DefaultVaultTimeout is the request timeout used when VaultKVSink.Timeout is not set.
+const DefaultVaultTimeout = 30 * time.Second
+
+// Secret is a newly issued credential that is only returned once by the API.
+type Secret struct {
+	// Name is the name of the resource the secret belongs to (e.g., the service account name).
+	Name string
+	// ID identifies the credential (e.g., the API key ID).
+	ID string
+	// Value is the secret itself.
+	Value string
+}
+
+// SecretSink stores a newly issued secret somewhere other than the terminal.
+type SecretSink interface {
+	// Store saves the secret and returns a reference to where it was stored.
+	// The reference never contains the secret value.
+	Store(secret Secret) (string, error)
+}
+
+// SecretSinkFunc adapts an ordinary function to the SecretSink interface.
+type SecretSinkFunc func(secret Secret) (string, error)
+
+// Store calls f(secret).
+func (f SecretSinkFunc) Store(secret Secret) (string, error) {
+	return f(secret)
+}
+
+// FileSink writes the raw secret value to a file with 0600 permissions.
+type FileSink struct {
+	Path string
+}
+
+// Store implements SecretSink.
+func (s *FileSink) Store(secret Secret) (string, error) {
+	if err := writeSecretFile(s.Path, []byte(secret.Value+"\n")); err != nil {
+		return "", err
+	}
+	return s.Path, nil
+}
+
+// DotenvSink writes the secret as a KEY="value" line to a dotenv file with 0600
+// permissions. Other lines in an existing file are kept; an existing assignment
+// of Key is replaced in place, otherwise the line is appended.
+type DotenvSink struct {
+	Path string
+	// Key is the variable name. Defaults to DefaultSecretKey.
+	Key string
+}
+
+// Store implements SecretSink.
+func (s *DotenvSink) Store(secret Secret) (string, error) {
+	existing, err := os.ReadFile(s.Path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("failed to read %s: %w", s.Path, err)
+	}
+	key := secretKey(s.Key)
+	data := setDotenvVariable(string(existing), key, key+"="+strconv.Quote(secret.Value))
+	if err := writeSecretFile(s.Path, []byte(data)); err != nil {
+		return "", err
+	}
+	return s.Path, nil
+}
+
+// setDotenvVariable replaces the first assignment of key in contents with line,
+// dropping any later assignments, or appends line if key is not assigned.
+func setDotenvVariable(contents, key, line string) string {
+	var lines []string
+	if contents != "" {
+		lines = strings.Split(strings.TrimSuffix(contents, "\n"), "\n")
+	}
+	out := make([]string, 0, len(lines)+1)
+	replaced := false
+	for _, l := range lines {
+		assignment := strings.TrimPrefix(strings.TrimSpace(l), "export ")
+		if strings.HasPrefix(strings.TrimSpace(assignment), key+"=") {
+			if !replaced {
+				out = append(out, line)
+				replaced = true
+			}
+			continue
+		}
+		out = append(out, l)
+	}
+	if !replaced {
+		out = append(out, line)
+	}
+	return strings.Join(out, "\n") + "\n"
+}
+
+// KubernetesSecretSink writes an Opaque Secret manifest, ready for kubectl apply,
+// to a file with 0600 permissions.
+type KubernetesSecretSink struct {
+	Path string
+	// SecretName is the Secret's name. Defaults to the secret's Name converted with KubernetesName.
+	SecretName string
+	// Namespace is the optional namespace of the Secret.
+	Namespace string
+	// Key is the data key. Defaults to DefaultSecretKey.
+	Key string
+}
+
+// Store implements SecretSink.
+func (s *KubernetesSecretSink) Store(secret Secret) (string, error) {
+	name := s.SecretName
+	if name == "" {
+		name = KubernetesName(secret.Name)
+	}
+	if name == "" {
+		return "", fmt.Errorf("a secret name is required")
+	}
+
+	var b strings.Builder
+	b.WriteString("apiVersion: v1\nkind: Secret\nmetadata:\n")
+	fmt.Fprintf(&b, "  name: %s\n", name)
+	if s.Namespace != "" {
+		fmt.Fprintf(&b, "  namespace: %s\n", s.Namespace)
+	}
+	b.WriteString("type: Opaque\nstringData:\n")
+	// A JSON string is a valid YAML double-quoted scalar.
+	fmt.Fprintf(&b, "  %s: %s\n", secretKey(s.Key), strconv.Quote(secret.Value))
+
+	if err := writeSecretFile(s.Path, []byte(b.String())); err != nil {
+		return "", err
+	}
+	return s.Path, nil
+}
+
+// VaultKVSink writes the secret to a HashiCorp Vault KV version 2 secrets engine.
+// Each Store creates a new version of the secret at Path.
+type VaultKVSink struct {
+	// Address is the Vault server URL, e.g. https://vault.example.com:8200.
+	Address string
+	// Token is the Vault token used for the X-Vault-Token header.
+	Token string
+	// Namespace is the optional Vault Enterprise namespace.
+	Namespace string
+	// Mount is the KV v2 mount path. Defaults to DefaultVaultMount.
+	Mount string
+	// Path is the secret path within the mount.
+	Path string
+	// Key is the field the value is stored under. Defaults to DefaultSecretKey.
+	Key string
+	// Timeout limits the write request. Defaults to DefaultVaultTimeout.
+	Timeout time.Duration
+}
+
+type vaultWriteResponse struct {
+	Data struct {
+		Version int `json:"version"`
+	} `json:"data"`
+}
+
+// Store implements SecretSink. The reference has the form vault:<mount>/<path>#<key>@v<version>.
+func (s *VaultKVSink) Store(secret Secret) (string, error) {
+	if s.Address == "" || s.Token == "" || s.Path == "" {
+		return "", fmt.Errorf("vault address, token and path are required")
+	}
+	mount := strings.Trim(s.Mount, "/")
+	if mount == "" {
+		mount = DefaultVaultMount
+	}
+	path := strings.Trim(s.Path, "/")
+	key := secretKey(s.Key)
+
+	timeout := s.Timeout
+	if timeout <= 0 {
+		timeout = DefaultVaultTimeout
+	}
+	req := resty.New().SetTimeout(timeout).R().
+		SetHeader("X-Vault-Token", s.Token).
+		SetBody(map[string]any{"data": map[string]string{key: secret.Value}}).
+		SetResult(&vaultWriteResponse{})
+	if s.Namespace != "" {
+		req.SetHeader("X-Vault-Namespace", s.Namespace)
+	}
+	resp, err := req.Post(strings.TrimRight(s.Address, "/") + "/v1/" + mount + "/data/" + path)
+	if err != nil {
+		return "", fmt.Errorf("failed to write secret to vault: %w", err)
+	}
+	if resp.IsError() {
+		return "", fmt.Errorf("failed to write secret to vault: status %d: %s", resp.StatusCode(), strings.TrimSpace(string(resp.Body())))
+	}
+
+	ref := fmt.Sprintf("vault:%s/%s#%s", mount, path, key)
+	if result, ok := resp.Result().(*vaultWriteResponse); ok && result.Data.Version > 0 {
+		ref += fmt.Sprintf("@v%d", result.Data.Version)
+	}
+	return ref, nil
+}
+
+// SecretSinkConfig describes a sink in a form that is easy to fill from flags or tool parameters.
+type SecretSinkConfig struct {
+	// Kind is one of SecretSinkFile, SecretSinkDotenv, SecretSinkKubernetes or SecretSinkVault.
+	Kind string
+	// Path is the file path, or the secret path within the Vault mount.
+	Path string
+	// Key is the dotenv variable, Secret data key or Vault field.
+	Key string
+	// SecretName and Namespace configure Kubernetes Secret manifests.
+	SecretName string
+	Namespace  string
+	// VaultAddress, VaultToken, VaultNamespace and VaultMount configure the Vault sink.
+	VaultAddress   string
+	VaultToken     string
+	VaultNamespace string
+	VaultMount     string
+}
+
+// NewSecretSink builds the sink described by cfg.
+func NewSecretSink(cfg SecretSinkConfig) (SecretSink, error) {
+	if cfg.Path == "" {
+		return nil, fmt.Errorf("a path is required for the %s secret sink", cfg.Kind)
+	}
+	switch cfg.Kind {
+	case SecretSinkFile:
+		return &FileSink{Path: cfg.Path}, nil
+	case SecretSinkDotenv:
+		return &DotenvSink{Path: cfg.Path, Key: cfg.Key}, nil
+	case SecretSinkKubernetes:
+		return &KubernetesSecretSink{Path: cfg.Path, SecretName: cfg.SecretName, Namespace: cfg.Namespace, Key: cfg.Key}, nil
+	case SecretSinkVault:
+		if cfg.VaultAddress == "" || cfg.VaultToken == "" {
+			return nil, fmt.Errorf("the vault secret sink requires a Vault address and token")
+		}
+		return &VaultKVSink{
+			Address:   cfg.VaultAddress,
+			Token:     cfg.VaultToken,
+			Namespace: cfg.VaultNamespace,
+			Mount:     cfg.VaultMount,
+			Path:      cfg.Path,
+			Key:       cfg.Key,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown secret sink %q (valid sinks: %s, %s, %s, %s)", cfg.Kind, SecretSinkFile, SecretSinkDotenv, SecretSinkKubernetes, SecretSinkVault)
+	}
+}
+
+// RedactSecret returns a form of value that is safe to print: the first six and
+// last four characters, or asterisks for short values.
+func RedactSecret(value string) string {
+	if len(value) <= 12 {
+		return strings.Repeat("*", len(value))
+	}
+	return value[:6] + "..." + value[len(value)-4:]
+}
+
+// KubernetesName converts s to a valid Kubernetes object name: lowercase
+// alphanumerics and '-', starting and ending with an alphanumeric.
+func KubernetesName(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	name := strings.TrimRight(b.String(), "-")
+	if len(name) > 253 {
+		name = strings.TrimRight(name[:253], "-")
+	}
+	return name
+}
+
+func secretKey(key string) string {
+	if key == "" {
+		return DefaultSecretKey
+	}
+	return key
+}
+
+// writeSecretFile writes data to path with 0600 permissions. The data is written to a
+// temporary file first and renamed into place, so readers never see a partial secret.
+func writeSecretFile(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create secret file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to set secret file permissions: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write secret file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write secret file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write secret file: %w", err)
+	}
+	return nil
+}
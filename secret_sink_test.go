@@ -0,0 +1,224 @@
+package openaiorgs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testSecret = Secret{Name: "CI Deployer", ID: "key_1", Value: "sk-svcacct-abcdefghijkl"}
+
+func readSecretFile(t *testing.T, path string) string {
+	t.Helper()
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("expected 0600 permissions, got %o", perm)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestFileSinks(t *testing.T) {
+	tests := []struct {
+		name string
+		sink func(path string) SecretSink
+		want string
+	}{
+		{
+			name: "file",
+			sink: func(path string) SecretSink { return &FileSink{Path: path} },
+			want: "sk-svcacct-abcdefghijkl\n",
+		},
+		{
+			name: "dotenv",
+			sink: func(path string) SecretSink { return &DotenvSink{Path: path, Key: "DEPLOY_KEY"} },
+			// Existing dotenv lines are kept, so the old contents stay above the new variable.
+			want: "old\nDEPLOY_KEY=\"sk-svcacct-abcdefghijkl\"\n",
+		},
+		{
+			name: "kubernetes",
+			sink: func(path string) SecretSink { return &KubernetesSecretSink{Path: path, Namespace: "ci"} },
+			want: "apiVersion: v1\nkind: Secret\nmetadata:\n  name: ci-deployer\n  namespace: ci\ntype: Opaque\nstringData:\n  OPENAI_API_KEY: \"sk-svcacct-abcdefghijkl\"\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "secret")
+			// An existing, more permissive file is replaced.
+			if err := os.WriteFile(path, []byte("old"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			ref, err := tt.sink(path).Store(testSecret)
+			if err != nil {
+				t.Fatalf("Store() error = %v", err)
+			}
+			if ref != path {
+				t.Errorf("Store() reference = %q, want %q", ref, path)
+			}
+			if got := readSecretFile(t, path); got != tt.want {
+				t.Errorf("file contents = %q, want %q", got, tt.want)
+			}
+			if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 1 {
+				t.Errorf("expected temporary files to be cleaned up, found %d entries", len(entries))
+			}
+		})
+	}
+}
+
+func TestDotenvSink_KeepsOtherVariables(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	existing := "# deploy settings\nREGION=us-east-1\nexport OPENAI_API_KEY=\"sk-old\"\nDEBUG=true\nOPENAI_API_KEY=duplicate\n"
+	if err := os.WriteFile(path, []byte(existing), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := (&DotenvSink{Path: path}).Store(testSecret); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+	want := "# deploy settings\nREGION=us-east-1\nOPENAI_API_KEY=\"sk-svcacct-abcdefghijkl\"\nDEBUG=true\n"
+	if got := readSecretFile(t, path); got != want {
+		t.Errorf("file contents = %q, want %q", got, want)
+	}
+
+	// A file without the variable gets it appended.
+	if _, err := (&DotenvSink{Path: path, Key: "DEPLOY_KEY"}).Store(testSecret); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+	want += "DEPLOY_KEY=\"sk-svcacct-abcdefghijkl\"\n"
+	if got := readSecretFile(t, path); got != want {
+		t.Errorf("file contents = %q, want %q", got, want)
+	}
+}
+
+func TestVaultKVSink(t *testing.T) {
+	var gotPath, gotToken, gotNamespace string
+	var gotBody map[string]map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotToken = r.Header.Get("X-Vault-Token")
+		gotNamespace = r.Header.Get("X-Vault-Namespace")
+		if err := json.NewDecoder(r.Body).Decode(&gotBody); err != nil {
+			t.Errorf("invalid request body: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": {"created_time": "2024-01-01T00:00:00Z", "version": 3}}`))
+	}))
+	defer server.Close()
+
+	sink := &VaultKVSink{Address: server.URL + "/", Token: "s.token", Namespace: "team", Path: "/openai/deployer/"}
+	ref, err := sink.Store(testSecret)
+	if err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+	if ref != "vault:secret/openai/deployer#OPENAI_API_KEY@v3" {
+		t.Errorf("unexpected reference %q", ref)
+	}
+	if strings.Contains(ref, testSecret.Value) {
+		t.Error("reference must not contain the secret")
+	}
+	if gotPath != "/v1/secret/data/openai/deployer" || gotToken != "s.token" || gotNamespace != "team" {
+		t.Errorf("unexpected request: path=%s token=%s namespace=%s", gotPath, gotToken, gotNamespace)
+	}
+	if gotBody["data"]["OPENAI_API_KEY"] != testSecret.Value {
+		t.Errorf("unexpected request body: %v", gotBody)
+	}
+}
+
+func TestVaultKVSink_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"errors": ["permission denied"]}`, http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	_, err := (&VaultKVSink{Address: server.URL, Token: "bad", Path: "x"}).Store(testSecret)
+	if err == nil || !strings.Contains(err.Error(), "permission denied") {
+		t.Fatalf("expected permission error, got %v", err)
+	}
+}
+
+func TestVaultKVSink_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	_, err := (&VaultKVSink{Address: server.URL, Token: "t", Path: "x", Timeout: 20 * time.Millisecond}).Store(testSecret)
+	if err == nil || !strings.Contains(err.Error(), "failed to write secret to vault") {
+		t.Fatalf("expected timeout error, got %v", err)
+	}
+}
+
+func TestNewSecretSink(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     SecretSinkConfig
+		want    any
+		wantErr bool
+	}{
+		{name: "file", cfg: SecretSinkConfig{Kind: SecretSinkFile, Path: "key"}, want: &FileSink{}},
+		{name: "dotenv", cfg: SecretSinkConfig{Kind: SecretSinkDotenv, Path: ".env"}, want: &DotenvSink{}},
+		{name: "kubernetes", cfg: SecretSinkConfig{Kind: SecretSinkKubernetes, Path: "secret.yaml"}, want: &KubernetesSecretSink{}},
+		{name: "vault", cfg: SecretSinkConfig{Kind: SecretSinkVault, Path: "app", VaultAddress: "http://vault", VaultToken: "t"}, want: &VaultKVSink{}},
+		{name: "vault without token", cfg: SecretSinkConfig{Kind: SecretSinkVault, Path: "app", VaultAddress: "http://vault"}, wantErr: true},
+		{name: "missing path", cfg: SecretSinkConfig{Kind: SecretSinkFile}, wantErr: true},
+		{name: "unknown", cfg: SecretSinkConfig{Kind: "s3", Path: "x"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink, err := NewSecretSink(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewSecretSink() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if gotType, wantType := fmt.Sprintf("%T", sink), fmt.Sprintf("%T", tt.want); gotType != wantType {
+				t.Errorf("NewSecretSink() = %s, want %s", gotType, wantType)
+			}
+		})
+	}
+}
+
+func TestRedactSecret(t *testing.T) {
+	tests := map[string]string{
+		"sk-svcacct-abcdefghijkl": "sk-svc...ijkl",
+		"short":                   "*****",
+		"":                        "",
+	}
+	for input, want := range tests {
+		if got := RedactSecret(input); got != want {
+			t.Errorf("RedactSecret(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestKubernetesName(t *testing.T) {
+	tests := map[string]string{
+		"deployer":         "deployer",
+		"CI Deploy Bot":    "ci-deploy-bot",
+		"--svc__account--": "svc-account",
+		"prod/api.key#1":   "prod-api-key-1",
+	}
+	for input, want := range tests {
+		if got := KubernetesName(input); got != want {
+			t.Errorf("KubernetesName(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
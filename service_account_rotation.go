@@ -0,0 +1,175 @@
+package openaiorgs
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Service account rotation statuses.
+const (
+	// RotationStatusCompleted means the key was stored and the old account deleted.
+	RotationStatusCompleted = "completed"
+	// RotationStatusOldAccountKept means the key was stored but the old account was
+	// left in place and must be deleted later.
+	RotationStatusOldAccountKept = "old_account_kept"
+	// RotationStatusFailed means the rotation stopped because of an error.
+	RotationStatusFailed = "failed"
+)
+
+// ServiceAccountRotation is one entry in a rotation log.
+type ServiceAccountRotation struct {
+	Time                time.Time `json:"time"`
+	ProjectID           string    `json:"project_id"`
+	Name                string    `json:"name"`
+	OldServiceAccountID string    `json:"old_service_account_id,omitempty"`
+	NewServiceAccountID string    `json:"new_service_account_id,omitempty"`
+	NewAPIKeyID         string    `json:"new_api_key_id,omitempty"`
+	Destination         string    `json:"destination,omitempty"`
+	Status              string    `json:"status"`
+	Error               string    `json:"error,omitempty"`
+}
+
+// ServiceAccountRotationOptions controls RotateServiceAccount.
+type ServiceAccountRotationOptions struct {
+	// Sink receives the replacement account's API key. It is required.
+	Sink SecretSink
+	// BeforeDelete is called once the key has been stored, before the old account
+	// is deleted. Returning false keeps the old account. A nil BeforeDelete deletes
+	// the old account immediately.
+	BeforeDelete func(old, replacement *ProjectServiceAccount) bool
+	// ServiceAccountID selects the account to replace instead of looking it up by
+	// name. It is needed when several accounts share the name, for example after
+	// an earlier rotation kept its old account. The account must have the given name.
+	ServiceAccountID string
+}
+
+// FindServiceAccountByName returns the project service account with the given name.
+// It is an error if no account or more than one account has that name.
+func FindServiceAccountByName(c OpenAIOrgsClient, projectID, name string) (*ProjectServiceAccount, error) {
+	accounts, err := ListAll(func(after string) (*ListResponse[ProjectServiceAccount], error) {
+		return c.ListProjectServiceAccounts(projectID, 100, after)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service accounts: %w", err)
+	}
+
+	var matches []ProjectServiceAccount
+	for _, account := range accounts {
+		if account.Name == name {
+			matches = append(matches, account)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no service account named %q in project %s", name, projectID)
+	case 1:
+		return &matches[0], nil
+	default:
+		ids := make([]string, len(matches))
+		for i, match := range matches {
+			ids[i] = match.ID
+		}
+		return nil, fmt.Errorf("%d service accounts named %q in project %s (%s); select one by ID", len(matches), name, projectID, strings.Join(ids, ", "))
+	}
+}
+
+// RotateServiceAccount replaces the service account with the given name by a new
+// account of the same name, stores the new API key in opts.Sink, and then deletes
+// the old account. If storing the key fails the new account is deleted again so that no
+// unstored key is left active, and the old account is kept.
+//
+// The old account is looked up by name unless opts.ServiceAccountID is set. When the
+// old account is kept, the project has two accounts with the same name until it is
+// deleted, so a later rotation must pass opts.ServiceAccountID to pick one.
+//
+// The returned record describes the outcome and is suitable for a rotation log; it is
+// returned even when err is non-nil, unless the old account could not be found.
+func RotateServiceAccount(c OpenAIOrgsClient, projectID, name string, opts ServiceAccountRotationOptions) (*ServiceAccountRotation, error) {
+	if opts.Sink == nil {
+		return nil, fmt.Errorf("a secret sink is required")
+	}
+
+	var old *ProjectServiceAccount
+	var err error
+	if opts.ServiceAccountID != "" {
+		old, err = c.RetrieveProjectServiceAccount(projectID, opts.ServiceAccountID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve service account %s: %w", opts.ServiceAccountID, err)
+		}
+		if old.Name != name {
+			return nil, fmt.Errorf("service account %s is named %q, not %q", old.ID, old.Name, name)
+		}
+	} else {
+		old, err = FindServiceAccountByName(c, projectID, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	record := &ServiceAccountRotation{
+		Time:                time.Now().UTC(),
+		ProjectID:           projectID,
+		Name:                name,
+		OldServiceAccountID: old.ID,
+		Status:              RotationStatusFailed,
+	}
+	fail := func(err error) (*ServiceAccountRotation, error) {
+		record.Error = err.Error()
+		return record, err
+	}
+
+	replacement, err := c.CreateProjectServiceAccount(projectID, name)
+	if err != nil {
+		return fail(fmt.Errorf("failed to create replacement service account: %w", err))
+	}
+	record.NewServiceAccountID = replacement.ID
+	if replacement.APIKey == nil || replacement.APIKey.Value == "" {
+		return fail(fmt.Errorf("replacement service account %s was created without an API key", replacement.ID))
+	}
+	record.NewAPIKeyID = replacement.APIKey.ID
+
+	destination, err := opts.Sink.Store(Secret{
+		Name:  replacement.Name,
+		ID:    replacement.APIKey.ID,
+		Value: replacement.APIKey.Value,
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to store new API key: %w", err)
+		if delErr := c.DeleteProjectServiceAccount(projectID, replacement.ID); delErr != nil {
+			err = fmt.Errorf("%w; also failed to delete replacement service account %s: %v", err, replacement.ID, delErr)
+		}
+		return fail(err)
+	}
+	record.Destination = destination
+
+	if opts.BeforeDelete != nil && !opts.BeforeDelete(old, replacement) {
+		record.Status = RotationStatusOldAccountKept
+		return record, nil
+	}
+	if err := c.DeleteProjectServiceAccount(projectID, old.ID); err != nil {
+		return fail(fmt.Errorf("failed to delete old service account %s: %w", old.ID, err))
+	}
+	record.Status = RotationStatusCompleted
+	return record, nil
+}
+
+// AppendRotationLog appends record as one JSON line to the log file at path,
+// creating it with 0600 permissions if needed.
+func AppendRotationLog(path string, record *ServiceAccountRotation) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal rotation record: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open rotation log: %w", err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write rotation log: %w", err)
+	}
+	return f.Close()
+}
@@ -0,0 +1,188 @@
+package openaiorgs
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const rotationAccountsEndpoint = "/organization/projects/proj_1/service_accounts"
+
+func mockRotationAccounts(h *testHelper, accounts ...ProjectServiceAccount) {
+	h.mockResponse("GET", rotationAccountsEndpoint, 200, ListResponse[ProjectServiceAccount]{Object: "list", Data: accounts})
+}
+
+func mockReplacementAccount(h *testHelper) {
+	h.mockResponse("POST", rotationAccountsEndpoint, 200, ProjectServiceAccount{
+		ID:     "svc_new",
+		Name:   "deployer",
+		APIKey: &ProjectServiceAccountAPIKey{ID: "key_new", Value: "sk-new"},
+	})
+}
+
+func TestFindServiceAccountByName(t *testing.T) {
+	tests := []struct {
+		name     string
+		accounts []ProjectServiceAccount
+		wantID   string
+		wantErr  string
+	}{
+		{name: "single match", accounts: []ProjectServiceAccount{{ID: "svc_1", Name: "deployer"}, {ID: "svc_2", Name: "other"}}, wantID: "svc_1"},
+		{name: "no match", accounts: []ProjectServiceAccount{{ID: "svc_2", Name: "other"}}, wantErr: "no service account named"},
+		{name: "ambiguous", accounts: []ProjectServiceAccount{{ID: "svc_1", Name: "deployer"}, {ID: "svc_3", Name: "deployer"}}, wantErr: "svc_1, svc_3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestHelper(t)
+			defer h.cleanup()
+			mockRotationAccounts(h, tt.accounts...)
+
+			account, err := FindServiceAccountByName(h.client, "proj_1", "deployer")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil || account.ID != tt.wantID {
+				t.Fatalf("FindServiceAccountByName() = %v, %v; want %s", account, err, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestRotateServiceAccount(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+	mockRotationAccounts(h, ProjectServiceAccount{ID: "svc_old", Name: "deployer"})
+	mockReplacementAccount(h)
+	h.mockResponse("DELETE", rotationAccountsEndpoint+"/svc_old", 200, map[string]any{"deleted": true})
+
+	var delivered string
+	record, err := RotateServiceAccount(h.client, "proj_1", "deployer", ServiceAccountRotationOptions{
+		Sink: SecretSinkFunc(func(secret Secret) (string, error) {
+			delivered = secret.Value
+			return "secret.env", nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("RotateServiceAccount() error = %v", err)
+	}
+	if delivered != "sk-new" {
+		t.Errorf("expected new key to be delivered, got %q", delivered)
+	}
+	if record.Status != RotationStatusCompleted || record.OldServiceAccountID != "svc_old" || record.NewServiceAccountID != "svc_new" || record.NewAPIKeyID != "key_new" || record.Destination != "secret.env" {
+		t.Errorf("unexpected record: %+v", record)
+	}
+	h.assertRequest("DELETE", rotationAccountsEndpoint+"/svc_old", 1)
+}
+
+func TestRotateServiceAccount_KeepsOldAccount(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+	mockRotationAccounts(h, ProjectServiceAccount{ID: "svc_old", Name: "deployer"})
+	mockReplacementAccount(h)
+
+	record, err := RotateServiceAccount(h.client, "proj_1", "deployer", ServiceAccountRotationOptions{
+		Sink:         SecretSinkFunc(func(Secret) (string, error) { return "secret.env", nil }),
+		BeforeDelete: func(old, replacement *ProjectServiceAccount) bool { return false },
+	})
+	if err != nil {
+		t.Fatalf("RotateServiceAccount() error = %v", err)
+	}
+	if record.Status != RotationStatusOldAccountKept {
+		t.Errorf("expected old account to be kept, got %+v", record)
+	}
+	h.assertRequest("DELETE", rotationAccountsEndpoint+"/svc_old", 0)
+}
+
+func TestRotateServiceAccount_ByID(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+	// An earlier rotation kept svc_old, so two accounts are named "deployer".
+	mockRotationAccounts(h, ProjectServiceAccount{ID: "svc_old", Name: "deployer"}, ProjectServiceAccount{ID: "svc_current", Name: "deployer"})
+	h.mockResponse("GET", rotationAccountsEndpoint+"/svc_current", 200, ProjectServiceAccount{ID: "svc_current", Name: "deployer"})
+	h.mockResponse("GET", rotationAccountsEndpoint+"/svc_other", 200, ProjectServiceAccount{ID: "svc_other", Name: "other"})
+	mockReplacementAccount(h)
+	h.mockResponse("DELETE", rotationAccountsEndpoint+"/svc_current", 200, map[string]any{"deleted": true})
+	sink := SecretSinkFunc(func(Secret) (string, error) { return "secret.env", nil })
+
+	if _, err := RotateServiceAccount(h.client, "proj_1", "deployer", ServiceAccountRotationOptions{Sink: sink}); err == nil || !strings.Contains(err.Error(), "select one by ID") {
+		t.Fatalf("expected ambiguous name error, got %v", err)
+	}
+
+	record, err := RotateServiceAccount(h.client, "proj_1", "deployer", ServiceAccountRotationOptions{Sink: sink, ServiceAccountID: "svc_current"})
+	if err != nil {
+		t.Fatalf("RotateServiceAccount() error = %v", err)
+	}
+	if record.OldServiceAccountID != "svc_current" || record.Status != RotationStatusCompleted {
+		t.Errorf("unexpected record: %+v", record)
+	}
+	h.assertRequest("DELETE", rotationAccountsEndpoint+"/svc_current", 1)
+	h.assertRequest("DELETE", rotationAccountsEndpoint+"/svc_old", 0)
+
+	if _, err := RotateServiceAccount(h.client, "proj_1", "deployer", ServiceAccountRotationOptions{Sink: sink, ServiceAccountID: "svc_other"}); err == nil || !strings.Contains(err.Error(), `is named "other"`) {
+		t.Errorf("expected name mismatch error, got %v", err)
+	}
+	h.assertRequest("POST", rotationAccountsEndpoint, 1)
+}
+
+func TestRotateServiceAccount_DeliveryFailureRollsBack(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+	mockRotationAccounts(h, ProjectServiceAccount{ID: "svc_old", Name: "deployer"})
+	mockReplacementAccount(h)
+	h.mockResponse("DELETE", rotationAccountsEndpoint+"/svc_new", 200, map[string]any{"deleted": true})
+
+	record, err := RotateServiceAccount(h.client, "proj_1", "deployer", ServiceAccountRotationOptions{
+		Sink: SecretSinkFunc(func(Secret) (string, error) { return "", errors.New("disk full") }),
+	})
+	if err == nil || !strings.Contains(err.Error(), "disk full") {
+		t.Fatalf("expected delivery error, got %v", err)
+	}
+	if record == nil || record.Status != RotationStatusFailed || record.Error == "" {
+		t.Errorf("unexpected record: %+v", record)
+	}
+	h.assertRequest("DELETE", rotationAccountsEndpoint+"/svc_new", 1)
+	h.assertRequest("DELETE", rotationAccountsEndpoint+"/svc_old", 0)
+}
+
+func TestAppendRotationLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rotations.jsonl")
+	for _, id := range []string{"svc_1", "svc_2"} {
+		if err := AppendRotationLog(path, &ServiceAccountRotation{ProjectID: "proj_1", NewServiceAccountID: id, Status: RotationStatusCompleted}); err != nil {
+			t.Fatalf("AppendRotationLog() error = %v", err)
+		}
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("expected 0600 permissions, got %o", perm)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var ids []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var record ServiceAccountRotation
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("invalid log line %q: %v", scanner.Text(), err)
+		}
+		ids = append(ids, record.NewServiceAccountID)
+	}
+	if strings.Join(ids, ",") != "svc_1,svc_2" {
+		t.Errorf("unexpected log entries: %v", ids)
+	}
+}
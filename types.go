@@ -3,6 +3,7 @@ package openaiorgs
 import (
 	"fmt"
 	"strings"
+	"unicode"
 )
 
 // ListResponse is a generic container for paginated API responses.
@@ -100,6 +101,51 @@ func ParseRoleType(s string) RoleType {
 	}
 }
 
+// ProjectAssignment pairs a project with the role a user should hold in it.
+type ProjectAssignment struct {
+	// ProjectID is the ID of the project.
+	ProjectID string `json:"id"`
+	// Role is the project role (e.g., "owner" or "member").
+	Role string `json:"role"`
+}
+
+// ParseProjectAssignment parses a "project_id:role" pair.
+func ParseProjectAssignment(s string) (ProjectAssignment, error) {
+	projectID, role, ok := strings.Cut(strings.TrimSpace(s), ":")
+	projectID, role = strings.TrimSpace(projectID), strings.TrimSpace(role)
+	if !ok || projectID == "" || role == "" {
+		return ProjectAssignment{}, fmt.Errorf("invalid project assignment %q, expected project_id:role", s)
+	}
+	return ProjectAssignment{ProjectID: projectID, Role: role}, nil
+}
+
+// ParseProjectAssignments parses a list of "project_id:role" pairs separated by
+// semicolons, commas or whitespace. An empty string yields no assignments.
+func ParseProjectAssignments(s string) ([]ProjectAssignment, error) {
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ';' || r == ',' || unicode.IsSpace(r)
+	})
+	assignments := make([]ProjectAssignment, 0, len(fields))
+	for _, field := range fields {
+		assignment, err := ParseProjectAssignment(field)
+		if err != nil {
+			return nil, err
+		}
+		assignments = append(assignments, assignment)
+	}
+	return assignments, nil
+}
+
+// FormatProjectAssignments renders assignments as semicolon-separated "project_id:role"
+// pairs, the form accepted by ParseProjectAssignments.
+func FormatProjectAssignments(assignments []ProjectAssignment) string {
+	parts := make([]string, len(assignments))
+	for i, assignment := range assignments {
+		parts[i] = assignment.ProjectID + ":" + assignment.Role
+	}
+	return strings.Join(parts, ";")
+}
+
 // String returns a human-readable string representation of the Owner.
 // It includes basic metadata and owner-specific information based on the owner type.
 func (o *Owner) String() string {
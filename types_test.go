@@ -1,6 +1,7 @@
 package openaiorgs
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -50,3 +51,57 @@ func TestListResponse_String(t *testing.T) {
 		}
 	}
 }
+
+func TestParseProjectAssignments(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []ProjectAssignment
+		wantErr bool
+	}{
+		{name: "empty", input: "", want: []ProjectAssignment{}},
+		{name: "single", input: "proj_a:owner", want: []ProjectAssignment{{"proj_a", "owner"}}},
+		{
+			name:  "commas and spaces",
+			input: "proj_a:owner,proj_b:member proj_c:member",
+			want:  []ProjectAssignment{{"proj_a", "owner"}, {"proj_b", "member"}, {"proj_c", "member"}},
+		},
+		{
+			name:  "semicolons",
+			input: "proj_a:owner; proj_b:member;",
+			want:  []ProjectAssignment{{"proj_a", "owner"}, {"proj_b", "member"}},
+		},
+		{name: "missing role", input: "proj_a:", wantErr: true},
+		{name: "missing separator", input: "proj_a", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseProjectAssignments(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseProjectAssignments() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseProjectAssignments() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatProjectAssignments(t *testing.T) {
+	assignments := []ProjectAssignment{{"proj_a", "owner"}, {"proj_b", "member"}}
+	formatted := FormatProjectAssignments(assignments)
+	if formatted != "proj_a:owner;proj_b:member" {
+		t.Errorf("FormatProjectAssignments() = %q", formatted)
+	}
+	parsed, err := ParseProjectAssignments(formatted)
+	if err != nil || !reflect.DeepEqual(parsed, assignments) {
+		t.Errorf("round trip = %v, %v", parsed, err)
+	}
+	if got := FormatProjectAssignments(nil); got != "" {
+		t.Errorf("FormatProjectAssignments(nil) = %q, want empty", got)
+	}
+}
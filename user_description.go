@@ -0,0 +1,231 @@
+package openaiorgs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultUserActivityWindow is the period covered by the audit events and usage in a
+// UserDescription when UserDescriptionOptions.Window is not set.
+const DefaultUserActivityWindow = 30 * 24 * time.Hour
+
+// DefaultUserAuditEvents is the number of recent audit events included in a
+// UserDescription when UserDescriptionOptions.AuditEvents is not set.
+const DefaultUserAuditEvents = 20
+
+// UserDescriptionOptions configures DescribeUser.
+type UserDescriptionOptions struct {
+	// Window is the period covered by audit events and usage. Defaults to DefaultUserActivityWindow.
+	Window time.Duration
+	// AuditEvents is the maximum number of audit events returned. Defaults to DefaultUserAuditEvents.
+	AuditEvents int
+	// Concurrency is the number of projects fetched in parallel.
+	Concurrency int
+	// Now overrides the current time, mainly for tests. Defaults to time.Now.
+	Now time.Time
+}
+
+// UserProjectMembership is a project the user belongs to.
+type UserProjectMembership struct {
+	ProjectID   string      `json:"project_id"`
+	ProjectName string      `json:"project_name"`
+	Role        string      `json:"role"`
+	AddedAt     UnixSeconds `json:"added_at"`
+}
+
+// UserAPIKey is a project API key owned by the user.
+type UserAPIKey struct {
+	ProjectID     string      `json:"project_id"`
+	ProjectName   string      `json:"project_name"`
+	KeyID         string      `json:"key_id"`
+	KeyName       string      `json:"key_name"`
+	RedactedValue string      `json:"redacted_value"`
+	CreatedAt     UnixSeconds `json:"created_at"`
+}
+
+// UserProjectUsage is the completions and embeddings usage attributed to the user in
+// one project. ProjectName is empty for projects that are no longer listed, such as
+// archived ones.
+type UserProjectUsage struct {
+	ProjectID    string `json:"project_id"`
+	ProjectName  string `json:"project_name,omitempty"`
+	Requests     int    `json:"requests"`
+	InputTokens  int    `json:"input_tokens"`
+	OutputTokens int    `json:"output_tokens"`
+}
+
+// UserDescription is everywhere a user has access and what they have done recently.
+type UserDescription struct {
+	User        User                    `json:"user"`
+	Memberships []UserProjectMembership `json:"memberships"`
+	APIKeys     []UserAPIKey            `json:"api_keys"`
+	GeneratedAt time.Time               `json:"generated_at"`
+	Since       time.Time               `json:"since"`
+	// AuditEvents are the most recent events the user performed, newest first.
+	AuditEvents []AuditLog `json:"audit_events"`
+	// Usage is sorted by project ID.
+	Usage []UserProjectUsage `json:"usage"`
+}
+
+// DescribeUser finds the organization user by email (case-insensitive) when
+// idOrEmail contains an @, or by user ID otherwise. It then lists the user's project
+// memberships and the project API keys they own across every active project, their
+// most recent audit events, and their usage per project within the window. Projects,
+// audit events and usage are fetched concurrently.
+func DescribeUser(c OpenAIOrgsClient, idOrEmail string, opts UserDescriptionOptions) (*UserDescription, error) {
+	user, err := findUser(c, idOrEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	now := lifecycleNow(opts.Now)
+	window := opts.Window
+	if window <= 0 {
+		window = DefaultUserActivityWindow
+	}
+	limit := opts.AuditEvents
+	if limit <= 0 {
+		limit = DefaultUserAuditEvents
+	}
+	description := &UserDescription{User: *user, GeneratedAt: now, Since: now.Add(-window)}
+
+	base := map[string]string{
+		"start_time":   strconv.FormatInt(description.Since.Unix(), 10),
+		"end_time":     strconv.FormatInt(now.Unix(), 10),
+		"bucket_width": "1d",
+		"group_by":     "project_id",
+		"user_ids":     user.ID,
+		"limit":        "31",
+	}
+	// Each fetch writes to its own variable, since they run concurrently.
+	var inventory []ProjectInventory
+	completions := make(map[string]UserProjectUsage)
+	embeddings := make(map[string]UserProjectUsage)
+
+	fetches := []func() error{
+		func() error {
+			var err error
+			inventory, err = CollectProjectInventory(c, InventoryOptions{Concurrency: opts.Concurrency})
+			return err
+		},
+		func() error {
+			// Audit logs are returned newest first, so one page holds the latest events.
+			logs, err := c.ListAuditLogs(&AuditLogListParams{
+				ActorIDs:    []string{user.ID},
+				EffectiveAt: &EffectiveAt{Gte: description.Since.Unix()},
+				Limit:       limit,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to list audit logs: %w", err)
+			}
+			description.AuditEvents = logs.Data
+			return nil
+		},
+		func() error {
+			return forEachUsagePage(base, func(params map[string]string) (bool, string, error) {
+				resp, err := c.GetCompletionsUsage(params)
+				if err != nil {
+					return false, "", fmt.Errorf("failed to get completions usage: %w", err)
+				}
+				for _, bucket := range resp.Data {
+					for _, result := range bucket.Results {
+						addUserProjectUsage(completions, result.ProjectID, result.NumModelRequests, result.InputTokens, result.OutputTokens)
+					}
+				}
+				return resp.HasMore, resp.NextPage, nil
+			})
+		},
+		func() error {
+			return forEachUsagePage(base, func(params map[string]string) (bool, string, error) {
+				resp, err := c.GetEmbeddingsUsage(params)
+				if err != nil {
+					return false, "", fmt.Errorf("failed to get embeddings usage: %w", err)
+				}
+				for _, bucket := range resp.Data {
+					for _, result := range bucket.Results {
+						addUserProjectUsage(embeddings, result.ProjectID, result.NumModelRequests, result.InputTokens, 0)
+					}
+				}
+				return resp.HasMore, resp.NextPage, nil
+			})
+		},
+	}
+	if err := forEachConcurrently(len(fetches), len(fetches), func(i int) error { return fetches[i]() }); err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]string, len(inventory))
+	for _, project := range inventory {
+		names[project.Project.ID] = project.Project.Name
+		for _, member := range project.Users {
+			if member.ID == user.ID {
+				description.Memberships = append(description.Memberships, UserProjectMembership{
+					ProjectID:   project.Project.ID,
+					ProjectName: project.Project.Name,
+					Role:        member.Role,
+					AddedAt:     member.AddedAt,
+				})
+			}
+		}
+		for _, key := range project.APIKeys {
+			if key.Owner.User != nil && key.Owner.User.ID == user.ID {
+				description.APIKeys = append(description.APIKeys, UserAPIKey{
+					ProjectID:     project.Project.ID,
+					ProjectName:   project.Project.Name,
+					KeyID:         key.ID,
+					KeyName:       key.Name,
+					RedactedValue: key.RedactedValue,
+					CreatedAt:     key.CreatedAt,
+				})
+			}
+		}
+	}
+
+	for projectID, u := range embeddings {
+		addUserProjectUsage(completions, projectID, u.Requests, u.InputTokens, u.OutputTokens)
+	}
+	for _, projectID := range sortedKeys(completions) {
+		if u := completions[projectID]; u.Requests > 0 || u.InputTokens > 0 || u.OutputTokens > 0 {
+			u.ProjectName = names[projectID]
+			description.Usage = append(description.Usage, u)
+		}
+	}
+	return description, nil
+}
+
+// findUser returns the organization user with the given email (case-insensitive) if
+// idOrEmail contains an @, or the user with the given ID otherwise.
+func findUser(c OpenAIOrgsClient, idOrEmail string) (*User, error) {
+	if !strings.Contains(idOrEmail, "@") {
+		user, err := c.RetrieveUser(idOrEmail)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve user %s: %w", idOrEmail, err)
+		}
+		return user, nil
+	}
+
+	users, err := ListAll(func(after string) (*ListResponse[User], error) {
+		return c.ListUsers(100, after)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	for i := range users {
+		if strings.EqualFold(users[i].Email, idOrEmail) {
+			return &users[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no user found with email %s", idOrEmail)
+}
+
+// addUserProjectUsage adds requests and tokens to the project's entry in usage.
+func addUserProjectUsage(usage map[string]UserProjectUsage, projectID string, requests, input, output int) {
+	u := usage[projectID]
+	u.ProjectID = projectID
+	u.Requests += requests
+	u.InputTokens += input
+	u.OutputTokens += output
+	usage[projectID] = u
+}
@@ -0,0 +1,103 @@
+package openaiorgs
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+// mockUserDescription sets up user_1, who is an owner of proj_1 with a key there, a
+// member of proj_2, and has audit events and usage in proj_1 and an archived project.
+func mockUserDescription(h *testHelper) {
+	joined := UnixSeconds(time.Unix(1710000000, 0))
+	user := User{ID: "user_1", Email: "Dev@Example.com", Role: "owner", AddedAt: UnixSeconds(time.Unix(1700000000, 0))}
+	h.mockResponse("GET", UsersListEndpoint, 200, ListResponse[User]{Object: "list", Data: []User{{ID: "user_2", Email: "other@example.com"}, user}})
+	h.mockResponse("GET", UsersListEndpoint+"/user_1", 200, user)
+	h.mockResponse("GET", ProjectsListEndpoint, 200, ListResponse[Project]{Object: "list", Data: []Project{{ID: "proj_1", Name: "One"}, {ID: "proj_2", Name: "Two"}}})
+	mockProjectInventory(h, "proj_1",
+		[]ProjectUser{{ID: "user_1", Role: "owner", AddedAt: joined}, {ID: "user_2", Role: "member"}},
+		nil,
+		[]ProjectApiKey{
+			{ID: "key_1", Name: "laptop", Owner: Owner{Type: OwnerTypeUser, User: &User{ID: "user_1"}}},
+			{ID: "key_2", Name: "other", Owner: Owner{Type: OwnerTypeUser, User: &User{ID: "user_2"}}},
+		})
+	mockProjectInventory(h, "proj_2", []ProjectUser{{ID: "user_1", Role: "member"}}, nil, nil)
+
+	httpmock.RegisterResponder("GET", testBaseURL+AuditLogsListEndpoint, func(req *http.Request) (*http.Response, error) {
+		if req.URL.Query().Get("actor_ids") != "user_1" {
+			return httpmock.NewStringResponse(400, "unexpected query "+req.URL.RawQuery), nil
+		}
+		return httpmock.NewJsonResponse(200, ListResponse[AuditLog]{Object: "list", Data: []AuditLog{{ID: "log_1", Type: "api_key.created"}}})
+	})
+	httpmock.RegisterResponder("GET", testBaseURL+usageCompletionsEndpoint, func(req *http.Request) (*http.Response, error) {
+		if req.URL.Query().Get("user_ids") != "user_1" || req.URL.Query().Get("group_by") != "project_id" {
+			return httpmock.NewStringResponse(400, "unexpected query "+req.URL.RawQuery), nil
+		}
+		return httpmock.NewJsonResponse(200, CompletionsUsageResponse{Data: []CompletionsUsageBucket{
+			{Results: []CompletionsUsageResult{
+				{ProjectID: "proj_1", NumModelRequests: 2, InputTokens: 100, OutputTokens: 20},
+				{ProjectID: "proj_old", NumModelRequests: 1, InputTokens: 10, OutputTokens: 5},
+			}},
+		}})
+	})
+	h.mockResponse("GET", usageEmbeddingsEndpoint, 200, EmbeddingsUsageResponse{Data: []EmbeddingsUsageBucket{
+		{Results: []EmbeddingsUsageResult{{ProjectID: "proj_1", NumModelRequests: 3, InputTokens: 300}}},
+	}})
+}
+
+func TestDescribeUser(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, identifier := range []string{"dev@example.com", "user_1"} {
+		t.Run(identifier, func(t *testing.T) {
+			h := newTestHelper(t)
+			defer h.cleanup()
+			mockUserDescription(h)
+
+			description, err := DescribeUser(h.client, identifier, UserDescriptionOptions{Now: now})
+			if err != nil {
+				t.Fatalf("DescribeUser() error = %v", err)
+			}
+			if description.User.ID != "user_1" || description.User.Role != "owner" || !description.Since.Equal(now.AddDate(0, 0, -30)) {
+				t.Errorf("unexpected user: %+v since %v", description.User, description.Since)
+			}
+			var memberships []string
+			for _, m := range description.Memberships {
+				memberships = append(memberships, m.ProjectID+":"+m.ProjectName+":"+m.Role)
+			}
+			if got := strings.Join(memberships, " "); got != "proj_1:One:owner proj_2:Two:member" {
+				t.Errorf("Memberships = %s", got)
+			}
+			if !description.Memberships[0].AddedAt.Time().Equal(time.Unix(1710000000, 0)) {
+				t.Errorf("AddedAt = %v", description.Memberships[0].AddedAt)
+			}
+			if len(description.APIKeys) != 1 || description.APIKeys[0].KeyID != "key_1" || description.APIKeys[0].ProjectName != "One" {
+				t.Errorf("unexpected API keys: %+v", description.APIKeys)
+			}
+			if len(description.AuditEvents) != 1 || description.AuditEvents[0].ID != "log_1" {
+				t.Errorf("unexpected audit events: %+v", description.AuditEvents)
+			}
+			wantUsage := []UserProjectUsage{
+				{ProjectID: "proj_1", ProjectName: "One", Requests: 5, InputTokens: 400, OutputTokens: 20},
+				{ProjectID: "proj_old", Requests: 1, InputTokens: 10, OutputTokens: 5},
+			}
+			if !reflect.DeepEqual(description.Usage, wantUsage) {
+				t.Errorf("Usage = %+v, want %+v", description.Usage, wantUsage)
+			}
+		})
+	}
+}
+
+func TestDescribeUser_NotFound(t *testing.T) {
+	h := newTestHelper(t)
+	defer h.cleanup()
+	mockUserDescription(h)
+
+	if _, err := DescribeUser(h.client, "nobody@example.com", UserDescriptionOptions{}); err == nil || !strings.Contains(err.Error(), "no user found") {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}